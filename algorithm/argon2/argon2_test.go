@@ -1,10 +1,14 @@
 package argon2
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
 )
 
 func TestNewVariant(t *testing.T) {
@@ -411,6 +415,8 @@ func TestDecode(t *testing.T) {
 	}{
 		{"ShouldFailInvalidFormat", "$", "argon2 decode error: provided encoded hash has an invalid format"},
 		{"ShouldFailUnknownIdentifier", "$unknown$v=19$m=65536,t=3,p=4$salt$key", "argon2 decode error: provided encoded hash has an invalid identifier: identifier 'unknown' is not an encoded argon2 digest"},
+		{"ShouldFailUnknownVersion", "$argon2id$v=18$m=32,t=3,p=4$c29tZXNhbHQ$TtRyqDKWDQ/Wd0WfeSb5tL9y337H5VQ21XjdXyToX9E", "argon2 decode error: provided encoded hash has an invalid version: versions 16 and 19 are supported but encoded hash is version 18"},
+		{"ShouldFailTooFewParts", "$argon2id$m=32,t=3,p=4$c29tZXNhbHQ", "argon2 decode error: provided encoded hash has an invalid format"},
 	}
 
 	for _, tc := range testCases {
@@ -524,3 +530,244 @@ func TestWithProfileRFC9106(t *testing.T) {
 		})
 	}
 }
+
+func TestWithSecretAndAssociatedData(t *testing.T) {
+	testCases := []struct {
+		name string
+		opt  Opt
+		err  string
+	}{
+		{"ShouldNotErrSecret", WithSecret([]byte("key1"), []byte("secret")), ""},
+		{"ShouldErrSecretWithoutKeyID", WithSecret(nil, []byte("secret")), "argon2 validation error: parameter is invalid: parameter 'keyid length' must be between 1 and 8 but is set to '0'"},
+		{"ShouldErrSecretEmptyKeyID", WithSecret([]byte{}, []byte("secret")), "argon2 validation error: parameter is invalid: parameter 'keyid length' must be between 1 and 8 but is set to '0'"},
+		{"ShouldErrKeyIDLength", WithSecret([]byte("key123456"), []byte("secret")), "argon2 validation error: parameter is invalid: parameter 'keyid length' must be between 1 and 8 but is set to '9'"},
+		{"ShouldErrEmptySecret", WithSecret([]byte("key1"), nil), "argon2 validation error: parameter is invalid: parameter 'secret length' must be between 1 and 2147483647 but is set to '0'"},
+		{"ShouldNotErrAssociatedData", WithAssociatedData([]byte("data")), ""},
+		{"ShouldErrEmptyAssociatedData", WithAssociatedData(nil), "argon2 validation error: parameter is invalid: parameter 'associated data length' must be between 1 and 32 but is set to '0'"},
+		{"ShouldErrAssociatedDataLength", WithAssociatedData(bytes.Repeat([]byte{0x04}, 33)), "argon2 validation error: parameter is invalid: parameter 'associated data length' must be between 1 and 32 but is set to '33'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opt(&Hasher{})
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestSecretAndAssociatedData(t *testing.T) {
+	var (
+		password = string(bytes.Repeat([]byte{0x01}, 32))
+		salt     = bytes.Repeat([]byte{0x02}, 16)
+		secret   = bytes.Repeat([]byte{0x03}, 8)
+		keyid    = []byte("key1")
+		data     = bytes.Repeat([]byte{0x04}, 12)
+	)
+
+	// The key is the RFC9106 Argon2id test vector.
+	encoded := "$argon2id$v=19$m=32,t=3,p=4,keyid=a2V5MQ,data=BAQEBAQEBAQEBAQE$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk"
+
+	hasher, err := New(WithVariantID(), WithT(3), WithM(32), WithP(4), WithK(32), WithSecret(keyid, secret), WithAssociatedData(data))
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt(password, salt)
+	require.NoError(t, err)
+
+	assert.Equal(t, encoded, digest.Encode())
+
+	lookup := func(id []byte) ([]byte, error) {
+		if string(id) != "key1" {
+			return nil, fmt.Errorf("key '%s' not found", id)
+		}
+
+		return secret, nil
+	}
+
+	t.Run("ShouldNotKeepSecretWhenHashing", func(t *testing.T) {
+		d, ok := digest.(*Digest)
+		require.True(t, ok)
+
+		match, err := d.MatchAdvanced(password)
+		assert.EqualError(t, err, "argon2 match error: could not resolve the secret: the digest has the key id 'a2V5MQ' but no secret lookup is configured")
+		assert.False(t, match)
+
+		d.WithSecretLookup(lookup)
+
+		assert.True(t, d.Match(password))
+	})
+
+	t.Run("ShouldMatchWithSecretLookup", func(t *testing.T) {
+		decoded, err := DecodeVariantWithSecretLookup(VariantNone, lookup)(encoded)
+		require.NoError(t, err)
+
+		d, ok := decoded.(*Digest)
+		require.True(t, ok)
+
+		assert.Equal(t, keyid, d.KeyID())
+		assert.Equal(t, data, d.AssociatedData())
+		assert.Equal(t, encoded, d.Encode())
+
+		match, err := d.MatchAdvanced(password)
+		assert.NoError(t, err)
+		assert.True(t, match)
+
+		assert.False(t, d.Match("wrong"))
+	})
+
+	t.Run("ShouldNotMatchWrongSecret", func(t *testing.T) {
+		decoded, err := Decode(encoded)
+		require.NoError(t, err)
+
+		d, ok := decoded.(*Digest)
+		require.True(t, ok)
+
+		d.WithSecretLookup(func(id []byte) ([]byte, error) {
+			return []byte("wrong"), nil
+		})
+
+		match, err := d.MatchAdvanced(password)
+		assert.NoError(t, err)
+		assert.False(t, match)
+	})
+
+	t.Run("ShouldErrWithoutSecretLookup", func(t *testing.T) {
+		decoded, err := Decode(encoded)
+		require.NoError(t, err)
+
+		match, err := decoded.MatchAdvanced(password)
+		assert.EqualError(t, err, "argon2 match error: could not resolve the secret: the digest has the key id 'a2V5MQ' but no secret lookup is configured")
+		assert.False(t, match)
+	})
+
+	t.Run("ShouldErrHashedWithoutSecretLookup", func(t *testing.T) {
+		hashed, err := hasher.Hash(password)
+		require.NoError(t, err)
+
+		decoded, err := Decode(hashed.Encode())
+		require.NoError(t, err)
+
+		match, err := decoded.MatchAdvanced(password)
+		assert.ErrorIs(t, err, algorithm.ErrSecretLookup)
+		assert.False(t, match)
+	})
+
+	t.Run("ShouldErrSecretLookup", func(t *testing.T) {
+		decoded, err := DecodeVariantWithSecretLookup(VariantID, lookup)("$argon2id$v=19$m=32,t=3,p=4,keyid=a2V5Mg$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+		require.NoError(t, err)
+
+		match, err := decoded.MatchAdvanced(password)
+		assert.EqualError(t, err, "argon2 match error: could not resolve the secret: key 'key2' not found")
+		assert.False(t, match)
+	})
+
+	t.Run("ShouldErrKeyIDLength", func(t *testing.T) {
+		_, err := Decode("$argon2id$v=19$m=32,t=3,p=4,keyid=a2V5MTIzNDU2$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+		assert.EqualError(t, err, "argon2 decode error: provided encoded hash has an invalid option value: option 'keyid' has 9 bytes but must not have more than 8 bytes")
+	})
+
+	t.Run("ShouldErrAssociatedDataLength", func(t *testing.T) {
+		_, err := Decode("$argon2id$v=19$m=32,t=3,p=4,data=BAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+		assert.EqualError(t, err, "argon2 decode error: provided encoded hash has an invalid option value: option 'data' has 33 bytes but must not have more than 32 bytes")
+	})
+
+	t.Run("ShouldErrInvalidKeyID", func(t *testing.T) {
+		_, err := Decode("$argon2id$v=19$m=32,t=3,p=4,keyid=!!$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+		assert.EqualError(t, err, "argon2 decode error: provided encoded hash has an invalid option value: option 'keyid' has invalid value '!!': illegal base64 data at input byte 0")
+	})
+}
+
+func TestDecodeVersion10(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldDecodeArgon2d", "$argon2d$v=16$m=32,t=3,p=4$c29tZXNhbHQ$wD5uo4josgbueHNT7r9iJfSkh1RpUvD8oqdu+wqz2K4", ""},
+		{"ShouldDecodeArgon2i", "$argon2i$v=16$m=32,t=3,p=4$c29tZXNhbHQ$pXQvnjv1mTZkj//FlgKcIkNhxe/kLMxwTUsve/SsBAM", ""},
+		{"ShouldDecodeArgon2id", "$argon2id$v=16$m=32,t=3,p=4$c29tZXNhbHQ$TtRyqDKWDQ/Wd0WfeSb5tL9y337H5VQ21XjdXyToX9E", ""},
+		{"ShouldDecodeArgon2iWithoutVersion", "$argon2i$m=32,t=3,p=4$c29tZXNhbHQ$pXQvnjv1mTZkj//FlgKcIkNhxe/kLMxwTUsve/SsBAM", "$argon2i$v=16$m=32,t=3,p=4$c29tZXNhbHQ$pXQvnjv1mTZkj//FlgKcIkNhxe/kLMxwTUsve/SsBAM"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, Version10, d.Version())
+
+			expected := tc.expected
+
+			if expected == "" {
+				expected = tc.have
+			}
+
+			assert.Equal(t, tc.have, d.Encode())
+			assert.Equal(t, expected, d.Canonical().Encode())
+			assert.Equal(t, tc.expected == "", d.Encode() == d.Canonical().Encode())
+
+			match, err := d.MatchAdvanced("password")
+			assert.NoError(t, err)
+			assert.True(t, match)
+
+			assert.False(t, d.Match("wrong"))
+		})
+	}
+}
+
+func TestDigestVersion(t *testing.T) {
+	hasher, err := New(WithT(1), WithP(1), WithM(8), WithK(32), WithS(16))
+	require.NoError(t, err)
+
+	digest, err := hasher.Hash("password")
+	require.NoError(t, err)
+
+	d, ok := digest.(*Digest)
+	require.True(t, ok)
+
+	assert.Equal(t, Version13, d.Version())
+}
+
+func TestNewDigest(t *testing.T) {
+	hasher, err := New(WithVariantID(), WithM(8192), WithT(1), WithP(2))
+	require.NoError(t, err)
+
+	hashed, err := hasher.Hash("password")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantID, 8192, 1, 2, hashed.Salt(), hashed.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, hashed.Encode(), digest.Encode())
+	assert.True(t, digest.Match("password"))
+
+	testCases := []struct {
+		name    string
+		variant Variant
+		m, t, p uint32
+		key     []byte
+		err     string
+	}{
+		{"ShouldErrVariant", VariantNone, 8192, 1, 2, []byte("key"), "argon2 decode error: parameter is invalid: variant must be specified"},
+		{"ShouldErrT", VariantID, 8192, 0, 2, []byte("key"), "argon2 decode error: parameter is invalid: parameter 't' must be between 1 and 2147483647 but is set to '0'"},
+		{"ShouldErrP", VariantID, 8192, 1, 0, []byte("key"), "argon2 decode error: parameter is invalid: parameter 'p' must be between 1 and 16777215 but is set to '0'"},
+		{"ShouldErrM", VariantID, 15, 1, 2, []byte("key"), "argon2 decode error: parameter is invalid: parameter 'm' must be between 16 (p * 8) and 4294967295 but is set to '15'"},
+		{"ShouldErrKey", VariantID, 8192, 1, 2, nil, "argon2 decode error: parameter is invalid: key has 0 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := NewDigest(tc.variant, tc.m, tc.t, tc.p, []byte("saltsalt"), tc.key)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -8,6 +8,17 @@ const (
 	// EncodingFmt is the encoding format for this algorithm.
 	EncodingFmt = "$%s$v=%d$m=%d,t=%d,p=%d$%s$%s"
 
+	// EncodingFmtParameters is the encoding format for this algorithm when the optional keyid or data parameters are
+	// present.
+	EncodingFmtParameters = "$%s$v=%d$m=%d,t=%d,p=%d,%s$%s$%s"
+
+	// Version10 is the legacy Argon2 version 1.0 (v=16). Digests with this version can only be verified, and should be
+	// rehashed as they're identified by the Version method of the argon2.Digest.
+	Version10 = 0x10
+
+	// Version13 is the Argon2 version 1.3 (v=19) which is the version used for all new digests.
+	Version13 = 0x13
+
 	// AlgName is the name for this algorithm.
 	AlgName = "argon2"
 
@@ -71,6 +82,17 @@ const (
 
 	// PasswordInputSizeMax is the maximum input for the password content.
 	PasswordInputSizeMax = math.MaxInt32
+
+	// SecretInputSizeMax is the maximum input for the secret content.
+	SecretInputSizeMax = math.MaxInt32
+
+	// KeyIDLengthMax is the maximum length of the key identifier of the secret which is limited by the PHC string
+	// format.
+	KeyIDLengthMax = 8
+
+	// AssociatedDataInputSizeMax is the maximum input for the associated data content which is limited by the PHC
+	// string format.
+	AssociatedDataInputSizeMax = 32
 )
 
 const (
@@ -85,4 +107,7 @@ const (
 	oM = "m"
 	oT = "t"
 	oP = "p"
+
+	oKeyID = "keyid"
+	oData  = "data"
 )
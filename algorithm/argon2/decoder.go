@@ -5,10 +5,8 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/go-crypt/x/argon2"
-
 	"github.com/go-crypt/crypt/algorithm"
-	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -55,6 +53,19 @@ func RegisterDecoderArgon2d(r algorithm.DecoderRegister) (err error) {
 	return nil
 }
 
+// RegisterDecoderWithSecretLookup registers all of the decoder variants with the algorithm.DecoderRegister using the
+// argon2.SecretLookupFunc to resolve the secret of the decoded digests. This should be used instead of
+// argon2.RegisterDecoder.
+func RegisterDecoderWithSecretLookup(r algorithm.DecoderRegister, lookup SecretLookupFunc) (err error) {
+	for _, variant := range []Variant{VariantID, VariantI, VariantD} {
+		if err = r.RegisterDecodeFunc(variant.Prefix(), DecodeVariantWithSecretLookup(variant, lookup)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Decode the encoded digest into a algorithm.Digest.
 func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	return DecodeVariant(VariantNone)(encodedDigest)
@@ -63,13 +74,19 @@ func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 // DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided argon2.Variant. If
 // argon2.VariantNone is used all variants can be decoded.
 func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeVariantWithSecretLookup(v, nil)
+}
+
+// DecodeVariantWithSecretLookup is the same as DecodeVariant except the decoded argon2.Digest uses the
+// argon2.SecretLookupFunc to resolve the secret when matching.
+func DecodeVariantWithSecretLookup(v Variant, lookup SecretLookupFunc) func(encodedDigest string) (digest algorithm.Digest, err error) {
 	return func(encodedDigest string) (digest algorithm.Digest, err error) {
 		var (
-			parts   []string
+			s       *phc.String
 			variant Variant
 		)
 
-		if variant, parts, err = decoderParts(encodedDigest); err != nil {
+		if variant, s, err = decoderParts(encodedDigest); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -77,7 +94,7 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
 		}
 
-		if digest, err = decode(variant, parts); err != nil {
+		if digest, err = decode(variant, s, lookup); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -85,76 +102,113 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
-func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+// NewDigest returns an argon2.Digest from the raw parameters, salt, and key which is useful when the values of a digest
+// are not stored in the encoded format. The memory parameter m is in KiB and the digest uses argon2.Version13.
+func NewDigest(variant Variant, m, t, p uint32, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant == VariantNone:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be specified", algorithm.ErrParameterInvalid))
+	case t < IterationsMin:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "t", IterationsMin, "", IterationsMax, t))
+	case p < ParallelismMin || p > ParallelismMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "p", ParallelismMin, "", ParallelismMax, p))
+	case uint64(m) < uint64(p)*MemoryMinParallelismMultiplier:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "m", p*MemoryMinParallelismMultiplier, " (p * 8)", MemoryMax, m))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	}
+
+	return &Digest{variant: variant, v: Version13, m: m, t: t, p: p, salt: salt, key: key}, nil
+}
 
-	if len(parts) != 6 {
-		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
+func decoderParts(encodedDigest string) (variant Variant, s *phc.String, err error) {
+	if s, err = phc.Decode(encodedDigest, phc.WithHashRequired(), phc.WithParameterEncoding(phc.B64, oKeyID, oData)); err != nil {
+		return VariantNone, nil, err
 	}
 
-	variant = NewVariant(parts[1])
+	variant = NewVariant(s.Identifier)
 
 	if variant == VariantNone {
-		return variant, nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
+		return variant, nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, s.Identifier, AlgName)
 	}
 
-	return variant, parts[2:], nil
+	return variant, s, nil
 }
 
 //nolint:gocyclo
-func decode(variant Variant, parts []string) (digest algorithm.Digest, err error) {
+func decode(variant Variant, s *phc.String, lookup SecretLookupFunc) (digest algorithm.Digest, err error) {
 	decoded := &Digest{
 		variant: variant,
+		v:       Version10,
+		lookup:  lookup,
 	}
 
-	var (
-		value   uint64
-		bitSize int
-	)
+	var value uint64
 
-	var params []encoding.Parameter
+	// The encoding used prior to version 1.3 omits the version section entirely.
+	if s.Version == "" {
+		decoded.omitVersion = true
+	} else {
+		if value, err = strconv.ParseUint(s.Version, 10, 8); err != nil {
+			return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, oV, s.Version, err)
+		}
 
-	if params, err = encoding.DecodeParameterStr(parts[1] + "," + parts[0]); err != nil {
-		return nil, err
+		decoded.v = uint8(value)
+
+		if decoded.v != Version10 && decoded.v != Version13 {
+			return nil, fmt.Errorf("%w: versions %d and %d are supported but encoded hash is version %d", algorithm.ErrEncodedHashInvalidVersion, Version10, Version13, decoded.v)
+		}
 	}
 
-	for _, param := range params {
+	for _, param := range s.Parameters {
 		switch param.Key {
-		case oV:
-			bitSize = 8
+		case oKeyID, oData:
+			var raw []byte
+
+			if raw, err = base64.RawStdEncoding.DecodeString(param.Value); err != nil {
+				return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, param.Value, err)
+			}
+
+			if param.Key == oKeyID {
+				if len(raw) > KeyIDLengthMax {
+					return nil, fmt.Errorf("%w: option '%s' has %d bytes but must not have more than %d bytes", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, len(raw), KeyIDLengthMax)
+				}
+
+				decoded.keyid = raw
+			} else {
+				if len(raw) > AssociatedDataInputSizeMax {
+					return nil, fmt.Errorf("%w: option '%s' has %d bytes but must not have more than %d bytes", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, len(raw), AssociatedDataInputSizeMax)
+				}
+
+				decoded.data = raw
+			}
+
+			continue
+		case oK, oM, oT, oP:
+			break
 		default:
-			bitSize = 32
+			return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
 		}
 
-		if value, err = strconv.ParseUint(param.Value, 10, bitSize); err != nil {
+		if value, err = strconv.ParseUint(param.Value, 10, 32); err != nil {
 			return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, param.Value, err)
 		}
 
 		switch param.Key {
-		case oV:
-			decoded.v = uint8(value)
-
-			if decoded.v != argon2.Version {
-				return nil, fmt.Errorf("%w: version %d is supported but encoded hash is version %d", algorithm.ErrEncodedHashInvalidVersion, argon2.Version, decoded.v)
-			}
-		case oK:
-			break
 		case oM:
 			decoded.m = uint32(value)
 		case oT:
 			decoded.t = uint32(value)
 		case oP:
 			decoded.p = uint32(value)
-		default:
-			return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
 		}
 	}
 
-	if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+	if decoded.salt, err = phc.B64.DecodeString(s.Salt); err != nil {
 		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashSaltEncoding, err)
 	}
 
-	if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+	if decoded.key, err = phc.B64.DecodeString(s.Hash); err != nil {
 		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
 	}
 
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/go-crypt/x/argon2"
-
 	"github.com/go-crypt/crypt/algorithm"
+	iargon2 "github.com/go-crypt/crypt/internal/argon2"
 	"github.com/go-crypt/crypt/internal/math"
 )
 
+// SecretLookupFunc describes a function which resolves the secret (K) for an argon2.Digest using the key identifier
+// encoded in the keyid parameter. The keyid is nil if the encoded digest doesn't have a keyid parameter.
+type SecretLookupFunc func(keyid []byte) (secret []byte, err error)
+
 // Digest is a digest which handles Argon2 hashes like Argon2id, Argon2i, and Argon2d.
 type Digest struct {
 	variant Variant
@@ -21,6 +24,15 @@ type Digest struct {
 	m, t, p uint32
 
 	salt, key []byte
+
+	keyid, data []byte
+
+	lookup SecretLookupFunc
+
+	algorithm.Prefix
+
+	// omitVersion is true when the version section was omitted from the encoded digest.
+	omitVersion bool
 }
 
 // Match returns true if the string password matches the current argon2.Digest.
@@ -46,14 +58,47 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
 	}
 
-	return subtle.ConstantTimeCompare(d.key, d.variant.KeyFunc()(passwordBytes, d.salt, d.t, d.m, d.p, uint32(len(d.key)))) == 1, nil
+	var secret []byte
+
+	if secret, err = d.resolveSecret(); err != nil {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, err)
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.derive(passwordBytes, secret, uint32(len(d.key)))) == 1, nil
 }
 
 // Encode returns the encoded form of this argon2.Digest.
 func (d *Digest) Encode() (encodedHash string) {
-	return strings.ReplaceAll(fmt.Sprintf(EncodingFmt,
-		d.variant.Prefix(), argon2.Version,
-		d.m, d.t, d.p,
+	if d.omitVersion {
+		return d.StoragePrefix() + strings.Replace(d.encode(), fmt.Sprintf("$%s=%d$", oV, d.v), "$", 1)
+	}
+
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this argon2.Digest without the storage prefix.
+func (d *Digest) encode() (encodedHash string) {
+	var params []string
+
+	if len(d.keyid) != 0 {
+		params = append(params, fmt.Sprintf("%s=%s", oKeyID, base64.RawStdEncoding.EncodeToString(d.keyid)))
+	}
+
+	if len(d.data) != 0 {
+		params = append(params, fmt.Sprintf("%s=%s", oData, base64.RawStdEncoding.EncodeToString(d.data)))
+	}
+
+	if len(params) == 0 {
+		return strings.ReplaceAll(fmt.Sprintf(EncodingFmt,
+			d.variant.Prefix(), d.v,
+			d.m, d.t, d.p,
+			base64.RawStdEncoding.EncodeToString(d.salt), base64.RawStdEncoding.EncodeToString(d.key),
+		), "\n", "")
+	}
+
+	return strings.ReplaceAll(fmt.Sprintf(EncodingFmtParameters,
+		d.variant.Prefix(), d.v,
+		d.m, d.t, d.p, strings.Join(params, ","),
 		base64.RawStdEncoding.EncodeToString(d.salt), base64.RawStdEncoding.EncodeToString(d.key),
 	), "\n", "")
 }
@@ -73,6 +118,84 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the argon2.Variant of this argon2.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// M returns the memory cost in kibibytes used to generate this digest.
+func (d *Digest) M() (m uint32) {
+	return d.m
+}
+
+// T returns the time cost (number of passes) used to generate this digest.
+func (d *Digest) T() (t uint32) {
+	return d.t
+}
+
+// P returns the parallelism used to generate this digest.
+func (d *Digest) P() (p uint32) {
+	return d.p
+}
+
+// Canonical returns a copy of this argon2.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix, c.omitVersion = algorithm.Prefix{}, false
+
+	return &c
+}
+
+// Version returns the Argon2 version of this argon2.Digest which is either argon2.Version10 or argon2.Version13. Digests
+// with the argon2.Version10 version should be rehashed.
+func (d *Digest) Version() (version int) {
+	return int(d.v)
+}
+
+// KeyID returns the raw unencoded key identifier of the secret used to generate this digest.
+func (d *Digest) KeyID() (keyid []byte) {
+	return d.keyid
+}
+
+// AssociatedData returns the raw unencoded associated data used to generate this digest.
+func (d *Digest) AssociatedData() (data []byte) {
+	return d.data
+}
+
+// WithSecretLookup sets the argon2.SecretLookupFunc used to resolve the secret when matching this argon2.Digest.
+func (d *Digest) WithSecretLookup(lookup SecretLookupFunc) {
+	d.lookup = lookup
+}
+
+// resolveSecret returns the secret for this digest using the argon2.SecretLookupFunc. The secret is never kept by the
+// argon2.Digest, including when it's the result of a hashing operation.
+func (d *Digest) resolveSecret() (secret []byte, err error) {
+	if d.lookup == nil {
+		if d.keyid != nil {
+			return nil, fmt.Errorf("%w: the digest has the key id '%s' but no secret lookup is configured", algorithm.ErrSecretLookup, base64.RawStdEncoding.EncodeToString(d.keyid))
+		}
+
+		return nil, nil
+	}
+
+	if secret, err = d.lookup(d.keyid); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrSecretLookup, err)
+	}
+
+	return secret, nil
+}
+
+// derive the key for this argon2.Digest using the password bytes and the secret. The internal implementation is only
+// used when the inputs of the digest aren't supported by the argon2.KeyFunc of the argon2.Variant.
+func (d *Digest) derive(passwordBytes, secret []byte, keyLen uint32) (key []byte) {
+	if d.v == Version13 && len(secret) == 0 && len(d.data) == 0 {
+		return d.variant.KeyFunc()(passwordBytes, d.salt, d.t, d.m, d.p, keyLen)
+	}
+
+	return iargon2.Key(d.variant.mode(), int(d.v), passwordBytes, d.salt, secret, d.data, d.t, d.m, d.p, keyLen)
+}
+
 func (d *Digest) defaults() {
 	switch d.variant {
 	case VariantID, VariantI, VariantD:
@@ -81,6 +204,13 @@ func (d *Digest) defaults() {
 		d.variant = variantDefault
 	}
 
+	switch d.v {
+	case Version10, Version13:
+		break
+	default:
+		d.v = Version13
+	}
+
 	if d.t < IterationsMin {
 		d.t = IterationsDefault
 	}
@@ -30,6 +30,8 @@ type Hasher struct {
 
 	m uint32
 
+	keyid, secret, data []byte
+
 	d bool
 }
 
@@ -47,6 +49,7 @@ func (h *Hasher) WithOptions(opts ...Opt) (err error) {
 // Copy copies all parameters from this argon2.Hasher to another *argon2.Hasher.
 func (h *Hasher) Copy(hasher *Hasher) {
 	hasher.variant, hasher.t, hasher.p, hasher.m, hasher.k, hasher.s = h.variant, h.t, h.p, h.m, h.k, h.s
+	hasher.keyid, hasher.secret, hasher.data = h.keyid, h.secret, h.data
 }
 
 // Clone returns a clone from this argon2.Hasher to another *argon2.Hasher.
@@ -58,6 +61,9 @@ func (h *Hasher) Clone() *Hasher {
 		m:       h.m,
 		k:       h.k,
 		s:       h.s,
+		keyid:   h.keyid,
+		secret:  h.secret,
+		data:    h.data,
 	}
 }
 
@@ -86,6 +92,14 @@ func (h *Hasher) Merge(hash *Hasher) {
 	if hash.s == 0 {
 		hash.s = h.s
 	}
+
+	if hash.secret == nil {
+		hash.keyid, hash.secret = h.keyid, h.secret
+	}
+
+	if hash.data == nil {
+		hash.data = h.data
+	}
 }
 
 // Hash performs the hashing operation and returns either a argon2.Digest or an error.
@@ -138,11 +152,13 @@ func (h *Hasher) hashWithSalt(passwordRaw string, salt []byte) (digest algorithm
 		p:       uint32(h.p),
 		m:       h.m,
 		salt:    salt,
+		keyid:   h.keyid,
+		data:    h.data,
 	}
 
 	d.defaults()
 
-	d.key = d.variant.KeyFunc()(password, d.salt, d.t, d.m, d.p, uint32(h.k))
+	d.key = d.derive(password, h.secret, uint32(h.k))
 
 	return d, nil
 }
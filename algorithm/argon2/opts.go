@@ -199,6 +199,52 @@ func WithSaltLength(s int) Opt {
 	return WithS(s)
 }
 
+// WithSecret satisfies the argon2.Opt type for the argon2.Hasher and sets input 'K' known as the secret value along
+// with the key identifier of the secret.
+//
+// Secret value K is OPTIONAL. If used, it MUST have a length not greater than 2^(32)-1 bytes.
+//
+// The secret is never encoded in or kept by the resulting argon2.Digest, instead the keyid is encoded as the PHC keyid
+// parameter so the secret can be resolved using an argon2.SecretLookupFunc when matching. The keyid is required so the
+// resulting digests can't be matched without the secret, and must not be longer than 8 bytes.
+//
+// RFC9106 section 3.1 "Argon2 Inputs and Outputs" https://www.rfc-editor.org/rfc/rfc9106.html#name-argon2-inputs-and-outputs.
+func WithSecret(keyid, secret []byte) Opt {
+	return func(h *Hasher) (err error) {
+		if len(secret) == 0 || len(secret) > SecretInputSizeMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "secret length", 1, "", SecretInputSizeMax, len(secret)))
+		}
+
+		if len(keyid) == 0 || len(keyid) > KeyIDLengthMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "keyid length", 1, "", KeyIDLengthMax, len(keyid)))
+		}
+
+		h.keyid, h.secret = keyid, secret
+
+		return nil
+	}
+}
+
+// WithAssociatedData satisfies the argon2.Opt type for the argon2.Hasher and sets input 'X' known as the associated
+// data.
+//
+// Associated data X is OPTIONAL. If used, it MUST have a length not greater than 2^(32)-1 bytes.
+//
+// The associated data is encoded as the PHC data parameter which limits it to 32 bytes.
+//
+// RFC9106 section 3.1 "Argon2 Inputs and Outputs" https://www.rfc-editor.org/rfc/rfc9106.html#name-argon2-inputs-and-outputs.
+func WithAssociatedData(data []byte) Opt {
+	return func(h *Hasher) (err error) {
+		if len(data) == 0 || len(data) > AssociatedDataInputSizeMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "associated data length", 1, "", AssociatedDataInputSizeMax, len(data)))
+		}
+
+		h.data = data
+
+		return nil
+	}
+}
+
 // WithProfileRFC9106Recommended is the recommended standard RFC9106 profile.
 //
 // RFC9106 section 4.0 "Parameter Choice" https://www.rfc-editor.org/rfc/rfc9106.html#name-parameter-choice
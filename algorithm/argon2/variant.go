@@ -2,6 +2,8 @@ package argon2
 
 import (
 	"github.com/go-crypt/x/argon2"
+
+	iargon2 "github.com/go-crypt/crypt/internal/argon2"
 )
 
 // NewVariant converts an identifier string to a argon2.Variant.
@@ -67,3 +69,15 @@ func (v Variant) KeyFunc() argon2.KeyFunc {
 		return nil
 	}
 }
+
+// mode returns the internal argon2 mode of this argon2.Variant.
+func (v Variant) mode() (mode int) {
+	switch v {
+	case VariantI:
+		return iargon2.ModeI
+	case VariantD:
+		return iargon2.ModeD
+	default:
+		return iargon2.ModeID
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/x/bcrypt"
 )
 
 func TestNewVariant(t *testing.T) {
@@ -22,6 +24,11 @@ func TestNewVariant(t *testing.T) {
 		{"ShouldReturnStandardForCommon", "common", VariantStandard},
 		{"ShouldReturnSHA256", "bcrypt-sha256", VariantSHA256},
 		{"ShouldReturnSHA256ForName", "sha256", VariantSHA256},
+		{"ShouldReturnSHA256V1ForName", "sha256-v1", VariantSHA256V1},
+		{"ShouldReturnSHA384", "bcrypt-sha384", VariantSHA384},
+		{"ShouldReturnSHA384ForName", "sha384", VariantSHA384},
+		{"ShouldReturnSHA512", "bcrypt-sha512", VariantSHA512},
+		{"ShouldReturnSHA512ForName", "sha512", VariantSHA512},
 		{"ShouldReturnNoneForUnknown", "nope", VariantNone},
 	}
 
@@ -40,6 +47,9 @@ func TestVariantString(t *testing.T) {
 	}{
 		{"ShouldReturnStandard", VariantStandard, "standard"},
 		{"ShouldReturnSHA256", VariantSHA256, "sha256"},
+		{"ShouldReturnSHA256V1", VariantSHA256V1, "sha256-v1"},
+		{"ShouldReturnSHA384", VariantSHA384, "sha384"},
+		{"ShouldReturnSHA512", VariantSHA512, "sha512"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -58,6 +68,9 @@ func TestVariantPrefix(t *testing.T) {
 	}{
 		{"ShouldReturnStandardPrefix", VariantStandard, "2b"},
 		{"ShouldReturnSHA256Prefix", VariantSHA256, "bcrypt-sha256"},
+		{"ShouldReturnSHA256V1Prefix", VariantSHA256V1, "bcrypt-sha256"},
+		{"ShouldReturnSHA384Prefix", VariantSHA384, "bcrypt-sha384"},
+		{"ShouldReturnSHA512Prefix", VariantSHA512, "bcrypt-sha512"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -76,6 +89,9 @@ func TestVariantPasswordMaxLength(t *testing.T) {
 	}{
 		{"ShouldReturnStandardMax", VariantStandard, 72},
 		{"ShouldReturnSHA256NoLimit", VariantSHA256, -1},
+		{"ShouldReturnSHA256V1NoLimit", VariantSHA256V1, -1},
+		{"ShouldReturnSHA384NoLimit", VariantSHA384, -1},
+		{"ShouldReturnSHA512NoLimit", VariantSHA512, -1},
 	}
 
 	for _, tc := range testCases {
@@ -93,7 +109,10 @@ func TestWithVariant(t *testing.T) {
 	}{
 		{"ShouldNotErrStandard", VariantStandard, ""},
 		{"ShouldNotErrSHA256", VariantSHA256, ""},
+		{"ShouldNotErrSHA384", VariantSHA384, ""},
+		{"ShouldNotErrSHA512", VariantSHA512, ""},
 		{"ShouldNotErrNone", VariantNone, ""},
+		{"ShouldErrSHA256V1", VariantSHA256V1, "bcrypt validation error: parameter is invalid: variant 'sha256-v1' can only be used to verify digests"},
 		{"ShouldErrInvalid", Variant(99), "bcrypt validation error: parameter is invalid: variant '99' is invalid"},
 	}
 
@@ -119,7 +138,9 @@ func TestWithVariantName(t *testing.T) {
 	}{
 		{"ShouldNotErrStandard", "standard", ""},
 		{"ShouldNotErrSHA256", "sha256", ""},
+		{"ShouldNotErrSHA512", "bcrypt-sha512", ""},
 		{"ShouldNotErrEmpty", "", ""},
+		{"ShouldErrSHA256V1", "sha256-v1", "bcrypt validation error: parameter is invalid: variant 'sha256-v1' can only be used to verify digests"},
 		{"ShouldErrInvalid", "invalid", "bcrypt validation error: parameter is invalid: variant identifier 'invalid' is invalid"},
 	}
 
@@ -303,6 +324,11 @@ func TestDecode(t *testing.T) {
 		{"ShouldFailInvalidFormat", "$", "bcrypt decode error: provided encoded hash has an invalid format"},
 		{"ShouldFailTooFewParts", "$2b$", "bcrypt decode error: provided encoded hash has an invalid format"},
 		{"ShouldFailUnknownIdentifier", "$unknown$10$abc", "bcrypt decode error: provided encoded hash has an invalid identifier: identifier 'unknown' is not an encoded bcrypt digest"},
+		{"ShouldFailSHA256V1NoCost", "$bcrypt-sha256$2b$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq", "bcrypt decode error: provided encoded hash has an invalid format: options '2b' are not in the version and cost format"},
+		{"ShouldFailSHA256V1BadVersion", "$bcrypt-sha256$2y,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq", "bcrypt decode error: provided encoded hash has an invalid version: version '2y' is not supported"},
+		{"ShouldFailSHA256V1BadCost", "$bcrypt-sha256$2b,ab$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq", "bcrypt decode error: provided encoded hash has an invalid option value: iterations could not be parsed: strconv.Atoi: parsing \"ab\": invalid syntax"},
+		{"ShouldFailSHA256V1BadSalt", "$bcrypt-sha256$2b,10$E/e/2AOEqqqqq$ezuk8qOS1HsmW622joN8tgodaYyp.sq", "bcrypt decode error: provided encoded hash has a salt value that can't be decoded: salt is expected to be 22 bytes but it has 13 bytes"},
+		{"ShouldFailSHA512WrongVariant", "$bcrypt-sha512$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq", "bcrypt decode error: provided encoded hash has an invalid option: parameter pair '2b' is not properly encoded: does not contain kv separator '='"},
 	}
 
 	for _, tc := range testCases {
@@ -354,3 +380,205 @@ func TestDigestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestSHA256V1(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{
+			"ShouldDecodeVersionB",
+			"$bcrypt-sha256$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+			"$bcrypt-sha256$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+		},
+		{
+			"ShouldDecodeVersionA",
+			"$bcrypt-sha256$2a,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+			"$bcrypt-sha256$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := DecodeVariant(VariantSHA256)(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, VariantSHA256V1, d.variant)
+			assert.Equal(t, 10, d.iterations)
+			assert.True(t, d.Match("password"))
+			assert.False(t, d.Match("wrong"))
+			assert.Equal(t, tc.have, d.Encode())
+			assert.Equal(t, tc.expected, d.Canonical().Encode())
+		})
+	}
+
+	_, err := DecodeVariant(VariantSHA384)("$bcrypt-sha256$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq")
+	assert.EqualError(t, err, "bcrypt decode error: the 'sha256-v1' variant cannot be decoded only the 'sha384' variant can be")
+}
+
+func TestHashWithSaltPreHashVariants(t *testing.T) {
+	salt, err := bcrypt.Base64Decode([]byte("E/e/2AOEqqqqqqqqqqqqqe"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		new      func(opts ...Opt) (*Hasher, error)
+		expected string
+	}{
+		{"ShouldHashSHA256", NewSHA256, "$bcrypt-sha256$v=2,t=2b,r=10$E/e/2AOEqqqqqqqqqqqqqe$CXtK6JyHw9WUyUe2YcKw2SZzu1oyJUu"},
+		{"ShouldHashSHA384", NewSHA384, "$bcrypt-sha384$v=2,t=2b,r=10$E/e/2AOEqqqqqqqqqqqqqe$JRqYp0g7CT.Zv8.Q17FnsmXYq6qiEPe"},
+		{"ShouldHashSHA512", NewSHA512, "$bcrypt-sha512$v=2,t=2b,r=10$E/e/2AOEqqqqqqqqqqqqqe$96EdadHIY99dTuFohZJ3kLf0vq/XlZK"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := tc.new(WithIterations(10))
+			require.NoError(t, err)
+
+			digest, err := h.HashWithSalt("password", salt)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, digest.Encode())
+
+			decoded, err := Decode(tc.expected)
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match("password"))
+			assert.False(t, decoded.Match("wrong"))
+			assert.Equal(t, tc.expected, decoded.Encode())
+		})
+	}
+}
+
+func TestDigestCanonical(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		prefix    string
+		canonical string
+	}{
+		{
+			"ShouldRetainStandardVersionA",
+			"$2a$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+		{
+			"ShouldRetainStandardVersionY",
+			"$2y$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+		{
+			"ShouldRetainSHA256Type",
+			"$bcrypt-sha256$v=2,t=2a,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC",
+			"",
+			"$bcrypt-sha256$v=2,t=2b,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC",
+		},
+		{
+			"ShouldRetainStoragePrefix",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"{CRYPT}",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			d.WithStoragePrefix(tc.prefix)
+
+			assert.Equal(t, tc.prefix, d.StoragePrefix())
+			assert.Equal(t, tc.prefix+tc.have, d.Encode())
+			assert.Equal(t, tc.canonical, d.Canonical().Encode())
+			assert.Equal(t, tc.canonical, d.Canonical().Encode())
+			assert.NotEqual(t, d.Encode(), d.Canonical().Encode())
+			assert.Equal(t, d.Canonical().Encode(), d.Canonical().(*Digest).Canonical().Encode())
+			assert.True(t, d.Canonical().Match("password"))
+		})
+	}
+}
+
+func TestDecodeCostRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldRoundTripCostPadded", "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW"},
+		{"ShouldRoundTripCostMinimum", "$2b$04$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW"},
+		{"ShouldRoundTripCost", "$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.have, digest.Encode())
+		})
+	}
+
+	digest, err := Decode("$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("U*U"))
+
+	d := digest.(*Digest)
+
+	created, err := NewDigest(VariantStandard, d.Iterations(), d.Salt(), d.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, "$2b$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW", created.Encode())
+
+	decoded, err := Decode(created.Encode())
+	require.NoError(t, err)
+
+	assert.Equal(t, created.Encode(), decoded.Encode())
+	assert.True(t, decoded.Match("U*U"))
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e")
+	require.NoError(t, err)
+
+	d := decoded.(*Digest)
+
+	digest, err := NewDigest(VariantStandard, d.Iterations(), d.Salt(), d.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	testCases := []struct {
+		name    string
+		variant Variant
+		cost    int
+		salt    []byte
+		key     []byte
+		err     string
+	}{
+		{"ShouldErrVariant", VariantNone, 10, d.Salt(), d.Key(), "bcrypt decode error: parameter is invalid: variant must be specified"},
+		{"ShouldErrVariantUnknown", Variant(42), 10, d.Salt(), d.Key(), "bcrypt decode error: parameter is invalid: variant '42' is not a known variant"},
+		{"ShouldErrVariantNegative", Variant(-1), 10, d.Salt(), d.Key(), "bcrypt decode error: parameter is invalid: variant '-1' is not a known variant"},
+		{"ShouldErrCost", VariantStandard, 3, d.Salt(), d.Key(), "bcrypt decode error: parameter is invalid: parameter 'cost' must be between 4 and 31 but is set to '3'"},
+		{"ShouldErrSalt", VariantStandard, 10, []byte("salt"), d.Key(), "bcrypt decode error: parameter is invalid: salt is expected to be 16 bytes but it has 4 bytes"},
+		{"ShouldErrKey", VariantStandard, 10, d.Salt(), []byte("key"), "bcrypt decode error: parameter is invalid: key is expected to be 31 bytes but it has 3 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := NewDigest(tc.variant, tc.cost, tc.salt, tc.key)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
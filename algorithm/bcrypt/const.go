@@ -6,11 +6,14 @@ import (
 
 const (
 	// EncodingFmt is the encoding format for this algorithm.
-	EncodingFmt = "$%s$%d$%s%s"
+	EncodingFmt = "$%s$%02d$%s%s"
 
-	// EncodingFmtSHA256 is the encoding format for the SHA256 variant of this algorithm.
+	// EncodingFmtSHA256 is the encoding format for the SHA256, SHA384, and SHA512 variants of this algorithm.
 	EncodingFmtSHA256 = "$%s$v=2,t=%s,r=%d$%s$%s"
 
+	// EncodingFmtSHA256V1 is the encoding format for the version 1 SHA256 variant of this algorithm.
+	EncodingFmtSHA256V1 = "$%s$%s,%d$%s$%s"
+
 	// AlgName is the name for this algorithm.
 	AlgName = "bcrypt"
 
@@ -20,6 +23,12 @@ const (
 	// AlgIdentifierVariantSHA256 is the identifier used in encoded SHA256 variant of this algorithm.
 	AlgIdentifierVariantSHA256 = "bcrypt-sha256"
 
+	// AlgIdentifierVariantSHA384 is the identifier used in encoded SHA384 variant of this algorithm.
+	AlgIdentifierVariantSHA384 = "bcrypt-sha384"
+
+	// AlgIdentifierVariantSHA512 is the identifier used in encoded SHA512 variant of this algorithm.
+	AlgIdentifierVariantSHA512 = "bcrypt-sha512"
+
 	// AlgIdentifierVerA is the identifier used in this algorithm (version a).
 	AlgIdentifierVerA = "2a"
 
@@ -38,6 +47,15 @@ const (
 	// VariantNameSHA256 is the variant name of the bcrypt.VariantSHA256.
 	VariantNameSHA256 = algorithm.DigestSHA256
 
+	// VariantNameSHA256V1 is the variant name of the bcrypt.VariantSHA256V1.
+	VariantNameSHA256V1 = algorithm.DigestSHA256 + "-v1"
+
+	// VariantNameSHA384 is the variant name of the bcrypt.VariantSHA384.
+	VariantNameSHA384 = algorithm.DigestSHA384
+
+	// VariantNameSHA512 is the variant name of the bcrypt.VariantSHA512.
+	VariantNameSHA512 = algorithm.DigestSHA512
+
 	// IterationsMin is the minimum iterations accepted.
 	IterationsMin = 10
 
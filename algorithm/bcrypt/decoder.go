@@ -3,11 +3,12 @@ package bcrypt
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-crypt/x/bcrypt"
 
 	"github.com/go-crypt/crypt/algorithm"
-	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -20,6 +21,14 @@ func RegisterDecoder(r algorithm.DecoderRegister) (err error) {
 		return err
 	}
 
+	if err = RegisterDecoderSHA384(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderSHA512(r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -50,7 +59,8 @@ func RegisterDecoderStandard(r algorithm.DecoderRegister) (err error) {
 	return nil
 }
 
-// RegisterDecoderSHA256 registers specifically the sha256 decoder variant with the algorithm.DecoderRegister.
+// RegisterDecoderSHA256 registers specifically the sha256 decoder variant with the algorithm.DecoderRegister. This
+// decoder also decodes the version 1 sha256 variant as both versions share the same identifier.
 func RegisterDecoderSHA256(r algorithm.DecoderRegister) (err error) {
 	if err = r.RegisterDecodeFunc(VariantSHA256.Prefix(), DecodeVariant(VariantSHA256)); err != nil {
 		return err
@@ -59,29 +69,49 @@ func RegisterDecoderSHA256(r algorithm.DecoderRegister) (err error) {
 	return nil
 }
 
+// RegisterDecoderSHA384 registers specifically the sha384 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderSHA384(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantSHA384.Prefix(), DecodeVariant(VariantSHA384)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderSHA512 registers specifically the sha512 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderSHA512(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantSHA512.Prefix(), DecodeVariant(VariantSHA512)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Decode the encoded digest into a algorithm.Digest.
 func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	return DecodeVariant(VariantNone)(encodedDigest)
 }
 
 // DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided bcrypt.Variant. If
-// bcrypt.VariantNone is used all variants can be decoded.
+// bcrypt.VariantNone is used all variants can be decoded. The bcrypt.VariantSHA256 also decodes the
+// bcrypt.VariantSHA256V1 as both versions share the same identifier.
 func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
 	return func(encodedDigest string) (digest algorithm.Digest, err error) {
 		var (
-			parts   []string
-			variant Variant
+			parts      []string
+			variant    Variant
+			identifier string
 		)
 
-		if variant, parts, err = decoderParts(encodedDigest); err != nil {
+		if variant, identifier, parts, err = decoderParts(encodedDigest); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
-		if v != VariantNone && v != variant {
+		if v != VariantNone && v != variant && (v != VariantSHA256 || variant != VariantSHA256V1) {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
 		}
 
-		if digest, err = decode(variant, parts); err != nil {
+		if digest, err = decode(variant, identifier, encodedDigest, parts); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -89,23 +119,48 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
-func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+// NewDigest returns a bcrypt.Digest from the raw cost, salt, and key which is useful when the values of a digest are
+// not stored in the encoded format. The salt is the raw 16 byte salt and the key is the 31 byte bcrypt base64 encoded
+// key in the same manner as they are returned by the Salt and Key functions.
+func NewDigest(variant Variant, cost int, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant == VariantNone:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be specified", algorithm.ErrParameterInvalid))
+	case variant < VariantStandard || variant > VariantSHA512:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant '%d' is not a known variant", algorithm.ErrParameterInvalid, variant))
+	case cost < bcrypt.MinCost || cost > IterationsMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "cost", bcrypt.MinCost, "", IterationsMax, cost))
+	case len(salt) != algorithm.SaltLengthDefault:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt is expected to be %d bytes but it has %d bytes", algorithm.ErrParameterInvalid, algorithm.SaltLengthDefault, len(salt)))
+	case len(key) != bcrypt.EncodedHashSize:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key is expected to be %d bytes but it has %d bytes", algorithm.ErrParameterInvalid, bcrypt.EncodedHashSize, len(key)))
+	}
+
+	return &Digest{variant: variant, iterations: cost, salt: salt, key: key}, nil
+}
+
+func decoderParts(encodedDigest string) (variant Variant, identifier string, parts []string, err error) {
+	parts = phc.Split(encodedDigest, -1)
 
 	if len(parts) < 4 {
-		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
+		return VariantNone, "", nil, algorithm.ErrEncodedHashInvalidFormat
 	}
 
 	variant = NewVariant(parts[1])
 
-	if variant == VariantNone {
-		return variant, nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
+	switch variant {
+	case VariantNone:
+		return variant, "", nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
+	case VariantSHA256:
+		if !strings.Contains(parts[2], "=") {
+			variant = VariantSHA256V1
+		}
 	}
 
-	return variant, parts[2:], nil
+	return variant, parts[1], parts[2:], nil
 }
 
-func decode(variant Variant, parts []string) (digest algorithm.Digest, err error) {
+func decode(variant Variant, identifier, encodedDigest string, parts []string) (digest algorithm.Digest, err error) {
 	countParts := len(parts)
 
 	var (
@@ -136,7 +191,7 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 		}
 
 		salt, key = bcrypt.DecodeSecret([]byte(parts[1]))
-	case VariantSHA256:
+	case VariantSHA256, VariantSHA256V1, VariantSHA384, VariantSHA512:
 		if countParts != 3 {
 			return nil, algorithm.ErrEncodedHashInvalidFormat
 		}
@@ -161,16 +216,28 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 			return nil, fmt.Errorf("%w: key is expected to be %d bytes but it has %d bytes", algorithm.ErrEncodedHashKeyEncoding, bcrypt.EncodedHashSize, n)
 		}
 
-		var params []encoding.Parameter
+		identifier = ""
+
+		if decoded.variant == VariantSHA256V1 {
+			if identifier, decoded.iterations, err = decodeSHA256V1Options(parts[0]); err != nil {
+				return nil, err
+			}
 
-		if params, err = encoding.DecodeParameterStr(parts[0]); err != nil {
+			break
+		}
+
+		var encoded *phc.String
+
+		if encoded, err = phc.Decode(encodedDigest, phc.WithHashRequired(), phc.WithSaltEncoding(nil), phc.WithHashEncoding(nil)); err != nil {
 			return nil, err
 		}
 
-		for _, param := range params {
+		for _, param := range encoded.Parameters {
 			switch param.Key {
-			case oV, oT:
+			case oV:
 				break
+			case oT:
+				identifier = param.Value
 			case oR:
 				decoded.iterations, err = param.Int()
 			default:
@@ -193,5 +260,30 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 
 	decoded.key = key
 
+	if identifier != AlgIdentifier {
+		decoded.identifier = identifier
+	}
+
 	return decoded, nil
 }
+
+func decodeSHA256V1Options(options string) (version string, iterations int, err error) {
+	version, cost, found := strings.Cut(options, ",")
+
+	if !found {
+		return "", 0, fmt.Errorf("%w: options '%s' are not in the version and cost format", algorithm.ErrEncodedHashInvalidFormat, options)
+	}
+
+	switch version {
+	case AlgIdentifier, AlgIdentifierVerA:
+		break
+	default:
+		return "", 0, fmt.Errorf("%w: version '%s' is not supported", algorithm.ErrEncodedHashInvalidVersion, version)
+	}
+
+	if iterations, err = strconv.Atoi(cost); err != nil {
+		return "", 0, fmt.Errorf("%w: iterations could not be parsed: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+	}
+
+	return version, iterations, nil
+}
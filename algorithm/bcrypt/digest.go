@@ -16,6 +16,10 @@ type Digest struct {
 	iterations int
 
 	salt, key []byte
+
+	algorithm.Prefix
+
+	identifier string
 }
 
 // Match returns true if the string password matches the current bcrypt.Digest.
@@ -54,7 +58,18 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode returns the encoded form of this bcrypt.Digest.
 func (d *Digest) Encode() string {
-	return d.variant.Encode(d.iterations, AlgIdentifier, bcrypt.Base64Encode(d.salt), d.key)
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this bcrypt.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	version := AlgIdentifier
+
+	if d.identifier != "" {
+		version = d.identifier
+	}
+
+	return d.variant.Encode(d.iterations, version, bcrypt.Base64Encode(d.salt), d.key)
 }
 
 // String returns the storable format of the bcrypt.Digest encoded hash.
@@ -72,11 +87,30 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the bcrypt.Variant of this bcrypt.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Iterations returns the cost used to generate this digest.
+func (d *Digest) Iterations() (iterations int) {
+	return d.iterations
+}
+
+// Canonical returns a copy of this bcrypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix, c.identifier = algorithm.Prefix{}, ""
+
+	return &c
+}
+
 func (d *Digest) defaults() {
 	switch d.variant {
 	case VariantNone:
 		d.variant = VariantStandard
-	case VariantStandard, VariantSHA256:
+	case VariantStandard, VariantSHA256, VariantSHA256V1, VariantSHA384, VariantSHA512:
 		break
 	default:
 		d.variant = variantDefault
@@ -38,6 +38,34 @@ func NewSHA256(opts ...Opt) (hasher *Hasher, err error) {
 	return hasher, nil
 }
 
+// NewSHA384 returns a new bcrypt.Hasher with the provided functional options applied as well as the bcrypt.VariantSHA384
+// applied via the bcrypt.WithVariant bcrypt.Opt.
+func NewSHA384(opts ...Opt) (hasher *Hasher, err error) {
+	if hasher, err = New(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.WithOptions(WithVariant(VariantSHA384)); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// NewSHA512 returns a new bcrypt.Hasher with the provided functional options applied as well as the bcrypt.VariantSHA512
+// applied via the bcrypt.WithVariant bcrypt.Opt.
+func NewSHA512(opts ...Opt) (hasher *Hasher, err error) {
+	if hasher, err = New(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.WithOptions(WithVariant(VariantSHA512)); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
 // Hasher is a crypt.Hash for bcrypt which can be initialized via bcrypt.New using a functional options pattern.
 type Hasher struct {
 	variant Variant
@@ -14,10 +14,12 @@ type Opt func(h *Hasher) (err error)
 func WithVariant(variant Variant) Opt {
 	return func(h *Hasher) (err error) {
 		switch variant {
-		case VariantNone, VariantStandard, VariantSHA256:
+		case VariantNone, VariantStandard, VariantSHA256, VariantSHA384, VariantSHA512:
 			h.variant = variant
 
 			return nil
+		case VariantSHA256V1:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%s' can only be used to verify digests", algorithm.ErrParameterInvalid, variant))
 		default:
 			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant))
 		}
@@ -38,9 +40,7 @@ func WithVariantName(identifier string) Opt {
 			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
 		}
 
-		h.variant = variant
-
-		return nil
+		return WithVariant(variant)(h)
 	}
 }
 
@@ -3,10 +3,13 @@ package bcrypt
 import (
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
 	"fmt"
 
 	"github.com/go-crypt/x/bcrypt"
+
+	"github.com/go-crypt/crypt/algorithm"
 )
 
 // NewVariant converts an identifier string to a bcrypt.Variant.
@@ -16,6 +19,12 @@ func NewVariant(identifier string) (variant Variant) {
 		return VariantStandard
 	case AlgIdentifierVariantSHA256, VariantNameSHA256:
 		return VariantSHA256
+	case VariantNameSHA256V1:
+		return VariantSHA256V1
+	case AlgIdentifierVariantSHA384, VariantNameSHA384:
+		return VariantSHA384
+	case AlgIdentifierVariantSHA512, VariantNameSHA512:
+		return VariantSHA512
 	default:
 		return VariantNone
 	}
@@ -33,6 +42,17 @@ const (
 
 	// VariantSHA256 is the variant of bcrypt.Digest which hashes the password with HMAC-SHA256.
 	VariantSHA256
+
+	// VariantSHA256V1 is the version 1 variant of bcrypt.Digest which hashes the password with SHA256. This variant
+	// shares the identifier with bcrypt.VariantSHA256 but uses the Modular Crypt Format and can only be used to verify
+	// existing digests.
+	VariantSHA256V1
+
+	// VariantSHA384 is the variant of bcrypt.Digest which hashes the password with HMAC-SHA384.
+	VariantSHA384
+
+	// VariantSHA512 is the variant of bcrypt.Digest which hashes the password with HMAC-SHA512.
+	VariantSHA512
 )
 
 // String implements the fmt.Stringer returning a string representation of the bcrypt.Variant.
@@ -42,6 +62,12 @@ func (v Variant) String() (name string) {
 		return VariantNameStandard
 	case VariantSHA256:
 		return VariantNameSHA256
+	case VariantSHA256V1:
+		return VariantNameSHA256V1
+	case VariantSHA384:
+		return VariantNameSHA384
+	case VariantSHA512:
+		return VariantNameSHA512
 	default:
 		return
 	}
@@ -52,8 +78,12 @@ func (v Variant) Prefix() (prefix string) {
 	switch v {
 	case VariantStandard:
 		return AlgIdentifier
-	case VariantSHA256:
+	case VariantSHA256, VariantSHA256V1:
 		return AlgIdentifierVariantSHA256
+	case VariantSHA384:
+		return AlgIdentifierVariantSHA384
+	case VariantSHA512:
+		return AlgIdentifierVariantSHA512
 	default:
 		return
 	}
@@ -62,7 +92,7 @@ func (v Variant) Prefix() (prefix string) {
 // PasswordMaxLength returns -1 if the variant has no max length, otherwise returns the maximum password length.
 func (v Variant) PasswordMaxLength() int {
 	switch v {
-	case VariantSHA256:
+	case VariantSHA256, VariantSHA256V1, VariantSHA384, VariantSHA512:
 		return -1
 	default:
 		return PasswordInputSizeMax
@@ -74,28 +104,50 @@ func (v Variant) Encode(cost int, version string, salt, key []byte) (f string) {
 	switch v {
 	case VariantStandard:
 		return fmt.Sprintf(EncodingFmt, version, cost, salt, key)
-	case VariantSHA256:
+	case VariantSHA256, VariantSHA384, VariantSHA512:
 		return fmt.Sprintf(EncodingFmtSHA256, v.Prefix(), version, cost, salt, key)
+	case VariantSHA256V1:
+		return fmt.Sprintf(EncodingFmtSHA256V1, v.Prefix(), version, cost, salt, key)
 	default:
 		return
 	}
 }
 
 // EncodeInput returns the appropriate algorithm input.
+//
+// The input for the bcrypt.VariantSHA512 is the 88 byte base64 encoded HMAC-SHA512 sum, as bcrypt only uses the first
+// 72 bytes of the input this is effectively 54 bytes of the sum. This is consistent regardless of the password length.
 func (v Variant) EncodeInput(src, salt []byte) (dst []byte) {
+	var digest []byte
+
 	switch v {
-	case VariantSHA256:
-		h := hmac.New(sha256.New, bcrypt.Base64Encode(salt))
+	case VariantSHA256, VariantSHA384, VariantSHA512:
+		h := hmac.New(v.hashFunc(), bcrypt.Base64Encode(salt))
 		h.Write(src)
 
-		digest := h.Sum(nil)
+		digest = h.Sum(nil)
+	case VariantSHA256V1:
+		sum := sha256.Sum256(src)
 
-		dst = make([]byte, base64.StdEncoding.EncodedLen(len(digest)))
+		digest = sum[:]
+	default:
+		return src
+	}
+
+	dst = make([]byte, base64.StdEncoding.EncodedLen(len(digest)))
 
-		base64.StdEncoding.Encode(dst, digest)
+	base64.StdEncoding.Encode(dst, digest)
 
-		return dst
+	return dst
+}
+
+func (v Variant) hashFunc() algorithm.HashFunc {
+	switch v {
+	case VariantSHA384:
+		return sha512.New384
+	case VariantSHA512:
+		return sha512.New
 	default:
-		return src
+		return sha256.New
 	}
 }
@@ -15,6 +15,18 @@ const (
 
 	// DigestSHA512 is te name for SHA512 digests.
 	DigestSHA512 = "sha512"
+
+	// DigestSHA512_256 is the name for SHA512/256 digests.
+	DigestSHA512_256 = "sha512-256"
+
+	// DigestSHA3_256 is the name for SHA3-256 digests.
+	DigestSHA3_256 = "sha3-256"
+
+	// DigestSHA3_512 is the name for SHA3-512 digests.
+	DigestSHA3_512 = "sha3-512"
+
+	// DigestBLAKE2b_512 is the name for BLAKE2b-512 digests.
+	DigestBLAKE2b_512 = "blake2b-512"
 )
 
 const (
@@ -0,0 +1,56 @@
+package descrypt
+
+const (
+	// AlgName is the name for this algorithm.
+	AlgName = "descrypt"
+
+	// AlgIdentifier is the identifier used to register the standard variant of this algorithm. Encoded digests of this
+	// algorithm do not contain an identifier.
+	AlgIdentifier = AlgName
+
+	// AlgIdentifierVariantExtended is the identifier used to register the extended variant of this algorithm.
+	AlgIdentifierVariantExtended = "bsdicrypt"
+
+	// PrefixVariantExtended is the prefix used by encoded digests of the extended variant of this algorithm.
+	PrefixVariantExtended = "_"
+
+	// VariantNameStandard is the descrypt.Variant name for descrypt.VariantStandard.
+	VariantNameStandard = "standard"
+
+	// VariantNameExtended is the descrypt.Variant name for descrypt.VariantExtended.
+	VariantNameExtended = "extended"
+
+	// SaltLengthStandard is the encoded salt length for the descrypt.VariantStandard.
+	SaltLengthStandard = 2
+
+	// SaltLengthExtended is the encoded salt length for the descrypt.VariantExtended.
+	SaltLengthExtended = 4
+
+	// KeyLength is the encoded key length.
+	KeyLength = 11
+
+	// PasswordInputSizeMax is the maximum password input size accepted by the descrypt.VariantStandard. Additional
+	// characters are ignored by the algorithm.
+	PasswordInputSizeMax = 8
+
+	// IterationsStandard is the fixed number of iterations for the descrypt.VariantStandard.
+	IterationsStandard = 25
+
+	// IterationsMin is the minimum number of iterations accepted by the descrypt.VariantExtended.
+	IterationsMin = 1
+
+	// IterationsMax is the maximum number of iterations accepted by the descrypt.VariantExtended.
+	IterationsMax = 1<<24 - 1
+
+	// IterationsDefault is the default number of iterations for the descrypt.VariantExtended.
+	IterationsDefault = 725
+
+	// SaltCharSet are the valid characters for the salt.
+	SaltCharSet = itoa64
+)
+
+const (
+	itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	variantDefault = VariantStandard
+)
@@ -0,0 +1,139 @@
+package descrypt
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = RegisterDecoderStandard(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderExtended(r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderStandard registers specifically the standard decoder variant with the algorithm.DecoderMatchRegister.
+func RegisterDecoderStandard(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantStandard.Prefix(), DecodeVariant(VariantStandard)); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(VariantStandard.Prefix(), VariantStandard.MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderExtended registers specifically the extended decoder variant with the algorithm.DecoderMatchRegister.
+func RegisterDecoderExtended(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantExtended.Prefix(), DecodeVariant(VariantExtended)); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(VariantExtended.Prefix(), VariantExtended.MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeVariant(VariantNone)(encodedDigest)
+}
+
+// DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided descrypt.Variant. If
+// descrypt.VariantNone is used all variants can be decoded.
+func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
+	return func(encodedDigest string) (digest algorithm.Digest, err error) {
+		var variant Variant
+
+		if variant, err = decoderVariant(encodedDigest); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		if v != VariantNone && v != variant {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
+		}
+
+		if digest, err = decode(variant, encodedDigest); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+}
+
+func decoderVariant(encodedDigest string) (variant Variant, err error) {
+	switch {
+	case VariantExtended.MatchEncoded(encodedDigest):
+		return VariantExtended, nil
+	case VariantStandard.MatchEncoded(encodedDigest):
+		return VariantStandard, nil
+	default:
+		return VariantNone, algorithm.ErrEncodedHashInvalidFormat
+	}
+}
+
+// NewDigest returns a descrypt.Digest from the raw parameters, salt, and key which is useful when the values of a
+// digest are not stored in the encoded format. The iterations must be descrypt.IterationsStandard for the
+// descrypt.VariantStandard variant. The salt and key are in the same form as returned by the Salt and Key methods.
+func NewDigest(variant Variant, iterations int, salt, key []byte) (digest *Digest, err error) {
+	var saltLength int
+
+	switch variant {
+	case VariantStandard:
+		if iterations != IterationsStandard {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsStandard, "", IterationsStandard, iterations))
+		}
+
+		saltLength = SaltLengthStandard
+	case VariantExtended:
+		if iterations < IterationsMin || iterations > IterationsMax {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations))
+		}
+
+		saltLength = SaltLengthExtended
+	default:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantStandard, VariantExtended))
+	}
+
+	switch {
+	case len(salt) != saltLength || !validCharSet(string(salt)):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt must be %d characters from the crypt character set", algorithm.ErrParameterInvalid, saltLength))
+	case len(key) != KeyLength || !validCharSet(string(key)):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key must be %d characters from the crypt character set", algorithm.ErrParameterInvalid, KeyLength))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, salt: salt, key: key}, nil
+}
+
+func decode(variant Variant, encodedDigest string) (digest algorithm.Digest, err error) {
+	decoded := &Digest{
+		variant: variant,
+	}
+
+	switch variant {
+	case VariantExtended:
+		decoded.iterations = int(decode64LittleEndian([]byte(encodedDigest[1:5])))
+
+		if decoded.iterations < IterationsMin {
+			return nil, fmt.Errorf("%w: iterations must be between %d and %d but is %d", algorithm.ErrEncodedHashInvalidOptionValue, IterationsMin, IterationsMax, decoded.iterations)
+		}
+
+		decoded.salt, decoded.key = []byte(encodedDigest[5:9]), []byte(encodedDigest[9:])
+	default:
+		decoded.iterations = IterationsStandard
+		decoded.salt, decoded.key = []byte(encodedDigest[:2]), []byte(encodedDigest[2:])
+	}
+
+	return decoded, nil
+}
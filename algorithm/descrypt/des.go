@@ -0,0 +1,189 @@
+package descrypt
+
+// This file implements the salted DES variant used by the traditional and BSDi extended crypt(3) functions. The
+// standard library crypto/des can't be used as the salt perturbs the output of the E-box.
+
+var (
+	desIP = [64]byte{
+		58, 50, 42, 34, 26, 18, 10, 2, 60, 52, 44, 36, 28, 20, 12, 4,
+		62, 54, 46, 38, 30, 22, 14, 6, 64, 56, 48, 40, 32, 24, 16, 8,
+		57, 49, 41, 33, 25, 17, 9, 1, 59, 51, 43, 35, 27, 19, 11, 3,
+		61, 53, 45, 37, 29, 21, 13, 5, 63, 55, 47, 39, 31, 23, 15, 7,
+	}
+
+	desFP = [64]byte{
+		40, 8, 48, 16, 56, 24, 64, 32, 39, 7, 47, 15, 55, 23, 63, 31,
+		38, 6, 46, 14, 54, 22, 62, 30, 37, 5, 45, 13, 53, 21, 61, 29,
+		36, 4, 44, 12, 52, 20, 60, 28, 35, 3, 43, 11, 51, 19, 59, 27,
+		34, 2, 42, 10, 50, 18, 58, 26, 33, 1, 41, 9, 49, 17, 57, 25,
+	}
+
+	desP = [32]byte{
+		16, 7, 20, 21, 29, 12, 28, 17, 1, 15, 23, 26, 5, 18, 31, 10,
+		2, 8, 24, 14, 32, 27, 3, 9, 19, 13, 30, 6, 22, 11, 4, 25,
+	}
+
+	desPC1 = [56]byte{
+		57, 49, 41, 33, 25, 17, 9, 1, 58, 50, 42, 34, 26, 18,
+		10, 2, 59, 51, 43, 35, 27, 19, 11, 3, 60, 52, 44, 36,
+		63, 55, 47, 39, 31, 23, 15, 7, 62, 54, 46, 38, 30, 22,
+		14, 6, 61, 53, 45, 37, 29, 21, 13, 5, 28, 20, 12, 4,
+	}
+
+	desPC2 = [48]byte{
+		14, 17, 11, 24, 1, 5, 3, 28, 15, 6, 21, 10,
+		23, 19, 12, 4, 26, 8, 16, 7, 27, 20, 13, 2,
+		41, 52, 31, 37, 47, 55, 30, 40, 51, 45, 33, 48,
+		44, 49, 39, 56, 34, 53, 46, 42, 50, 36, 29, 32,
+	}
+
+	desRotations = [16]byte{1, 1, 2, 2, 2, 2, 2, 2, 1, 2, 2, 2, 2, 2, 2, 1}
+
+	desS = [8][64]byte{
+		{
+			14, 4, 13, 1, 2, 15, 11, 8, 3, 10, 6, 12, 5, 9, 0, 7,
+			0, 15, 7, 4, 14, 2, 13, 1, 10, 6, 12, 11, 9, 5, 3, 8,
+			4, 1, 14, 8, 13, 6, 2, 11, 15, 12, 9, 7, 3, 10, 5, 0,
+			15, 12, 8, 2, 4, 9, 1, 7, 5, 11, 3, 14, 10, 0, 6, 13,
+		},
+		{
+			15, 1, 8, 14, 6, 11, 3, 4, 9, 7, 2, 13, 12, 0, 5, 10,
+			3, 13, 4, 7, 15, 2, 8, 14, 12, 0, 1, 10, 6, 9, 11, 5,
+			0, 14, 7, 11, 10, 4, 13, 1, 5, 8, 12, 6, 9, 3, 2, 15,
+			13, 8, 10, 1, 3, 15, 4, 2, 11, 6, 7, 12, 0, 5, 14, 9,
+		},
+		{
+			10, 0, 9, 14, 6, 3, 15, 5, 1, 13, 12, 7, 11, 4, 2, 8,
+			13, 7, 0, 9, 3, 4, 6, 10, 2, 8, 5, 14, 12, 11, 15, 1,
+			13, 6, 4, 9, 8, 15, 3, 0, 11, 1, 2, 12, 5, 10, 14, 7,
+			1, 10, 13, 0, 6, 9, 8, 7, 4, 15, 14, 3, 11, 5, 2, 12,
+		},
+		{
+			7, 13, 14, 3, 0, 6, 9, 10, 1, 2, 8, 5, 11, 12, 4, 15,
+			13, 8, 11, 5, 6, 15, 0, 3, 4, 7, 2, 12, 1, 10, 14, 9,
+			10, 6, 9, 0, 12, 11, 7, 13, 15, 1, 3, 14, 5, 2, 8, 4,
+			3, 15, 0, 6, 10, 1, 13, 8, 9, 4, 5, 11, 12, 7, 2, 14,
+		},
+		{
+			2, 12, 4, 1, 7, 10, 11, 6, 8, 5, 3, 15, 13, 0, 14, 9,
+			14, 11, 2, 12, 4, 7, 13, 1, 5, 0, 15, 10, 3, 9, 8, 6,
+			4, 2, 1, 11, 10, 13, 7, 8, 15, 9, 12, 5, 6, 3, 0, 14,
+			11, 8, 12, 7, 1, 14, 2, 13, 6, 15, 0, 9, 10, 4, 5, 3,
+		},
+		{
+			12, 1, 10, 15, 9, 2, 6, 8, 0, 13, 3, 4, 14, 7, 5, 11,
+			10, 15, 4, 2, 7, 12, 9, 5, 6, 1, 13, 14, 0, 11, 3, 8,
+			9, 14, 15, 5, 2, 8, 12, 3, 7, 0, 4, 10, 1, 13, 11, 6,
+			4, 3, 2, 12, 9, 5, 15, 10, 11, 14, 1, 7, 6, 0, 8, 13,
+		},
+		{
+			4, 11, 2, 14, 15, 0, 8, 13, 3, 12, 9, 7, 5, 10, 6, 1,
+			13, 0, 11, 7, 4, 9, 1, 10, 14, 3, 5, 12, 2, 15, 8, 6,
+			1, 4, 11, 13, 12, 3, 7, 14, 10, 15, 6, 8, 0, 5, 9, 2,
+			6, 11, 13, 8, 1, 4, 10, 7, 9, 5, 0, 15, 14, 2, 3, 12,
+		},
+		{
+			13, 2, 8, 4, 6, 15, 11, 1, 10, 9, 3, 14, 5, 0, 12, 7,
+			1, 15, 13, 8, 10, 3, 7, 4, 12, 5, 6, 11, 0, 14, 9, 2,
+			7, 11, 4, 1, 9, 12, 14, 2, 0, 6, 10, 13, 15, 3, 5, 8,
+			2, 1, 14, 7, 4, 10, 8, 13, 15, 12, 9, 0, 3, 5, 6, 11,
+		},
+	}
+
+	// desSP is the combination of the S-boxes and the P permutation indexed by the 6-bit S-box input.
+	desSP [8][64]uint32
+)
+
+func init() {
+	for i := 0; i < 8; i++ {
+		for v := 0; v < 64; v++ {
+			row, col := (v>>4)&0x2|v&0x1, (v>>1)&0xf
+
+			desSP[i][v] = uint32(permute(uint64(desS[i][row*16+col])<<(28-4*i), desP[:], 32))
+		}
+	}
+}
+
+// permute applies the 1-indexed most significant bit first permutation table to the lowest n bits of in.
+func permute(in uint64, table []byte, n int) (out uint64) {
+	for _, position := range table {
+		out = out<<1 | (in>>(n-int(position)))&1
+	}
+
+	return out
+}
+
+// desSchedule is the 16 round key schedule with each 48-bit round key split into two 24-bit halves.
+type desSchedule [16][2]uint32
+
+func newDESSchedule(key uint64) (schedule *desSchedule) {
+	schedule = &desSchedule{}
+
+	cd := permute(key, desPC1[:], 64)
+
+	c, d := uint32(cd>>28)&0xfffffff, uint32(cd)&0xfffffff
+
+	for i, rotation := range desRotations {
+		c = (c<<rotation | c>>(28-rotation)) & 0xfffffff
+		d = (d<<rotation | d>>(28-rotation)) & 0xfffffff
+
+		k := permute(uint64(c)<<28|uint64(d), desPC2[:], 56)
+
+		schedule[i][0], schedule[i][1] = uint32(k>>24), uint32(k)&0xffffff
+	}
+
+	return schedule
+}
+
+// desSaltBits converts a crypt(3) salt into the E-box swap mask where salt bit i swaps bit i and bit i + 24 of the
+// E-box output.
+func desSaltBits(salt uint32) (bits uint32) {
+	for i := 0; i < 24; i++ {
+		if salt&(1<<i) != 0 {
+			bits |= 0x800000 >> i
+		}
+	}
+
+	return bits
+}
+
+// encrypt performs count encryptions of the block using the key schedule and salt bits.
+func (s *desSchedule) encrypt(block uint64, saltBits uint32, count int) uint64 {
+	block = permute(block, desIP[:], 64)
+
+	l, r := uint32(block>>32), uint32(block)
+
+	for ; count > 0; count-- {
+		for i := 0; i < 16; i++ {
+			l, r = r, l^s.feistel(r, i, saltBits)
+		}
+
+		l, r = r, l
+	}
+
+	return permute(uint64(l)<<32|uint64(r), desFP[:], 64)
+}
+
+func (s *desSchedule) feistel(r uint32, round int, saltBits uint32) (f uint32) {
+	// The E-box output is built as eight 6-bit groups from the 32-bit input rotated right by 1.
+	rr := uint64(r>>1 | r<<31)
+	rr = rr<<32 | rr
+
+	var el, er uint32
+
+	for i := 0; i < 4; i++ {
+		el = el<<6 | uint32(rr>>(58-4*i))&0x3f
+		er = er<<6 | uint32(rr>>(42-4*i))&0x3f
+	}
+
+	t := (el ^ er) & saltBits
+
+	el ^= t ^ s[round][0]
+	er ^= t ^ s[round][1]
+
+	for i := 0; i < 4; i++ {
+		f |= desSP[i][(el>>(18-6*i))&0x3f] | desSP[i+4][(er>>(18-6*i))&0x3f]
+	}
+
+	return f
+}
@@ -0,0 +1,355 @@
+package descrypt
+
+import (
+	"crypto/des"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDESScheduleEncrypt(t *testing.T) {
+	key := []byte{0x13, 0x34, 0x57, 0x79, 0x9b, 0xbc, 0xdf, 0xf1}
+	block := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+
+	cipher, err := des.NewCipher(key)
+	require.NoError(t, err)
+
+	expected := make([]byte, 8)
+
+	cipher.Encrypt(expected, block)
+
+	actual := newDESSchedule(binary.BigEndian.Uint64(key)).encrypt(binary.BigEndian.Uint64(block), 0, 1)
+
+	assert.Equal(t, binary.BigEndian.Uint64(expected), actual)
+}
+
+func TestNewVariant(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Variant
+	}{
+		{"ShouldReturnStandard", "descrypt", VariantStandard},
+		{"ShouldReturnStandardName", "standard", VariantStandard},
+		{"ShouldReturnExtended", "bsdicrypt", VariantExtended},
+		{"ShouldReturnExtendedName", "extended", VariantExtended},
+		{"ShouldReturnExtendedPrefix", "_", VariantExtended},
+		{"ShouldReturnNoneForUnknown", "unknown", VariantNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewVariant(tc.have))
+		})
+	}
+}
+
+func TestVariantString(t *testing.T) {
+	assert.Equal(t, "standard", VariantStandard.String())
+	assert.Equal(t, "extended", VariantExtended.String())
+	assert.Equal(t, "", VariantNone.String())
+}
+
+func TestVariantPrefix(t *testing.T) {
+	assert.Equal(t, "descrypt", VariantStandard.Prefix())
+	assert.Equal(t, "bsdicrypt", VariantExtended.Prefix())
+	assert.Equal(t, "", VariantNone.Prefix())
+}
+
+func TestVariantMatchEncoded(t *testing.T) {
+	testCases := []struct {
+		name     string
+		variant  Variant
+		have     string
+		expected bool
+	}{
+		{"ShouldMatchStandard", VariantStandard, "abJnggxhB/yWI", true},
+		{"ShouldNotMatchStandardShort", VariantStandard, "abJnggxhB/yW", false},
+		{"ShouldNotMatchStandardInvalidChars", VariantStandard, "abJnggxhB/yW$", false},
+		{"ShouldNotMatchStandardExtended", VariantStandard, "_J9..saltJW8FtKdEkNM", false},
+		{"ShouldMatchExtended", VariantExtended, "_J9..saltJW8FtKdEkNM", true},
+		{"ShouldNotMatchExtendedWithoutPrefix", VariantExtended, "$J9..saltJW8FtKdEkNM", false},
+		{"ShouldNotMatchExtendedStandard", VariantExtended, "abJnggxhB/yWI", false},
+		{"ShouldNotMatchNone", VariantNone, "abJnggxhB/yWI", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.variant.MatchEncoded(tc.have))
+		})
+	}
+}
+
+func TestWithVariant(t *testing.T) {
+	testCases := []struct {
+		name string
+		have Variant
+		err  string
+	}{
+		{"ShouldNotErrStandard", VariantStandard, ""},
+		{"ShouldNotErrExtended", VariantExtended, ""},
+		{"ShouldNotErrNone", VariantNone, ""},
+		{"ShouldErrInvalid", Variant(99), "descrypt validation error: parameter is invalid: variant '99' is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+			err := WithVariant(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithVariantName(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldNotErrStandard", "standard", ""},
+		{"ShouldNotErrExtended", "bsdicrypt", ""},
+		{"ShouldNotErrEmpty", "", ""},
+		{"ShouldErrInvalid", "invalid", "descrypt validation error: parameter is invalid: variant identifier 'invalid' is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+			err := WithVariantName(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithIterations(t *testing.T) {
+	testCases := []struct {
+		name string
+		have int
+		err  string
+	}{
+		{"ShouldNotErrMin", 1, ""},
+		{"ShouldNotErrMax", 16777215, ""},
+		{"ShouldErrBelowMin", 0, "descrypt validation error: parameter is invalid: parameter 'iterations' must be between 1 and 16777215 but is set to '0'"},
+		{"ShouldErrAboveMax", 16777216, "descrypt validation error: parameter is invalid: parameter 'iterations' must be between 1 and 16777215 but is set to '16777216'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+			err := WithIterations(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithRounds(t *testing.T) {
+	h := &Hasher{}
+
+	assert.NoError(t, WithRounds(1000)(h))
+	assert.Equal(t, 1000, h.iterations)
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		password string
+		err      string
+	}{
+		{"ShouldDecodeStandard", "abJnggxhB/yWI", "password", ""},
+		{"ShouldDecodeStandardEmptyPassword", "..X8NBuQ4l6uQ", "", ""},
+		{"ShouldDecodeStandardTest", "zZ2FT51eQDxN6", "test", ""},
+		{"ShouldDecodeExtended", "_J9..saltJW8FtKdEkNM", "password", ""},
+		{"ShouldDecodeExtendedLongPassword", "_J9..saltLoBPP3svkZ2", "a much longer password here", ""},
+		{"ShouldDecodeExtendedSingleIteration", "_/...abcdJZJP1o1hSpg", "password", ""},
+		{"ShouldFailExtendedZeroIterations", "_....abcdJZJP1o1hSpg", "", "descrypt decode error: provided encoded hash has an invalid option value: iterations must be between 1 and 16777215 but is 0"},
+		{"ShouldFailInvalidFormat", "$1$abc$def", "", "descrypt decode error: provided encoded hash has an invalid format"},
+		{"ShouldFailEmptyString", "", "", "descrypt decode error: provided encoded hash has an invalid format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.True(t, digest.Match(tc.password))
+			assert.False(t, digest.Match("wrong"))
+			assert.Equal(t, tc.have, digest.Encode())
+			assert.Equal(t, tc.have, digest.String())
+		})
+	}
+}
+
+func TestDecodeVariant(t *testing.T) {
+	_, err := DecodeVariant(VariantStandard)("_J9..saltJW8FtKdEkNM")
+	assert.EqualError(t, err, "descrypt decode error: the 'extended' variant cannot be decoded only the 'standard' variant can be")
+
+	_, err = DecodeVariant(VariantExtended)("abJnggxhB/yWI")
+	assert.EqualError(t, err, "descrypt decode error: the 'standard' variant cannot be decoded only the 'extended' variant can be")
+}
+
+func TestDigestMatchStandardTruncation(t *testing.T) {
+	digest, err := Decode("abJnggxhB/yWI")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("password123456"))
+}
+
+func TestHashAndDecode(t *testing.T) {
+	testCases := []struct {
+		name           string
+		opts           []Opt
+		password       string
+		expectedLength int
+	}{
+		{"ShouldHashStandard", nil, "password", 13},
+		{"ShouldHashExtended", []Opt{WithVariant(VariantExtended), WithIterations(1000)}, "a long password value", 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.Hash(tc.password)
+			require.NoError(t, err)
+
+			encoded := digest.Encode()
+			assert.Len(t, encoded, tc.expectedLength)
+
+			decoded, err := Decode(encoded)
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match(tc.password))
+			assert.False(t, decoded.Match("wrong"))
+		})
+	}
+}
+
+func TestHashWithSalt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Opt
+		password string
+		salt     []byte
+		expected string
+		err      string
+	}{
+		{"ShouldHashStandard", nil, "password", []byte("ab"), "abJnggxhB/yWI", ""},
+		{"ShouldHashExtended", []Opt{WithVariant(VariantExtended)}, "password", []byte("salt"), "_J9..saltJW8FtKdEkNM", ""},
+		{"ShouldErrSaltLength", nil, "password", []byte("abc"), "", "descrypt hashing error: salt is invalid: salt bytes must have a length of 2 but has a length of 3"},
+		{"ShouldErrSaltCharSet", nil, "password", []byte("a$"), "", "descrypt hashing error: salt is invalid: salt bytes must only contain the characters './0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz'"},
+		{"ShouldErrPasswordTooLong", nil, "password1", []byte("ab"), "", "descrypt hashing error: password is invalid: password must be 8 bytes or less but it's 9 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt(tc.password, tc.salt)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, digest.Encode())
+		})
+	}
+}
+
+func TestNewExtended(t *testing.T) {
+	hasher, err := NewExtended()
+	require.NoError(t, err)
+
+	assert.Equal(t, VariantExtended, hasher.variant)
+}
+
+func TestMustHash(t *testing.T) {
+	hasher, err := New()
+	require.NoError(t, err)
+
+	assert.NotPanics(t, func() {
+		digest := hasher.MustHash("password")
+		assert.NotNil(t, digest)
+	})
+
+	assert.Panics(t, func() {
+		hasher.MustHash("password1")
+	})
+}
+
+func TestDigestKeySalt(t *testing.T) {
+	digest, err := Decode("abJnggxhB/yWI")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("ab"), digest.Salt())
+	assert.Equal(t, []byte("JnggxhB/yWI"), digest.Key())
+}
+
+func TestDigestMatchAdvancedEmptyKey(t *testing.T) {
+	d := &Digest{}
+
+	match, err := d.MatchAdvanced("password")
+
+	assert.False(t, match)
+	assert.EqualError(t, err, "descrypt match error: password is invalid: key has 0 bytes")
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("_J9..saltJW8FtKdEkNM")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantExtended, 725, decoded.Salt(), decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(VariantStandard, 725, []byte("sa"), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "descrypt decode error: parameter is invalid: parameter 'iterations' must be between 25 and 25 but is set to '725'")
+
+	digest, err = NewDigest(VariantExtended, 0, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "descrypt decode error: parameter is invalid: parameter 'iterations' must be between 1 and 16777215 but is set to '0'")
+
+	digest, err = NewDigest(VariantStandard, IterationsStandard, []byte("s!"), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "descrypt decode error: parameter is invalid: salt must be 2 characters from the crypt character set")
+
+	digest, err = NewDigest(VariantExtended, 725, decoded.Salt(), []byte("short"))
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "descrypt decode error: parameter is invalid: key must be 11 characters from the crypt character set")
+
+	digest, err = NewDigest(VariantNone, 725, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "descrypt decode error: parameter is invalid: variant must be either the 'standard' or 'extended' variant")
+}
@@ -0,0 +1,105 @@
+package descrypt
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a algorithm.Digest which handles traditional and BSDi extended DES crypt hashes.
+type Digest struct {
+	variant Variant
+
+	iterations int
+
+	salt, key []byte
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current descrypt.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current descrypt.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.variant.Key(passwordBytes, d.salt, d.iterations)) == 1, nil
+}
+
+// Encode returns the encoded form of this descrypt.Digest.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this descrypt.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	switch d.variant {
+	case VariantExtended:
+		return PrefixVariantExtended + string(encode64LittleEndian(uint32(d.iterations), 4)) + string(d.salt) + string(d.key)
+	default:
+		return string(d.salt) + string(d.key)
+	}
+}
+
+// String returns the storable format of the descrypt.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the key which is the final result of this digest.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns the salt used to generate this digest.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Variant returns the descrypt.Variant of this descrypt.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Canonical returns a copy of this descrypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+func (d *Digest) defaults() {
+	switch d.variant {
+	case VariantStandard, VariantExtended:
+		break
+	default:
+		d.variant = variantDefault
+	}
+
+	switch {
+	case d.variant == VariantStandard:
+		d.iterations = IterationsStandard
+	case d.iterations < IterationsMin:
+		d.iterations = IterationsDefault
+	}
+}
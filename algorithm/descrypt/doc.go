@@ -0,0 +1,8 @@
+// Package descrypt provides helpful abstractions for an implementation of the traditional DES based crypt(3) and the
+// BSDi extended DES based crypt(3) and implements github.com/go-crypt/crypt interfaces.
+//
+// Encoded digests of these algorithms are not delimited and can only be decoded by a crypt.Decoder which has
+// explicitly registered them. This implementation is not loaded by crypt.NewDefaultDecoder or crypt.NewDecoderAll.
+//
+// These algorithms are considered insecure and should only be used for verification during migrations.
+package descrypt
@@ -0,0 +1,67 @@
+package descrypt
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the descrypt.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithVariant is used to configure the descrypt.Variant of the resulting descrypt.Digest.
+// Default is descrypt.VariantStandard.
+func WithVariant(variant Variant) Opt {
+	return func(h *Hasher) (err error) {
+		switch variant {
+		case VariantNone:
+			return nil
+		case VariantStandard, VariantExtended:
+			h.variant = variant
+
+			return nil
+		default:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant))
+		}
+	}
+}
+
+// WithVariantName uses the variant name or identifier to configure the descrypt.Variant of the resulting
+// descrypt.Digest. Default is descrypt.VariantStandard.
+func WithVariantName(identifier string) Opt {
+	return func(h *Hasher) (err error) {
+		if identifier == "" {
+			return nil
+		}
+
+		variant := NewVariant(identifier)
+
+		if variant == VariantNone {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
+		}
+
+		h.variant = variant
+
+		return nil
+	}
+}
+
+// WithIterations sets the iterations parameter of the resulting descrypt.Digest. Only valid for the extended variant,
+// the standard variant always uses 25 iterations.
+// Minimum is 1, Maximum is 16777215. Default is 725.
+func WithIterations(iterations int) Opt {
+	return func(h *Hasher) (err error) {
+		if iterations < IterationsMin || iterations > IterationsMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations))
+		}
+
+		h.iterations = iterations
+
+		return nil
+	}
+}
+
+// WithRounds is an alias for descrypt.WithIterations.
+func WithRounds(rounds int) Opt {
+	return WithIterations(rounds)
+}
@@ -0,0 +1,177 @@
+package descrypt
+
+import (
+	"strings"
+)
+
+// NewVariant converts an identifier string to a descrypt.Variant.
+func NewVariant(identifier string) (variant Variant) {
+	switch identifier {
+	case AlgIdentifier, VariantNameStandard, "traditional":
+		return VariantStandard
+	case AlgIdentifierVariantExtended, VariantNameExtended, "bsdi", PrefixVariantExtended:
+		return VariantExtended
+	default:
+		return VariantNone
+	}
+}
+
+// Variant is a variant of the descrypt.Digest.
+type Variant int
+
+const (
+	// VariantNone is a variant of the descrypt.Digest which is unknown.
+	VariantNone Variant = iota
+
+	// VariantStandard is a variant of the descrypt.Digest which uses the traditional 13 character format.
+	VariantStandard
+
+	// VariantExtended is a variant of the descrypt.Digest which uses the BSDi extended 20 character format.
+	VariantExtended
+)
+
+// String implements the fmt.Stringer returning a string representation of the descrypt.Variant.
+func (v Variant) String() (name string) {
+	switch v {
+	case VariantStandard:
+		return VariantNameStandard
+	case VariantExtended:
+		return VariantNameExtended
+	default:
+		return
+	}
+}
+
+// Prefix returns the descrypt.Variant identifier this variant is registered with.
+func (v Variant) Prefix() (prefix string) {
+	switch v {
+	case VariantStandard:
+		return AlgIdentifier
+	case VariantExtended:
+		return AlgIdentifierVariantExtended
+	default:
+		return
+	}
+}
+
+// SaltLength returns the encoded salt length for this descrypt.Variant.
+func (v Variant) SaltLength() int {
+	switch v {
+	case VariantExtended:
+		return SaltLengthExtended
+	default:
+		return SaltLengthStandard
+	}
+}
+
+// EncodedLength returns the length of an encoded digest for this descrypt.Variant.
+func (v Variant) EncodedLength() int {
+	switch v {
+	case VariantExtended:
+		return len(PrefixVariantExtended) + 4 + SaltLengthExtended + KeyLength
+	default:
+		return SaltLengthStandard + KeyLength
+	}
+}
+
+// MatchEncoded returns true if the encoded digest has the structure of this descrypt.Variant. It's used as the
+// algorithm.DecodeMatchFunc when registering the decoder.
+func (v Variant) MatchEncoded(encodedDigest string) (match bool) {
+	if len(encodedDigest) != v.EncodedLength() {
+		return false
+	}
+
+	switch v {
+	case VariantStandard:
+		return validCharSet(encodedDigest)
+	case VariantExtended:
+		return strings.HasPrefix(encodedDigest, PrefixVariantExtended) && validCharSet(encodedDigest[1:])
+	default:
+		return false
+	}
+}
+
+// Key derives the encoded key for this descrypt.Variant.
+func (v Variant) Key(password, salt []byte, iterations int) (key []byte) {
+	switch v {
+	case VariantExtended:
+		return keyExtended(password, salt, iterations)
+	default:
+		return keyStandard(password, salt)
+	}
+}
+
+func keyStandard(password, salt []byte) (key []byte) {
+	schedule := newDESSchedule(desKeyBlock(password))
+
+	return encode64BigEndian(schedule.encrypt(0, desSaltBits(decode64LittleEndian(salt)), IterationsStandard))
+}
+
+func keyExtended(password, salt []byte, iterations int) (key []byte) {
+	block := desKeyBlock(password)
+	schedule := newDESSchedule(block)
+
+	for i := 8; i < len(password); i += 8 {
+		block = schedule.encrypt(block, 0, 1) ^ desKeyBlock(password[i:])
+		schedule = newDESSchedule(block)
+	}
+
+	return encode64BigEndian(schedule.encrypt(0, desSaltBits(decode64LittleEndian(salt)), iterations))
+}
+
+// desKeyBlock converts up to the first 8 bytes of the password into a DES key ignoring the most significant bit of
+// each byte.
+func desKeyBlock(password []byte) (block uint64) {
+	for i := 0; i < 8; i++ {
+		block <<= 8
+
+		if i < len(password) {
+			block |= uint64(password[i]<<1) & 0xff
+		}
+	}
+
+	return block
+}
+
+// encode64BigEndian encodes the 64-bit block as 11 characters with the most significant bits first.
+func encode64BigEndian(block uint64) (dst []byte) {
+	dst = make([]byte, KeyLength)
+
+	for i := 0; i < KeyLength-1; i++ {
+		dst[i] = itoa64[(block>>(58-6*i))&0x3f]
+	}
+
+	dst[KeyLength-1] = itoa64[(block<<2)&0x3f]
+
+	return dst
+}
+
+// encode64LittleEndian encodes the value as n characters with the least significant bits first.
+func encode64LittleEndian(value uint32, n int) (dst []byte) {
+	dst = make([]byte, n)
+
+	for i := 0; i < n; i++ {
+		dst[i] = itoa64[(value>>(6*i))&0x3f]
+	}
+
+	return dst
+}
+
+// decode64LittleEndian decodes the characters with the least significant bits first.
+func decode64LittleEndian(src []byte) (value uint32) {
+	for i, c := range src {
+		value |= uint32(strings.IndexByte(itoa64, c)&0x3f) << (6 * i)
+	}
+
+	return value
+}
+
+func validCharSet(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(itoa64, value[i]) == -1 {
+			return false
+		}
+	}
+
+	return true
+}
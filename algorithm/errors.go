@@ -49,6 +49,13 @@ var (
 	// ErrSaltInvalid is an error returned when a salt has an invalid or unsupported properties.
 	ErrSaltInvalid = errors.New("salt is invalid")
 
+	// ErrSecretLookup is an error returned when the secret required to match a digest could not be resolved.
+	ErrSecretLookup = errors.New("could not resolve the secret")
+
+	// ErrMatchContextMissing is an error returned when a value required to match a digest is missing from both the
+	// MatchContext and the digest.
+	ErrMatchContextMissing = errors.New("match context is missing a required value")
+
 	// ErrSaltReadRandomBytes is an error returned when generating the random bytes for salt resulted in an error.
 	ErrSaltReadRandomBytes = errors.New("could not read random bytes for salt")
 
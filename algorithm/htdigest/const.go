@@ -0,0 +1,15 @@
+package htdigest
+
+const (
+	// EncodingFmt is the encoding format for this algorithm.
+	EncodingFmt = "%s:%s:%x"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "htdigest"
+)
+
+const (
+	separator = ":"
+
+	keyLength = 16
+)
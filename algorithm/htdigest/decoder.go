@@ -0,0 +1,79 @@
+package htdigest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which consist of the username, realm, and 32 hex characters separated by colons.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgName, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgName, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest consists of the username, realm, and 32 hex characters separated by
+// colons.
+func MatchEncoded(encodedDigest string) (match bool) {
+	_, err := decode(encodedDigest)
+
+	return err == nil
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	if digest, err = decode(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+// NewDigest returns a htdigest.Digest from the username, realm, and raw key which is useful when the digest is not
+// stored in the encoded format.
+func NewDigest(username, realm string, key []byte) (digest *Digest, err error) {
+	switch {
+	case username == "" || realm == "":
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: the username and realm must not be empty", algorithm.ErrParameterInvalid))
+	case strings.Contains(username, separator) || strings.Contains(realm, separator):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: the username and realm must not contain the '%s' separator", algorithm.ErrParameterInvalid, separator))
+	case len(key) != keyLength:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(key), keyLength))
+	}
+
+	return &Digest{username: username, realm: realm, key: key}, nil
+}
+
+func decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	parts := strings.Split(encodedDigest, separator)
+
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: the digest must have the username, realm, and key separated by colons", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	if parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%w: the username and realm must not be empty", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	var key []byte
+
+	if key, err = hex.DecodeString(parts[2]); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(key) != keyLength {
+		return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(key), keyLength)
+	}
+
+	return &Digest{username: parts[0], realm: parts[1], key: key}, nil
+}
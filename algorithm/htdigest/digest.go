@@ -0,0 +1,133 @@
+package htdigest
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a htdigest.Digest which handles the Apache htdigest HA1 format.
+type Digest struct {
+	username, realm string
+
+	key []byte
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current htdigest.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current htdigest.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well. The username and
+// realm the htdigest.Digest was decoded or hashed with are used.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	return d.matchContext(passwordBytes, algorithm.MatchContext{})
+}
+
+// MatchWithContext returns true if the string password matches the current htdigest.Digest using the username and
+// realm from the algorithm.MatchContext.
+func (d *Digest) MatchWithContext(password string, ctx algorithm.MatchContext) (match bool) {
+	match, _ = d.MatchAdvancedWithContext(password, ctx)
+
+	return match
+}
+
+// MatchAdvancedWithContext is the same as MatchWithContext except if there is an error it returns that as well.
+func (d *Digest) MatchAdvancedWithContext(password string, ctx algorithm.MatchContext) (match bool, err error) {
+	return d.matchContext([]byte(password), ctx)
+}
+
+func (d *Digest) matchContext(passwordBytes []byte, ctx algorithm.MatchContext) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	username, realm := d.username, d.realm
+
+	if ctx.Username != "" {
+		username = ctx.Username
+	}
+
+	if ctx.Realm != "" {
+		realm = ctx.Realm
+	}
+
+	switch {
+	case username == "":
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: username", algorithm.ErrMatchContextMissing))
+	case realm == "":
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: realm", algorithm.ErrMatchContextMissing))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, derive(passwordBytes, username, realm)) == 1, nil
+}
+
+// Encode returns the encoded form of this htdigest.Digest which is the htdigest file format.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this htdigest.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	return fmt.Sprintf(EncodingFmt, d.username, d.realm, d.key)
+}
+
+// String returns the storable format of the htdigest.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw htdigest.Digest key which has been derived.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns nil as the htdigest format uses the username and realm instead of a salt.
+func (d *Digest) Salt() (salt []byte) {
+	return nil
+}
+
+// Username returns the username this htdigest.Digest was decoded or hashed with.
+func (d *Digest) Username() (username string) {
+	return d.username
+}
+
+// Realm returns the realm this htdigest.Digest was decoded or hashed with.
+func (d *Digest) Realm() (realm string) {
+	return d.realm
+}
+
+// Canonical returns a copy of this htdigest.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+// derive the HA1 key which is the MD5 digest of the username, realm, and password separated by colons.
+func derive(passwordBytes []byte, username, realm string) (key []byte) {
+	h := md5.New() //nolint:gosec
+
+	h.Write([]byte(username + separator + realm + separator))
+	h.Write(passwordBytes)
+
+	return h.Sum(nil)
+}
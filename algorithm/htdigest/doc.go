@@ -0,0 +1,11 @@
+// Package htdigest provides helpful abstractions for an implementation of the Apache htdigest HA1 format and
+// implements github.com/go-crypt/crypt interfaces.
+//
+// The key is the MD5 digest of the username, realm, and password separated by colons which is the HA1 value described
+// in RFC2617. Digests are encoded in the htdigest file format which includes the username and realm so they can be
+// matched without any additional context, however the username and realm can also be provided via the
+// algorithm.ContextualMatcher implementation.
+//
+// This implementation is cryptographically broken, is not loaded by any of the crypt decoders, and must be registered
+// explicitly.
+package htdigest
@@ -0,0 +1,74 @@
+package htdigest
+
+import (
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// New returns a new htdigest.Hasher with the provided functional options applied. The htdigest.WithUsername and
+// htdigest.WithRealm options are required.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for the Apache htdigest HA1 format which can be initialized via New using a functional
+// options pattern.
+type Hasher struct {
+	username, realm string
+}
+
+// WithOptions defines the options for this htdigest.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	if err = h.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Digest{username: h.username, realm: h.realm, key: derive([]byte(password), h.username, h.realm)}, nil
+}
+
+// HashWithSalt is an overload of htdigest.Digest that also accepts a salt. The salt is ignored as the username and
+// realm are used instead of a salt.
+func (h *Hasher) HashWithSalt(password string, _ []byte) (digest algorithm.Digest, err error) {
+	return h.Hash(password)
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	if err = validate("username", h.username); err != nil {
+		return err
+	}
+
+	return validate("realm", h.realm)
+}
@@ -0,0 +1,167 @@
+package htdigest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+const encodedRFC2617 = "Mufasa:testrealm@host.com:939e7578ed9e3c518a452acee763bce9"
+
+func TestDecode(t *testing.T) {
+	digest, err := Decode(encodedRFC2617)
+	require.NoError(t, err)
+
+	d, ok := digest.(*Digest)
+	require.True(t, ok)
+
+	assert.Equal(t, encodedRFC2617, d.Encode())
+	assert.Equal(t, encodedRFC2617, d.String())
+	assert.Equal(t, "Mufasa", d.Username())
+	assert.Equal(t, "testrealm@host.com", d.Realm())
+	assert.Nil(t, d.Salt())
+	assert.Len(t, d.Key(), 16)
+	assert.Equal(t, d.Encode(), d.Canonical().Encode())
+
+	assert.True(t, d.Match("Circle Of Life"))
+	assert.False(t, d.Match("wrong"))
+
+	match, err := d.MatchAdvanced("Circle Of Life")
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	assert.True(t, d.MatchWithContext("Circle Of Life", algorithm.MatchContext{Username: "Mufasa", Realm: "testrealm@host.com"}))
+	assert.False(t, d.MatchWithContext("Circle Of Life", algorithm.MatchContext{Username: "Simba"}))
+	assert.False(t, d.MatchWithContext("Circle Of Life", algorithm.MatchContext{Realm: "example.com"}))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrFormat", "Mufasa:939e7578ed9e3c518a452acee763bce9", "htdigest decode error: provided encoded hash has an invalid format: the digest must have the username, realm, and key separated by colons"},
+		{"ShouldErrUsername", ":testrealm@host.com:939e7578ed9e3c518a452acee763bce9", "htdigest decode error: provided encoded hash has an invalid format: the username and realm must not be empty"},
+		{"ShouldErrRealm", "Mufasa::939e7578ed9e3c518a452acee763bce9", "htdigest decode error: provided encoded hash has an invalid format: the username and realm must not be empty"},
+		{"ShouldErrKeyEncoding", "Mufasa:testrealm@host.com:939e7578ed9e3c518a452acee763bczz", "htdigest decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: invalid byte: U+007A 'z'"},
+		{"ShouldErrKeyLength", "Mufasa:testrealm@host.com:939e7578", "htdigest decode error: provided encoded hash has a key value that can't be decoded: key has 4 bytes but must have 16 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestMatchContextErrors(t *testing.T) {
+	d := &Digest{key: []byte{0x01}}
+
+	match, err := d.MatchAdvancedWithContext("password", algorithm.MatchContext{})
+	assert.False(t, match)
+	assert.EqualError(t, err, "htdigest match error: match context is missing a required value: username")
+
+	match, err = d.MatchAdvancedWithContext("password", algorithm.MatchContext{Username: "Mufasa"})
+	assert.False(t, match)
+	assert.EqualError(t, err, "htdigest match error: match context is missing a required value: realm")
+
+	match, err = (&Digest{}).MatchAdvanced("password")
+	assert.False(t, match)
+	assert.EqualError(t, err, "htdigest match error: password is invalid: key has 0 bytes")
+}
+
+func TestHasher(t *testing.T) {
+	hasher, err := New(WithUsername("Mufasa"), WithRealm("testrealm@host.com"))
+	require.NoError(t, err)
+
+	digest, err := hasher.Hash("Circle Of Life")
+	require.NoError(t, err)
+
+	assert.Equal(t, encodedRFC2617, digest.Encode())
+	assert.True(t, digest.Match("Circle Of Life"))
+	assert.False(t, digest.Match("wrong"))
+
+	digest, err = hasher.HashWithSalt("Circle Of Life", []byte("ignored"))
+	require.NoError(t, err)
+
+	assert.Equal(t, encodedRFC2617, digest.Encode())
+	assert.True(t, hasher.MustHash("password").Match("password"))
+}
+
+func TestHasherErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Opt
+		err  string
+	}{
+		{"ShouldErrUsernameMissing", []Opt{WithRealm("example.com")}, "htdigest validation error: parameter is invalid: username must not be empty"},
+		{"ShouldErrRealmMissing", []Opt{WithUsername("john")}, "htdigest validation error: parameter is invalid: realm must not be empty"},
+		{"ShouldErrUsernameEmpty", []Opt{WithUsername("")}, "htdigest validation error: parameter is invalid: username must not be empty"},
+		{"ShouldErrUsernameColon", []Opt{WithUsername("jo:hn")}, "htdigest validation error: parameter is invalid: username must not contain a colon"},
+		{"ShouldErrRealmColon", []Opt{WithRealm("example.com:443")}, "htdigest validation error: parameter is invalid: realm must not contain a colon"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode(encodedRFC2617)
+	require.NoError(t, err)
+
+	assert.IsType(t, &Digest{}, digest)
+	assert.True(t, digest.Match("Circle Of Life"))
+
+	match, err := crypt.MatchWithContext(digest, "Circle Of Life", algorithm.MatchContext{Username: "Mufasa", Realm: "testrealm@host.com"})
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	assert.False(t, MatchEncoded("Mufasa:939e7578ed9e3c518a452acee763bce9"))
+	assert.False(t, MatchEncoded("$1$salt$key"))
+
+	var (
+		_ algorithm.CanonicalDigest   = &Digest{}
+		_ algorithm.ContextualMatcher = &Digest{}
+	)
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("Mufasa:testrealm@host.com:939e7578ed9e3c518a452acee763bce9")
+	require.NoError(t, err)
+
+	digest, err := NewDigest("Mufasa", "testrealm@host.com", decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+	assert.True(t, digest.Match("Circle Of Life"))
+
+	digest, err = NewDigest("", "testrealm@host.com", decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "htdigest decode error: parameter is invalid: the username and realm must not be empty")
+
+	digest, err = NewDigest("Mufasa", "test:realm", decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "htdigest decode error: parameter is invalid: the username and realm must not contain the ':' separator")
+
+	digest, err = NewDigest("Mufasa", "testrealm@host.com", decoded.Key()[:8])
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "htdigest decode error: parameter is invalid: key has 8 bytes but must have 16 bytes")
+}
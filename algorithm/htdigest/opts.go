@@ -0,0 +1,48 @@
+package htdigest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the htdigest.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithUsername sets the username of the resulting htdigest.Digest which is mixed into the key. This option is required.
+func WithUsername(username string) Opt {
+	return func(h *Hasher) (err error) {
+		if err = validate("username", username); err != nil {
+			return err
+		}
+
+		h.username = username
+
+		return nil
+	}
+}
+
+// WithRealm sets the realm of the resulting htdigest.Digest which is mixed into the key. This option is required.
+func WithRealm(realm string) Opt {
+	return func(h *Hasher) (err error) {
+		if err = validate("realm", realm); err != nil {
+			return err
+		}
+
+		h.realm = realm
+
+		return nil
+	}
+}
+
+func validate(name, value string) (err error) {
+	switch {
+	case value == "":
+		return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: %s must not be empty", algorithm.ErrParameterInvalid, name))
+	case strings.Contains(value, separator):
+		return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: %s must not contain a colon", algorithm.ErrParameterInvalid, name))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package legacy
+
+const (
+	// EncodingFmt is the encoding format for this algorithm.
+	EncodingFmt = "$%s$%s=%s$%s$%s"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "legacy"
+
+	// AlgIdentifier is the identifier used in this algorithm.
+	AlgIdentifier = AlgName
+
+	// RecipeLengthMax is the maximum length of a recipe.
+	RecipeLengthMax = 1024
+
+	// RecipeDepthMax is the maximum function nesting depth of a recipe.
+	RecipeDepthMax = 16
+
+	// RecipeAmplificationMax is the maximum number of times the password and salt may be included in the output of
+	// each function of a recipe which bounds the memory used to evaluate a recipe.
+	RecipeAmplificationMax = 64
+
+	// RecipeOutputLengthMax is the maximum length of the output of each function of a recipe excluding the password
+	// and salt.
+	RecipeOutputLengthMax = 65536
+
+	// RecipeWorkMax is the maximum number of 64 byte blocks a recipe may hash excluding the password and salt including
+	// every count of the iterate function. Each hash function call hashes at least one block. This bounds the time taken
+	// to evaluate a recipe.
+	RecipeWorkMax = 4000000
+
+	// IterationsMin is the minimum count accepted by the iterate function.
+	IterationsMin = 1
+
+	// IterationsMax is the maximum count accepted by the iterate function.
+	IterationsMax = 1000000
+
+	// SaltLengthMin is the minimum salt length.
+	SaltLengthMin = 1
+
+	// SaltLengthMax is the maximum salt length.
+	SaltLengthMax = 1024
+
+	// SaltLengthDefault is the default salt length.
+	SaltLengthDefault = 16
+
+	// SaltCharSet are the characters used when generating a salt.
+	SaltCharSet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+const (
+	oRecipe = "r"
+
+	recipeWorkBlockSize = 64
+
+	varPass = "pass"
+	varSalt = "salt"
+	varPrev = "prev"
+
+	funcRaw     = "raw"
+	funcHex     = "hex"
+	funcUpper   = "upper"
+	funcIterate = "iterate"
+)
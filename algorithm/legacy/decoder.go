@@ -0,0 +1,106 @@
+package legacy
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderRegister.
+func RegisterDecoder(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, Decode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	var (
+		encoded *phc.String
+	)
+
+	if encoded, err = decoderParts(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	if digest, err = decode(encoded); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+// NewDigest returns a legacy.Digest from the recipe expression and the salt and key values stored by the legacy
+// application. The key is the output of the recipe, for example the hex encoded value for recipes which end with one of
+// the hash functions.
+func NewDigest(expression string, salt, key []byte) (digest *Digest, err error) {
+	var recipe *Recipe
+
+	if recipe, err = ParseRecipe(expression); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: recipe is invalid: %v", algorithm.ErrParameterInvalid, err))
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	}
+
+	return &Digest{recipe: recipe, salt: salt, key: key}, nil
+}
+
+func decoderParts(encodedDigest string) (encoded *phc.String, err error) {
+	if encoded, err = phc.Decode(encodedDigest, phc.WithHashRequired(), phc.WithParameterEncoding(phc.B64, oRecipe)); err != nil {
+		return nil, err
+	}
+
+	if encoded.Identifier != AlgIdentifier {
+		return nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, encoded.Identifier, AlgName)
+	}
+
+	return encoded, nil
+}
+
+func decode(encoded *phc.String) (digest algorithm.Digest, err error) {
+	decoded := &Digest{}
+
+	if encoded.Version != "" {
+		return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, phc.VersionKey, encoded.Version)
+	}
+
+	for _, param := range encoded.Parameters {
+		switch param.Key {
+		case oRecipe:
+			var expression []byte
+
+			if expression, err = phc.B64.DecodeString(param.Value); err != nil {
+				return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, param.Value, err)
+			}
+
+			if decoded.recipe, err = ParseRecipe(string(expression)); err != nil {
+				return nil, fmt.Errorf("%w: option '%s' has an invalid recipe: %v", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, err)
+			}
+		default:
+			return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
+		}
+	}
+
+	if decoded.recipe == nil {
+		return nil, fmt.Errorf("%w: option '%s' is required", algorithm.ErrEncodedHashInvalidOption, oRecipe)
+	}
+
+	if decoded.salt, err = phc.B64.DecodeString(encoded.Salt); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashSaltEncoding, err)
+	}
+
+	if decoded.key, err = phc.B64.DecodeString(encoded.Hash); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(decoded.key) == 0 {
+		return nil, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrEncodedHashKeyEncoding)
+	}
+
+	return decoded, nil
+}
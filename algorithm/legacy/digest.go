@@ -0,0 +1,90 @@
+package legacy
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a legacy.Digest which handles the ad-hoc digest constructions described by a legacy.Recipe.
+type Digest struct {
+	recipe *Recipe
+
+	salt, key []byte
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current legacy.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current legacy.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if d.recipe == nil {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: recipe is not set", algorithm.ErrPasswordInvalid))
+	}
+
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.recipe.Evaluate(passwordBytes, d.salt)) == 1, nil
+}
+
+// Encode returns the encoded form of this legacy.Digest.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this legacy.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	return fmt.Sprintf(EncodingFmt, AlgIdentifier, oRecipe,
+		base64.RawStdEncoding.EncodeToString([]byte(d.recipe.String())),
+		base64.RawStdEncoding.EncodeToString(d.salt), base64.RawStdEncoding.EncodeToString(d.key),
+	)
+}
+
+// String returns the storable format of the legacy.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw legacy.Digest key which is the output of the legacy.Recipe.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns the salt used to generate this digest.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Recipe returns the legacy.Recipe which describes the construction of this legacy.Digest.
+func (d *Digest) Recipe() (recipe *Recipe) {
+	return d.recipe
+}
+
+// Canonical returns a copy of this legacy.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
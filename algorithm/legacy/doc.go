@@ -0,0 +1,33 @@
+// Package legacy provides helpful abstractions for ad-hoc digest constructions used by legacy web applications such as
+// md5(md5($pass).$salt) and implements github.com/go-crypt/crypt interfaces.
+//
+// The construction of each digest is described by a recipe written in a small composition language which is recorded
+// in the encoded digest. The recipe grammar is as follows:
+//
+//	expression = term { "." term }
+//	term       = "$pass" | "$salt" | "$prev" | literal | function
+//	literal    = "'" { any character except "'" } "'"
+//	function   = hash "(" expression ")"
+//	           | "raw" "(" hash "(" expression ")" ")"
+//	           | "hex" "(" expression ")"
+//	           | "upper" "(" expression ")"
+//	           | "iterate" "(" count "," expression "," expression ")"
+//	hash       = "md5" | "sha1" | "sha224" | "sha256" | "sha384" | "sha512"
+//
+// The "." operator concatenates the terms. The hash functions return the lowercase hex encoded digest in the same
+// manner as the PHP functions of the same name, and the raw function returns the raw digest instead. The hex function
+// returns the lowercase hex encoding of the expression and the upper function returns the expression in uppercase.
+// The iterate function evaluates the second expression and then evaluates the third expression count times, where
+// $prev is the result of the previous evaluation. The iterate function can't be nested, the third expression must be
+// a hash function, and $prev is only valid in the third expression of the iterate function. Recipes which could
+// include the password and salt more than RecipeAmplificationMax times in the output of any function, or which produce
+// more than RecipeOutputLengthMax other bytes, are rejected so the memory used to evaluate a recipe is bounded. Recipes
+// which could hash more than RecipeWorkMax blocks including every iteration are also rejected so the time taken to
+// evaluate a recipe is bounded.
+//
+// For example sha256($pass.$salt) repeated 1000 times can be described as
+// iterate(999,sha256($pass.$salt),sha256($prev)).
+//
+// This implementation is cryptographically weak, is not loaded by any of the crypt decoders, and must be registered
+// explicitly. It's only intended to allow verifying legacy digests so they can be upgraded to a modern algorithm.
+package legacy
@@ -0,0 +1,130 @@
+package legacy
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/random"
+)
+
+// New returns a new legacy.Hasher with the provided functional options applied. The legacy.WithRecipe option is
+// required.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for the legacy digests which can be initialized via New using a functional options pattern.
+// It's only intended for testing and for applications which must continue to produce the legacy digests.
+type Hasher struct {
+	recipe *Recipe
+
+	bytesSalt int
+
+	d bool
+}
+
+// WithOptions defines the options for this legacy.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	if err = h.Validate(); err != nil {
+		return nil, err
+	}
+
+	if digest, err = h.hash(password); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hash(password string) (digest algorithm.Digest, err error) {
+	var salt []byte
+
+	if h.recipe.Salted() {
+		if salt, err = random.CharSetBytes(h.bytesSalt, SaltCharSet); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrSaltReadRandomBytes, err)
+		}
+	}
+
+	return h.hashWithSalt(password, salt)
+}
+
+// HashWithSalt overloads the Hash method allowing the user to provide a salt. It's recommended instead to configure the
+// salt size and let this be a random value generated using crypto/rand. The salt is ignored when the recipe doesn't
+// use the salt.
+func (h *Hasher) HashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	if err = h.Validate(); err != nil {
+		return nil, err
+	}
+
+	if digest, err = h.hashWithSalt(password, salt); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	if !h.recipe.Salted() {
+		salt = nil
+	} else if s := len(salt); s > SaltLengthMax || s < SaltLengthMin {
+		return nil, fmt.Errorf("%w: salt bytes must have a length of between %d and %d but has a length of %d", algorithm.ErrSaltInvalid, SaltLengthMin, SaltLengthMax, len(salt))
+	}
+
+	return &Digest{recipe: h.recipe, salt: salt, key: h.recipe.Evaluate([]byte(password), salt)}, nil
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	h.defaults()
+
+	if h.recipe == nil {
+		return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: recipe must be set", algorithm.ErrParameterInvalid))
+	}
+
+	return nil
+}
+
+func (h *Hasher) defaults() {
+	if h.d {
+		return
+	}
+
+	h.d = true
+
+	if h.bytesSalt == 0 {
+		h.bytesSalt = SaltLengthDefault
+	}
+}
@@ -0,0 +1,256 @@
+package legacy
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+func TestRecipe(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		salted   bool
+		expected string
+	}{
+		{"ShouldEvaluateMD5", "md5($pass)", false, "5f4dcc3b5aa765d61d8327deb882cf99"},
+		{"ShouldEvaluateNestedMD5", "md5(md5($pass).$salt)", true, "d514dee5e76bbb718084294c835f312c"},
+		{"ShouldEvaluateSHA1SaltPrefix", "sha1($salt.$pass)", true, "59b3e8d637cf97edbe2384cf59cb7453dfe30789"},
+		{"ShouldEvaluateIterate", "iterate(999,sha256($pass.$salt),sha256($prev))", true, "fb17f1dce5a1e855784c1c59e08c68299cddf36c5e8d83ba7cfac5244f9923fc"},
+		{"ShouldEvaluateUpper", "upper(sha1($pass))", false, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"},
+		{"ShouldEvaluateLiteral", "md5($salt.':'.$pass)", true, "7979d4b6b5604c0f10a2d829963bb36b"},
+		{"ShouldEvaluateRawHex", "hex(raw(md5($pass)))", false, "5f4dcc3b5aa765d61d8327deb882cf99"},
+		{"ShouldEvaluateWhitespace", "md5( md5( $pass ) . $salt )", true, "d514dee5e76bbb718084294c835f312c"},
+		{"ShouldEvaluateIterateDoubling", "iterate(20,md5($pass),md5($prev.$prev))", false, "b84ca3bef9df8bdea47cffd2f9e0ae0a"},
+		{"ShouldEvaluateRawIterate", "hex(iterate(3, raw(sha512($pass.$salt)), raw(sha512($prev.$pass.$salt))))", true, "71985a96bb2add20821cd95b7ec77bca297b3ef641b64eb1762cf9ae89333689fd2f2d7342090f4bdf2cb4ea45171b528a10ff3a0fa436557b7b44eeb4102086"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipe, err := ParseRecipe(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.have, recipe.String())
+			assert.Equal(t, tc.salted, recipe.Salted())
+			assert.Equal(t, tc.expected, string(recipe.Evaluate([]byte("password"), []byte("salt"))))
+		})
+	}
+}
+
+func TestRecipeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrEmpty", "", "recipe must not be empty"},
+		{"ShouldErrUnknownFunction", "md4($pass)", "unknown function 'md4' at position 0"},
+		{"ShouldErrUnknownVariable", "md5($password)", "unknown variable '$password' at position 4"},
+		{"ShouldErrPrev", "md5($prev)", "variable '$prev' at position 4 is only valid in the last argument of the iterate function"},
+		{"ShouldErrPrevSeed", "iterate(2,md5($prev),md5($prev))", "variable '$prev' at position 14 is only valid in the last argument of the iterate function"},
+		{"ShouldErrNestedIterate", "iterate(2,md5($pass),iterate(2,md5($pass),md5($prev)))", "the iterate function at position 21 can't be nested"},
+		{"ShouldErrIterateCount", "iterate(x,md5($pass),md5($prev))", "the iterate function at position 0 must have a count as the first argument"},
+		{"ShouldErrIterateCountRange", "iterate(0,md5($pass),md5($prev))", "the iterate function at position 0 must have a count between 1 and 1000000 but has a count of 0"},
+		{"ShouldErrIterateArguments", "iterate(2,md5($pass))", "expected ',' at position 20 but found ')'"},
+		{"ShouldErrIterateDoubling", "iterate(1000000,$pass,$prev.$prev)", "the iterate function at position 0 must have a hash function as the last argument"},
+		{"ShouldErrIterateDoublingHex", "iterate(20,$pass,hex($prev))", "the iterate function at position 0 must have a hash function as the last argument"},
+		{"ShouldErrIterateUpper", "iterate(2,md5($pass),upper(md5($prev)))", "the iterate function at position 0 must have a hash function as the last argument"},
+		{"ShouldErrAmplificationHex", "hex(hex(hex(hex(hex(hex(hex($pass.$salt)))))))", "recipe must include the password and salt in the output of each function 64 times or less but includes them 128 times"},
+		{"ShouldErrAmplificationConcat", "md5(hex(hex(hex(hex(hex(hex($pass.$pass)))))))", "recipe must include the password and salt in the output of each function 64 times or less but includes them 128 times"},
+		{"ShouldErrOutputLength", "hex(hex(hex(hex(hex(hex(hex(hex(hex(hex(sha512($pass)))))))))))", "recipe must produce an output of each function with a length of 65536 or less excluding the password and salt but produces 131072"},
+		{"ShouldErrWorkIterate", "iterate(1000000,$pass,md5(" + strings.Repeat("md5($prev).", 89) + "md5($prev)))", "recipe must hash 4000000 blocks or less including each iteration but hashes 136000000 blocks"},
+		{"ShouldErrWorkIterateHex", "iterate(100000,raw(md5($pass)),md5(hex(hex(hex(hex(hex(hex(hex(hex(hex(hex(hex($prev)))))))))))))", "recipe must hash 4000000 blocks or less including each iteration but hashes 102500001 blocks"},
+		{"ShouldErrWorkIterateStepOutput", "iterate(300000,raw(md5($pass)),sha512(hex(hex(hex($prev)))))", "recipe must hash 4000000 blocks or less including each iteration but hashes 5100001 blocks"},
+		{"ShouldErrRaw", "raw($pass)", "the raw function at position 4 must have a hash function as the argument"},
+		{"ShouldErrUnterminatedLiteral", "md5($pass.':)", "unterminated literal at position 10"},
+		{"ShouldErrUnclosed", "md5($pass", "expected ')' but reached the end of the recipe"},
+		{"ShouldErrTrailing", "md5($pass))", "unexpected character ')' at position 10"},
+		{"ShouldErrUnexpectedCharacter", "md5(+)", "unexpected character '+' at position 4"},
+		{"ShouldErrUnexpectedEnd", "md5($pass).", "unexpected end of recipe at position 11"},
+		{"ShouldErrDepth", "md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5(md5($pass)))))))))))))))))", "function 'md5' at position 64 exceeds the maximum nesting depth of 16"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipe, err := ParseRecipe(tc.have)
+
+			assert.Nil(t, recipe)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	digest, err := NewDigest("md5(md5($pass).$salt)", []byte("salt"), []byte("d514dee5e76bbb718084294c835f312c"))
+	require.NoError(t, err)
+
+	encoded := digest.Encode()
+
+	assert.Equal(t, "$legacy$r=bWQ1KG1kNSgkcGFzcykuJHNhbHQp$c2FsdA$ZDUxNGRlZTVlNzZiYmI3MTgwODQyOTRjODM1ZjMxMmM", encoded)
+	assert.True(t, digest.Match("password"))
+	assert.False(t, digest.Match("wrong"))
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+
+	d, ok := decoded.(*Digest)
+	require.True(t, ok)
+
+	assert.Equal(t, encoded, d.Encode())
+	assert.Equal(t, encoded, d.String())
+	assert.Equal(t, "md5(md5($pass).$salt)", d.Recipe().String())
+	assert.Equal(t, []byte("salt"), d.Salt())
+	assert.Equal(t, []byte("d514dee5e76bbb718084294c835f312c"), d.Key())
+	assert.Equal(t, d.Encode(), d.Canonical().Encode())
+
+	assert.True(t, d.Match("password"))
+	assert.False(t, d.Match("wrong"))
+
+	match, err := d.MatchAdvanced("password")
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	raw, err := hex.DecodeString("5f4dcc3b5aa765d61d8327deb882cf99")
+	require.NoError(t, err)
+
+	digest, err = NewDigest("raw(md5($pass))", nil, raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, "$legacy$r=cmF3KG1kNSgkcGFzcykp$$X03MO1qnZdYdgyfeuILPmQ", digest.Encode())
+
+	decoded, err = Decode(digest.Encode())
+	require.NoError(t, err)
+
+	assert.True(t, decoded.Match("password"))
+	assert.Empty(t, decoded.Salt())
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrFormat", "$legacy$r=bWQ1KCRwYXNzKQ$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrIdentifier", "$other$r=bWQ1KCRwYXNzKQ$$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid identifier: identifier 'other' is not an encoded legacy digest"},
+		{"ShouldErrParameters", "$legacy$$$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid option: parameter pair '' is not properly encoded: does not contain kv separator '='"},
+		{"ShouldErrOptionKey", "$legacy$x=1$$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid option key: option 'x' with value '1' is unknown"},
+		{"ShouldErrRecipeEncoding", "$legacy$r=!!$$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid option value: option 'r' has invalid value '!!': illegal base64 data at input byte 0"},
+		{"ShouldErrRecipe", "$legacy$r=bWQ0KCRwYXNzKQ$$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has an invalid option value: option 'r' has an invalid recipe: unknown function 'md4' at position 0"},
+		{"ShouldErrSalt", "$legacy$r=bWQ1KCRwYXNzKQ$!!$X03MO1qnZdYdgyfeuILPmQ", "legacy decode error: provided encoded hash has a salt value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrKey", "$legacy$r=bWQ1KCRwYXNzKQ$$!!", "legacy decode error: provided encoded hash has a key value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrKeyEmpty", "$legacy$r=bWQ1KCRwYXNzKQ$$", "legacy decode error: provided encoded hash has a key value that can't be decoded: key has 0 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestNewDigestErrors(t *testing.T) {
+	digest, err := NewDigest("md4($pass)", nil, []byte("key"))
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "legacy decode error: parameter is invalid: recipe is invalid: unknown function 'md4' at position 0")
+
+	digest, err = NewDigest("md5($pass)", nil, nil)
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "legacy decode error: parameter is invalid: key has 0 bytes")
+}
+
+func TestHasher(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Opt
+		salt     int
+		expected string
+	}{
+		{"ShouldHashSalted", []Opt{WithRecipe("md5(md5($pass).$salt)")}, 16, "$legacy$r=bWQ1KG1kNSgkcGFzcykuJHNhbHQp$c2FsdA$ZDUxNGRlZTVlNzZiYmI3MTgwODQyOTRjODM1ZjMxMmM"},
+		{"ShouldHashSaltLength", []Opt{WithRecipe("sha1($salt.$pass)"), WithSaltLength(8)}, 8, ""},
+		{"ShouldHashUnsalted", []Opt{WithRecipe("md5($pass)")}, 0, "$legacy$r=bWQ1KCRwYXNzKQ$$NWY0ZGNjM2I1YWE3NjVkNjFkODMyN2RlYjg4MmNmOTk"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt("password", []byte("salt"))
+			require.NoError(t, err)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, digest.Encode())
+			}
+
+			assert.True(t, digest.Match("password"))
+			assert.False(t, digest.Match("wrong"))
+
+			random, err := hasher.Hash("password")
+			require.NoError(t, err)
+
+			assert.Len(t, random.Salt(), tc.salt)
+			assert.True(t, random.Match("password"))
+			assert.True(t, hasher.MustHash("password").Match("password"))
+
+			decoded, err := Decode(random.Encode())
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match("password"))
+		})
+	}
+}
+
+func TestHasherErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Opt
+		err  string
+	}{
+		{"ShouldErrRecipeMissing", nil, "legacy validation error: parameter is invalid: recipe must be set"},
+		{"ShouldErrRecipe", []Opt{WithRecipe("md4($pass)")}, "legacy validation error: parameter is invalid: recipe is invalid: unknown function 'md4' at position 0"},
+		{"ShouldErrSaltLength", []Opt{WithSaltLength(0)}, "legacy validation error: parameter is invalid: parameter 'salt length' must be between 1 and 1024 but is set to '0'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	hasher, err := New(WithRecipe("md5($salt.$pass)"))
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt("password", nil)
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "legacy hashing error: salt is invalid: salt bytes must have a length of between 1 and 1024 but has a length of 0")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode("$legacy$r=bWQ1KG1kNSgkcGFzcykuJHNhbHQp$c2FsdA$ZDUxNGRlZTVlNzZiYmI3MTgwODQyOTRjODM1ZjMxMmM")
+	require.NoError(t, err)
+
+	assert.IsType(t, &Digest{}, digest)
+	assert.True(t, digest.Match("password"))
+
+	var _ algorithm.CanonicalDigest = &Digest{}
+}
@@ -0,0 +1,37 @@
+package legacy
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the legacy.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithRecipe sets the recipe expression which describes the construction of the resulting legacy.Digest. This option
+// is required.
+func WithRecipe(expression string) Opt {
+	return func(h *Hasher) (err error) {
+		if h.recipe, err = ParseRecipe(expression); err != nil {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: recipe is invalid: %v", algorithm.ErrParameterInvalid, err))
+		}
+
+		return nil
+	}
+}
+
+// WithSaltLength adjusts the salt size (in bytes) of the resulting legacy.Digest. The salt is only used when the
+// recipe uses the salt.
+// Minimum is 1, Maximum is 1024. Default is 16.
+func WithSaltLength(bytes int) Opt {
+	return func(h *Hasher) (err error) {
+		if bytes < SaltLengthMin || bytes > SaltLengthMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "salt length", SaltLengthMin, "", SaltLengthMax, bytes))
+		}
+
+		h.bytesSalt = bytes
+
+		return nil
+	}
+}
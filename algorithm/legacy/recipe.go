@@ -0,0 +1,543 @@
+package legacy
+
+import (
+	"bytes"
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+var hashes = map[string]algorithm.HashFunc{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// ParseRecipe parses a recipe expression into a legacy.Recipe. See the package documentation for the grammar.
+func ParseRecipe(expression string) (recipe *Recipe, err error) {
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("recipe must not be empty")
+	}
+
+	if len(expression) > RecipeLengthMax {
+		return nil, fmt.Errorf("recipe must have a length of %d or less but has a length of %d", RecipeLengthMax, len(expression))
+	}
+
+	p := &parser{input: expression}
+
+	recipe = &Recipe{expression: expression}
+
+	if recipe.root, err = p.expression(); err != nil {
+		return nil, err
+	}
+
+	if p.skip(); p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", p.input[p.pos], p.pos)
+	}
+
+	if _, err = recipe.root.size(length{}); err != nil {
+		return nil, err
+	}
+
+	recipe.salted = p.salted
+
+	return recipe, nil
+}
+
+// Recipe is a parsed recipe expression which describes the construction of a legacy.Digest.
+type Recipe struct {
+	expression string
+	root       node
+	salted     bool
+}
+
+// String returns the recipe expression.
+func (r *Recipe) String() string {
+	return r.expression
+}
+
+// Salted returns true if the recipe expression uses the salt.
+func (r *Recipe) Salted() bool {
+	return r.salted
+}
+
+// Evaluate the recipe with the password and salt returning the output.
+func (r *Recipe) Evaluate(passwordBytes, salt []byte) (output []byte) {
+	return r.root.eval(&state{password: passwordBytes, salt: salt})
+}
+
+type state struct {
+	password, salt, prev []byte
+}
+
+// length is the maximum length of the output of a node where variable is the number of times the password and salt
+// are included in the output and fixed is the number of bytes which don't depend on the password or salt. The work is
+// the number of blocks hashed to produce the output where each hash function call hashes at least one block.
+type length struct {
+	variable, fixed, work int
+}
+
+func (l length) add(o length) length {
+	return length{variable: l.variable + o.variable, fixed: l.fixed + o.fixed, work: l.work + o.work}
+}
+
+func (l length) check() (length, error) {
+	switch {
+	case l.variable > RecipeAmplificationMax:
+		return l, fmt.Errorf("recipe must include the password and salt in the output of each function %d times or less but includes them %d times", RecipeAmplificationMax, l.variable)
+	case l.fixed > RecipeOutputLengthMax:
+		return l, fmt.Errorf("recipe must produce an output of each function with a length of %d or less excluding the password and salt but produces %d", RecipeOutputLengthMax, l.fixed)
+	case l.work > RecipeWorkMax:
+		return l, fmt.Errorf("recipe must hash %d blocks or less including each iteration but hashes %d blocks", RecipeWorkMax, l.work)
+	}
+
+	return l, nil
+}
+
+type node interface {
+	eval(s *state) []byte
+	size(prev length) (length, error)
+}
+
+type nodeConcat []node
+
+func (n nodeConcat) size(prev length) (l length, err error) {
+	var t length
+
+	for _, term := range n {
+		if t, err = term.size(prev); err != nil {
+			return l, err
+		}
+
+		l = l.add(t)
+	}
+
+	return l.check()
+}
+
+func (n nodeConcat) eval(s *state) (output []byte) {
+	for _, term := range n {
+		output = append(output, term.eval(s)...)
+	}
+
+	return output
+}
+
+type nodeVariable string
+
+func (n nodeVariable) eval(s *state) []byte {
+	switch n {
+	case varPass:
+		return s.password
+	case varSalt:
+		return s.salt
+	default:
+		return s.prev
+	}
+}
+
+func (n nodeVariable) size(prev length) (length, error) {
+	// The previous output has already been computed so the work which produced it isn't repeated.
+	if n == varPrev {
+		return length{variable: prev.variable, fixed: prev.fixed}, nil
+	}
+
+	return length{variable: 1}, nil
+}
+
+type nodeLiteral string
+
+func (n nodeLiteral) size(_ length) (length, error) {
+	return length{fixed: len(n)}, nil
+}
+
+func (n nodeLiteral) eval(_ *state) []byte {
+	return []byte(n)
+}
+
+type nodeHash struct {
+	hf  algorithm.HashFunc
+	raw bool
+	arg node
+}
+
+func (n *nodeHash) eval(s *state) []byte {
+	h := n.hf()
+
+	h.Write(n.arg.eval(s))
+
+	if n.raw {
+		return h.Sum(nil)
+	}
+
+	return []byte(hex.EncodeToString(h.Sum(nil)))
+}
+
+func (n *nodeHash) size(prev length) (l length, err error) {
+	if l, err = n.arg.size(prev); err != nil {
+		return l, err
+	}
+
+	work := l.work + 1 + l.fixed/recipeWorkBlockSize
+
+	if n.raw {
+		return length{fixed: n.hf().Size(), work: work}.check()
+	}
+
+	return length{fixed: n.hf().Size() * 2, work: work}.check()
+}
+
+type nodeHex struct {
+	arg node
+}
+
+func (n *nodeHex) eval(s *state) []byte {
+	return []byte(hex.EncodeToString(n.arg.eval(s)))
+}
+
+func (n *nodeHex) size(prev length) (l length, err error) {
+	if l, err = n.arg.size(prev); err != nil {
+		return l, err
+	}
+
+	return length{variable: l.variable * 2, fixed: l.fixed * 2, work: l.work}.check()
+}
+
+type nodeUpper struct {
+	arg node
+}
+
+func (n *nodeUpper) eval(s *state) []byte {
+	return bytes.ToUpper(n.arg.eval(s))
+}
+
+func (n *nodeUpper) size(prev length) (length, error) {
+	return n.arg.size(prev)
+}
+
+type nodeIterate struct {
+	count      int
+	seed, step node
+}
+
+func (n *nodeIterate) eval(s *state) (output []byte) {
+	output = n.seed.eval(s)
+
+	i := *s
+
+	for c := 0; c < n.count; c++ {
+		i.prev = output
+		output = n.step.eval(&i)
+	}
+
+	return output
+}
+
+func (n *nodeIterate) size(prev length) (l length, err error) {
+	var step length
+
+	if l, err = n.seed.size(prev); err != nil {
+		return l, err
+	}
+
+	if step, err = n.step.size(l); err != nil {
+		return step, err
+	}
+
+	// After the first iteration the previous output is the output of the step which may be longer than the seed.
+	if step.variable > l.variable || step.fixed > l.fixed {
+		if step, err = n.step.size(length{variable: max(l.variable, step.variable), fixed: max(l.fixed, step.fixed)}); err != nil {
+			return step, err
+		}
+	}
+
+	step.work = l.work + n.count*step.work
+
+	return step.check()
+}
+
+type parser struct {
+	input string
+	pos   int
+	depth int
+
+	iterate, step, salted bool
+}
+
+func (p *parser) expression() (n node, err error) {
+	var terms nodeConcat
+
+	for {
+		if n, err = p.term(); err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, n)
+
+		if p.skip(); !p.consume('.') {
+			break
+		}
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+
+	return terms, nil
+}
+
+func (p *parser) term() (n node, err error) {
+	if p.skip(); p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of recipe at position %d", p.pos)
+	}
+
+	switch c := p.input[p.pos]; {
+	case c == '$':
+		return p.variable()
+	case c == '\'':
+		return p.literal()
+	case isIdentifier(c):
+		return p.function()
+	default:
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
+	}
+}
+
+func (p *parser) variable() (n node, err error) {
+	pos := p.pos
+
+	p.pos++
+
+	switch name := p.identifier(); name {
+	case varPass:
+		return nodeVariable(name), nil
+	case varSalt:
+		p.salted = true
+
+		return nodeVariable(name), nil
+	case varPrev:
+		if !p.step {
+			return nil, fmt.Errorf("variable '$%s' at position %d is only valid in the last argument of the %s function", name, pos, funcIterate)
+		}
+
+		return nodeVariable(name), nil
+	default:
+		return nil, fmt.Errorf("unknown variable '$%s' at position %d", name, pos)
+	}
+}
+
+func (p *parser) literal() (n node, err error) {
+	pos := p.pos
+
+	p.pos++
+
+	for i := p.pos; i < len(p.input); i++ {
+		if p.input[i] == '\'' {
+			n, p.pos = nodeLiteral(p.input[p.pos:i]), i+1
+
+			return n, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unterminated literal at position %d", pos)
+}
+
+func (p *parser) function() (n node, err error) {
+	pos := p.pos
+	name := p.identifier()
+
+	if err = p.open(name, pos); err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case funcRaw:
+		if n, err = p.raw(); err != nil {
+			return nil, err
+		}
+	case funcHex:
+		var arg node
+
+		if arg, err = p.expression(); err != nil {
+			return nil, err
+		}
+
+		n = &nodeHex{arg: arg}
+	case funcUpper:
+		var arg node
+
+		if arg, err = p.expression(); err != nil {
+			return nil, err
+		}
+
+		n = &nodeUpper{arg: arg}
+	case funcIterate:
+		if n, err = p.iteration(pos); err != nil {
+			return nil, err
+		}
+	default:
+		hf, ok := hashes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function '%s' at position %d", name, pos)
+		}
+
+		var arg node
+
+		if arg, err = p.expression(); err != nil {
+			return nil, err
+		}
+
+		n = &nodeHash{hf: hf, arg: arg}
+	}
+
+	if err = p.close(); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (p *parser) raw() (n node, err error) {
+	p.skip()
+
+	pos := p.pos
+	name := p.identifier()
+
+	hf, ok := hashes[name]
+	if !ok {
+		return nil, fmt.Errorf("the %s function at position %d must have a hash function as the argument", funcRaw, pos)
+	}
+
+	if err = p.open(name, pos); err != nil {
+		return nil, err
+	}
+
+	var arg node
+
+	if arg, err = p.expression(); err != nil {
+		return nil, err
+	}
+
+	if err = p.close(); err != nil {
+		return nil, err
+	}
+
+	return &nodeHash{hf: hf, raw: true, arg: arg}, nil
+}
+
+func (p *parser) iteration(pos int) (n node, err error) {
+	if p.iterate {
+		return nil, fmt.Errorf("the %s function at position %d can't be nested", funcIterate, pos)
+	}
+
+	p.iterate = true
+
+	p.skip()
+
+	start := p.pos
+
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+
+	iteration := &nodeIterate{}
+
+	if iteration.count, err = strconv.Atoi(p.input[start:p.pos]); err != nil {
+		return nil, fmt.Errorf("the %s function at position %d must have a count as the first argument", funcIterate, pos)
+	}
+
+	if iteration.count < IterationsMin || iteration.count > IterationsMax {
+		return nil, fmt.Errorf("the %s function at position %d must have a count between %d and %d but has a count of %d", funcIterate, pos, IterationsMin, IterationsMax, iteration.count)
+	}
+
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+
+	if iteration.seed, err = p.expression(); err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+
+	p.step = true
+
+	if iteration.step, err = p.expression(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := iteration.step.(*nodeHash); !ok {
+		return nil, fmt.Errorf("the %s function at position %d must have a hash function as the last argument", funcIterate, pos)
+	}
+
+	p.iterate, p.step = false, false
+
+	return iteration, nil
+}
+
+func (p *parser) open(name string, pos int) (err error) {
+	if p.depth++; p.depth > RecipeDepthMax {
+		return fmt.Errorf("function '%s' at position %d exceeds the maximum nesting depth of %d", name, pos, RecipeDepthMax)
+	}
+
+	return p.expect('(')
+}
+
+func (p *parser) close() (err error) {
+	p.depth--
+
+	return p.expect(')')
+}
+
+func (p *parser) expect(c byte) (err error) {
+	if p.skip(); p.consume(c) {
+		return nil
+	}
+
+	if p.pos >= len(p.input) {
+		return fmt.Errorf("expected '%c' but reached the end of the recipe", c)
+	}
+
+	return fmt.Errorf("expected '%c' at position %d but found '%c'", c, p.pos, p.input[p.pos])
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) skip() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) identifier() (name string) {
+	start := p.pos
+
+	for p.pos < len(p.input) && isIdentifier(p.input[p.pos]) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+func isIdentifier(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -74,8 +75,28 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
+// NewDigest returns a md5crypt.Digest from the raw parameters, salt, and key which is useful when the values of a
+// digest are not stored in the encoded format. The iterations are only valid for the md5crypt.VariantSun variant. The
+// salt and key are in the same form as returned by the Salt and Key methods.
+func NewDigest(variant Variant, iterations uint32, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant != VariantStandard && variant != VariantSun:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantStandard, VariantSun))
+	case iterations != 0 && variant != VariantSun:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: parameters are only valid for the %s variant but the %s variant was provided", algorithm.ErrParameterInvalid, VariantSun.String(), variant.String()))
+	case strings.ContainsRune(string(salt), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	case strings.ContainsRune(string(key), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, salt: salt, key: key}, nil
+}
+
 func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	partsTemp := encoding.Split(encodedDigest, -1)
+	partsTemp := phc.Split(encodedDigest, -1)
 
 	p := len(partsTemp)
 
@@ -115,14 +136,14 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 		variant: variant,
 	}
 
-	var params []encoding.Parameter
+	var params []phc.Parameter
 
 	if parts[0] != "" {
 		if variant != VariantSun {
 			return nil, fmt.Errorf("%w: parameters are only valid for the %s variant but the %s variant was decoded", algorithm.ErrParameterInvalid, VariantSun.String(), variant.String())
 		}
 
-		if params, err = encoding.DecodeParameterStr(parts[0]); err != nil {
+		if params, err = phc.DecodeParameters(parts[0]); err != nil {
 			return nil, err
 		}
 
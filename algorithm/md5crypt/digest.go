@@ -16,6 +16,8 @@ type Digest struct {
 	iterations uint32
 
 	salt, key []byte
+
+	algorithm.Prefix
 }
 
 // Match returns true if the string password matches the current md5crypt.Digest.
@@ -51,6 +53,11 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode returns the encoded form of this md5crypt.Digest.
 func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this md5crypt.Digest without the storage prefix.
+func (d *Digest) encode() string {
 	switch {
 	case d.variant == VariantSun && d.iterations > 0:
 		return fmt.Sprintf(EncodingFmtSunIterations,
@@ -82,6 +89,20 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the md5crypt.Variant of this md5crypt.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Canonical returns a copy of this md5crypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
 func (d *Digest) defaults() {
 	switch d.variant {
 	case VariantStandard, VariantSun:
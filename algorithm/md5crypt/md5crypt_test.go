@@ -336,3 +336,31 @@ func TestDigestEncode(t *testing.T) {
 	assert.NotEmpty(t, encoded)
 	assert.Equal(t, encoded, digest.String())
 }
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantStandard, 0, decoded.Salt(), decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+	assert.True(t, digest.Match("password"))
+
+	digest, err = NewDigest(VariantSun, 5000, []byte("saltsalt"), []byte("key"))
+	require.NoError(t, err)
+
+	assert.Equal(t, VariantSun, digest.Variant())
+
+	digest, err = NewDigest(VariantNone, 0, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "md5crypt decode error: parameter is invalid: variant must be either the 'standard' or 'sun' variant")
+
+	digest, err = NewDigest(VariantStandard, 5000, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "md5crypt decode error: parameter is invalid: parameters are only valid for the sun variant but the standard variant was provided")
+
+	digest, err = NewDigest(VariantStandard, 0, decoded.Salt(), []byte("a$b"))
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "md5crypt decode error: parameter is invalid: key must not contain the '$' delimiter")
+}
@@ -0,0 +1,55 @@
+package mysql
+
+const (
+	// EncodingFmtNative is the encoding format for the mysql_native_password variant of this algorithm.
+	EncodingFmtNative = "*%s"
+
+	// EncodingFmtCachingSHA2 is the encoding format for the caching_sha2_password variant of this algorithm.
+	EncodingFmtCachingSHA2 = "$%s$%03X$%s%s"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "mysql"
+
+	// AlgIdentifierNative is the identifier used for the mysql_native_password variant of this algorithm.
+	AlgIdentifierNative = "mysql_native_password"
+
+	// AlgIdentifierOldPassword is the identifier used for the mysql_old_password variant of this algorithm.
+	AlgIdentifierOldPassword = "mysql_old_password"
+
+	// AlgIdentifierCachingSHA2 is the identifier used in encoded caching_sha2_password variants of this algorithm.
+	AlgIdentifierCachingSHA2 = "A"
+
+	// IterationsMin is the minimum number of iterations accepted by the caching_sha2_password variant.
+	IterationsMin = 5000
+
+	// IterationsMax is the maximum number of iterations accepted by the caching_sha2_password variant.
+	IterationsMax = 4095000
+
+	// IterationsDefault is the default number of iterations for the caching_sha2_password variant which is the MySQL
+	// default.
+	IterationsDefault = 5000
+
+	// IterationsMultiplier is the multiplier applied to the encoded iteration count of the caching_sha2_password
+	// variant. The iterations must be a multiple of this value.
+	IterationsMultiplier = 1000
+
+	// SaltLength is the salt length of the caching_sha2_password variant.
+	SaltLength = 20
+)
+
+const (
+	variantDefault = VariantCachingSHA2
+
+	prefixNative = "*"
+
+	charSetCrypt = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	keyLengthNative      = 20
+	keyLengthOldPassword = 8
+	keyLengthCachingSHA2 = 43
+
+	oldPasswordNR   uint32 = 1345345333
+	oldPasswordNR2  uint32 = 0x12345671
+	oldPasswordAdd  uint32 = 7
+	oldPasswordMask uint32 = 1<<31 - 1
+)
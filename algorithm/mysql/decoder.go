@@ -0,0 +1,231 @@
+package mysql
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/encoding"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. This registers the mysql_native_password and
+// caching_sha2_password variants, the mysql_old_password variant must be registered via RegisterDecoderOldPassword.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = RegisterDecoderNative(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderCachingSHA2(r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderNative registers specifically the mysql_native_password decoder variant with the
+// algorithm.DecoderMatchRegister. The decoder is selected for encoded digests which begin with an asterisk followed by
+// 40 hex characters.
+func RegisterDecoderNative(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifierNative, DecodeVariant(VariantNative)); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifierNative, MatchEncodedNative); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderOldPassword registers specifically the mysql_old_password decoder variant with the
+// algorithm.DecoderMatchRegister. The decoder is selected for any encoded digest which consists of exactly 16 hex
+// characters, so this should only be registered when the digests are known to be in this format.
+func RegisterDecoderOldPassword(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifierOldPassword, DecodeVariant(VariantOldPassword)); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifierOldPassword, MatchEncodedOldPassword); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderCachingSHA2 registers specifically the caching_sha2_password decoder variant with the
+// algorithm.DecoderRegister.
+func RegisterDecoderCachingSHA2(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifierCachingSHA2, DecodeVariant(VariantCachingSHA2)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncodedNative returns true if the encoded digest is in the mysql_native_password format.
+func MatchEncodedNative(encodedDigest string) (match bool) {
+	return len(encodedDigest) == len(prefixNative)+hex.EncodedLen(keyLengthNative) &&
+		strings.HasPrefix(encodedDigest, prefixNative) && isHex(encodedDigest[len(prefixNative):])
+}
+
+// MatchEncodedOldPassword returns true if the encoded digest is in the mysql_old_password format.
+func MatchEncodedOldPassword(encodedDigest string) (match bool) {
+	return len(encodedDigest) == hex.EncodedLen(keyLengthOldPassword) && isHex(encodedDigest)
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeVariant(VariantNone)(encodedDigest)
+}
+
+// DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided mysql.Variant. If
+// mysql.VariantNone is used all variants can be decoded.
+func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
+	return func(encodedDigest string) (digest algorithm.Digest, err error) {
+		variant := decoderVariant(encodedDigest)
+
+		if variant == VariantNone {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: the digest is not in any of the mysql formats", algorithm.ErrEncodedHashInvalidFormat))
+		}
+
+		if v != VariantNone && v != variant {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
+		}
+
+		if digest, err = decode(variant, encodedDigest); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+}
+
+func decoderVariant(encodedDigest string) (variant Variant) {
+	switch {
+	case strings.HasPrefix(encodedDigest, prefixNative):
+		return VariantNative
+	case strings.HasPrefix(encodedDigest, encoding.DelimiterStr+AlgIdentifierCachingSHA2+encoding.DelimiterStr):
+		return VariantCachingSHA2
+	case MatchEncodedOldPassword(encodedDigest):
+		return VariantOldPassword
+	default:
+		return VariantNone
+	}
+}
+
+// NewDigest returns a mysql.Digest from the raw parameters, salt, and key which is useful when the values of a digest
+// are not stored in the encoded format. The iterations and salt are only valid for the mysql.VariantCachingSHA2 variant
+// and the key of that variant is in the same form as returned by the Key method.
+func NewDigest(variant Variant, iterations int, salt, key []byte) (digest *Digest, err error) {
+	switch variant {
+	case VariantNative, VariantOldPassword:
+		length := keyLengthNative
+
+		if variant == VariantOldPassword {
+			length = keyLengthOldPassword
+		}
+
+		switch {
+		case iterations != 0 || len(salt) != 0:
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: the iterations and salt are only valid for the %s variant", algorithm.ErrParameterInvalid, VariantCachingSHA2))
+		case len(key) != length:
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(key), length))
+		}
+	case VariantCachingSHA2:
+		switch {
+		case iterations < IterationsMin || iterations > IterationsMax:
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations))
+		case iterations%IterationsMultiplier != 0:
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: iterations must be a multiple of %d but is '%d'", algorithm.ErrParameterInvalid, IterationsMultiplier, iterations))
+		case len(salt) != SaltLength:
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(salt), SaltLength))
+		case len(key) != keyLengthCachingSHA2 || strings.Trim(string(key), charSetCrypt) != "":
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key must be %d characters from the crypt base64 character set", algorithm.ErrParameterInvalid, keyLengthCachingSHA2))
+		}
+	default:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be specified", algorithm.ErrParameterInvalid))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, salt: salt, key: key}, nil
+}
+
+func decode(variant Variant, encodedDigest string) (digest algorithm.Digest, err error) {
+	switch variant {
+	case VariantNative:
+		return decodeHex(variant, encodedDigest[len(prefixNative):], keyLengthNative)
+	case VariantOldPassword:
+		return decodeHex(variant, encodedDigest, keyLengthOldPassword)
+	default:
+		return decodeCachingSHA2(encodedDigest)
+	}
+}
+
+func decodeHex(variant Variant, value string, length int) (digest algorithm.Digest, err error) {
+	var key []byte
+
+	if key, err = hex.DecodeString(value); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(key) != length {
+		return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(key), length)
+	}
+
+	return &Digest{variant: variant, key: key}, nil
+}
+
+// decodeCachingSHA2 decodes the caching_sha2_password format. The salt is raw bytes which may include the delimiter so
+// the salt and key are split by their fixed lengths rather than the delimiter.
+func decodeCachingSHA2(encodedDigest string) (digest algorithm.Digest, err error) {
+	value := encodedDigest[len(AlgIdentifierCachingSHA2)+2:]
+
+	count, value, found := strings.Cut(value, encoding.DelimiterStr)
+
+	if !found || len(count) != 3 {
+		return nil, fmt.Errorf("%w: the iteration count must be 3 hex characters followed by the delimiter", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	var n uint64
+
+	if n, err = strconv.ParseUint(count, 16, 16); err != nil {
+		return nil, fmt.Errorf("%w: iterations could not be parsed: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+	}
+
+	iterations := int(n) * IterationsMultiplier
+
+	if iterations < IterationsMin || iterations > IterationsMax {
+		return nil, fmt.Errorf("%w: iterations must be between %d and %d but is '%d'", algorithm.ErrEncodedHashInvalidOptionValue, IterationsMin, IterationsMax, iterations)
+	}
+
+	if len(value) != SaltLength+keyLengthCachingSHA2 {
+		return nil, fmt.Errorf("%w: the salt and key must have a combined length of %d but has a length of %d", algorithm.ErrEncodedHashInvalidFormat, SaltLength+keyLengthCachingSHA2, len(value))
+	}
+
+	key := value[SaltLength:]
+
+	if strings.Trim(key, charSetCrypt) != "" {
+		return nil, fmt.Errorf("%w: key contains characters which are not in the crypt base64 character set", algorithm.ErrEncodedHashKeyEncoding)
+	}
+
+	return &Digest{
+		variant:    VariantCachingSHA2,
+		iterations: iterations,
+		salt:       []byte(value[:SaltLength]),
+		key:        []byte(key),
+	}, nil
+}
+
+func isHex(value string) bool {
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			continue
+		default:
+			return false
+		}
+	}
+
+	return true
+}
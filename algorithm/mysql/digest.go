@@ -0,0 +1,162 @@
+package mysql
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	xcrypt "github.com/go-crypt/x/crypt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a mysql.Digest which handles the MySQL authentication string formats.
+type Digest struct {
+	variant Variant
+
+	iterations int
+
+	salt, key []byte
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current mysql.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current mysql.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.derive(passwordBytes)) == 1, nil
+}
+
+// Encode returns the encoded form of this mysql.Digest.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this mysql.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	switch d.variant {
+	case VariantNative:
+		return fmt.Sprintf(EncodingFmtNative, strings.ToUpper(hex.EncodeToString(d.key)))
+	case VariantOldPassword:
+		return hex.EncodeToString(d.key)
+	default:
+		return fmt.Sprintf(EncodingFmtCachingSHA2, d.variant.Prefix(), d.iterations/IterationsMultiplier, d.salt, d.key)
+	}
+}
+
+// String returns the storable format of the mysql.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw mysql.Digest key which has been derived. The caching_sha2_password variant key is returned in
+// the encoded form.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns the salt used to generate this digest. Only the caching_sha2_password variant has a salt.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Iterations returns the iteration count used to generate this digest. Only the caching_sha2_password variant has
+// iterations.
+func (d *Digest) Iterations() (iterations int) {
+	return d.iterations
+}
+
+// Variant returns the mysql.Variant of this mysql.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Canonical returns a copy of this mysql.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+// derive the key for this mysql.Digest using the password bytes.
+func (d *Digest) derive(passwordBytes []byte) (key []byte) {
+	switch d.variant {
+	case VariantNative:
+		return deriveNative(passwordBytes)
+	case VariantOldPassword:
+		return deriveOldPassword(passwordBytes)
+	default:
+		return xcrypt.KeySHACrypt(sha256.New, passwordBytes, d.salt, d.iterations)
+	}
+}
+
+// deriveNative derives the mysql_native_password key which is the SHA1 digest of the SHA1 digest of the password.
+func deriveNative(passwordBytes []byte) (key []byte) {
+	stage1 := sha1.Sum(passwordBytes) //nolint:gosec
+	stage2 := sha1.Sum(stage1[:])     //nolint:gosec
+
+	return stage2[:]
+}
+
+// deriveOldPassword derives the pre-4.1 mysql_old_password key. Spaces and tabs are skipped by this algorithm.
+func deriveOldPassword(passwordBytes []byte) (key []byte) {
+	nr, nr2, add := oldPasswordNR, oldPasswordNR2, oldPasswordAdd
+
+	for _, c := range passwordBytes {
+		if c == ' ' || c == '\t' {
+			continue
+		}
+
+		tmp := uint32(c)
+
+		nr ^= (((nr & 63) + add) * tmp) + (nr << 8)
+		nr2 += (nr2 << 8) ^ nr
+		add += tmp
+	}
+
+	key = make([]byte, keyLengthOldPassword)
+
+	binary.BigEndian.PutUint32(key[:4], nr&oldPasswordMask)
+	binary.BigEndian.PutUint32(key[4:], nr2&oldPasswordMask)
+
+	return key
+}
+
+func (d *Digest) defaults() {
+	switch d.variant {
+	case VariantNative, VariantOldPassword, VariantCachingSHA2:
+		break
+	default:
+		d.variant = variantDefault
+	}
+
+	if d.variant == VariantCachingSHA2 && d.iterations < IterationsMin {
+		d.iterations = IterationsDefault
+	}
+}
@@ -0,0 +1,10 @@
+// Package mysql provides helpful abstractions for an implementation of the MySQL mysql_native_password,
+// mysql_old_password, and caching_sha2_password authentication string formats and implements
+// github.com/go-crypt/crypt interfaces.
+//
+// The mysql_old_password format is the pre-4.1 OLD_PASSWORD function which is cryptographically broken, it can only be
+// decoded and verified and is only decoded when registered explicitly via RegisterDecoderOldPassword as the encoded
+// form is indistinguishable from other hex encoded values.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package mysql
@@ -0,0 +1,168 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/random"
+)
+
+// New returns a new mysql.Hasher with the provided functional options applied.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// NewNative returns a new mysql.Hasher with the provided functional options applied as well as the
+// mysql.VariantNative variant.
+func NewNative(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantNative, opts...)
+}
+
+// NewCachingSHA2 returns a new mysql.Hasher with the provided functional options applied as well as the
+// mysql.VariantCachingSHA2 variant.
+func NewCachingSHA2(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantCachingSHA2, opts...)
+}
+
+func newVariant(variant Variant, opts ...Opt) (hasher *Hasher, err error) {
+	if hasher, err = New(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.WithOptions(WithVariant(variant)); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for MySQL which can be initialized via New using a functional options pattern.
+type Hasher struct {
+	variant Variant
+
+	iterations int
+
+	d bool
+}
+
+// WithOptions defines the options for this mysql.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hash(password); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hash(password string) (digest algorithm.Digest, err error) {
+	var salt []byte
+
+	if h.variant == VariantCachingSHA2 {
+		if salt, err = random.Bytes(SaltLength); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrSaltReadRandomBytes, err)
+		}
+
+		// MySQL generates salts from 7-bit characters excluding the NUL character and the delimiter.
+		for i := range salt {
+			if salt[i] &= 0x7f; salt[i] == 0 || salt[i] == '$' {
+				salt[i]++
+			}
+		}
+	}
+
+	return h.hashWithSalt(password, salt)
+}
+
+// HashWithSalt overloads the Hash method allowing the user to provide a salt. It's recommended instead to let this be a
+// random value generated using crypto/rand. The salt is ignored by the mysql_native_password variant.
+func (h *Hasher) HashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hashWithSalt(password, salt); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	d := &Digest{
+		variant: h.variant,
+	}
+
+	if d.variant == VariantCachingSHA2 {
+		if len(salt) != SaltLength {
+			return nil, fmt.Errorf("%w: salt bytes must have a length of %d but has a length of %d", algorithm.ErrSaltInvalid, SaltLength, len(salt))
+		}
+
+		d.iterations, d.salt = h.iterations, salt
+	}
+
+	d.defaults()
+
+	d.key = d.derive([]byte(password))
+
+	return d, nil
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	h.defaults()
+
+	return nil
+}
+
+func (h *Hasher) defaults() {
+	if h.d {
+		return
+	}
+
+	h.d = true
+
+	if h.variant == VariantNone {
+		h.variant = variantDefault
+	}
+
+	if h.iterations == 0 {
+		h.iterations = IterationsDefault
+	}
+}
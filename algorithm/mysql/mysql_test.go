@@ -0,0 +1,362 @@
+package mysql
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+func TestNewVariant(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Variant
+	}{
+		{"ShouldReturnNative", AlgIdentifierNative, VariantNative},
+		{"ShouldReturnNativeName", "native", VariantNative},
+		{"ShouldReturnOldPassword", AlgIdentifierOldPassword, VariantOldPassword},
+		{"ShouldReturnOldPasswordName", "old", VariantOldPassword},
+		{"ShouldReturnCachingSHA2", AlgIdentifierCachingSHA2, VariantCachingSHA2},
+		{"ShouldReturnCachingSHA2Name", "caching_sha2_password", VariantCachingSHA2},
+		{"ShouldReturnNoneForUnknown", "sha256_password", VariantNone},
+		{"ShouldReturnNoneForEmpty", "", VariantNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewVariant(tc.have))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	salt, err := hex.DecodeString("F9CC98CE08892924F50A213B6BC571A2C11778C5")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		have       string
+		password   string
+		variant    Variant
+		iterations int
+		encoded    string
+	}{
+		{
+			"ShouldDecodeNative",
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+			"password",
+			VariantNative,
+			0,
+			"",
+		},
+		{
+			"ShouldDecodeNativeLowerCase",
+			"*2470c0c06dee42fd1618bb99005adca2ec9d1e19",
+			"password",
+			VariantNative,
+			0,
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+		},
+		{
+			"ShouldDecodeOldPassword",
+			"5d2e19393cc5ef67",
+			"password",
+			VariantOldPassword,
+			0,
+			"",
+		},
+		{
+			"ShouldDecodeOldPasswordDocumentation",
+			"6f8c114b58f2ce9e",
+			"mypass",
+			VariantOldPassword,
+			0,
+			"",
+		},
+		{
+			"ShouldDecodeCachingSHA2WithDelimiterInSalt",
+			"$A$005$" + string(salt) + "bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/",
+			"hashcat",
+			VariantCachingSHA2,
+			5000,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			expected := tc.encoded
+			if expected == "" {
+				expected = tc.have
+			}
+
+			assert.Equal(t, tc.variant, d.Variant())
+			assert.Equal(t, tc.iterations, d.Iterations())
+			assert.Equal(t, expected, d.Encode())
+			assert.Equal(t, expected, d.String())
+			assert.Equal(t, d.Encode(), d.Canonical().Encode())
+
+			assert.True(t, d.Match(tc.password))
+			assert.False(t, d.Match("wrong"))
+
+			match, err := d.MatchAdvanced(tc.password)
+			assert.NoError(t, err)
+			assert.True(t, match)
+		})
+	}
+}
+
+func TestOldPasswordWhitespace(t *testing.T) {
+	digest, err := Decode("5d2e19393cc5ef67")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("pass word"))
+	assert.True(t, digest.Match("pass\tword"))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrFormat", "password", "mysql decode error: provided encoded hash has an invalid format: the digest is not in any of the mysql formats"},
+		{"ShouldErrNativeHex", "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1EZZ", "mysql decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: invalid byte: U+005A 'Z'"},
+		{"ShouldErrNativeLength", "*2470C0C06DEE42FD", "mysql decode error: provided encoded hash has a key value that can't be decoded: key has 8 bytes but must have 20 bytes"},
+		{"ShouldErrCachingSHA2Count", "$A$5$AAAA", "mysql decode error: provided encoded hash has an invalid format: the iteration count must be 3 hex characters followed by the delimiter"},
+		{"ShouldErrCachingSHA2CountParse", "$A$00Z$AAAA", "mysql decode error: provided encoded hash has an invalid option value: iterations could not be parsed: strconv.ParseUint: parsing \"00Z\": invalid syntax"},
+		{"ShouldErrCachingSHA2Iterations", "$A$004$AAAA", "mysql decode error: provided encoded hash has an invalid option value: iterations must be between 5000 and 4095000 but is '4000'"},
+		{"ShouldErrCachingSHA2Length", "$A$005$AAAA", "mysql decode error: provided encoded hash has an invalid format: the salt and key must have a combined length of 63 but has a length of 4"},
+		{"ShouldErrCachingSHA2Key", "$A$005$AAAAAAAAAAAAAAAAAAAA!Ty95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/", "mysql decode error: provided encoded hash has a key value that can't be decoded: key contains characters which are not in the crypt base64 character set"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecodeVariant(t *testing.T) {
+	digest, err := DecodeVariant(VariantNative)("5d2e19393cc5ef67")
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: the 'mysql_old_password' variant cannot be decoded only the 'mysql_native_password' variant can be")
+}
+
+func TestHasher(t *testing.T) {
+	testCases := []struct {
+		name       string
+		hasher     func(opts ...Opt) (*Hasher, error)
+		opts       []Opt
+		password   string
+		iterations int
+		expected   string
+	}{
+		{
+			"ShouldHashDefault",
+			New,
+			nil,
+			"password",
+			5000,
+			"",
+		},
+		{
+			"ShouldHashNative",
+			NewNative,
+			nil,
+			"password",
+			0,
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+		},
+		{
+			"ShouldHashCachingSHA2WithIterations",
+			NewCachingSHA2,
+			[]Opt{WithIterations(10000)},
+			"password",
+			10000,
+			"",
+		},
+		{
+			"ShouldHashVariantName",
+			New,
+			[]Opt{WithVariantName("native")},
+			"password",
+			0,
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := tc.hasher(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.Hash(tc.password)
+			require.NoError(t, err)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, digest.Encode())
+			}
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, tc.iterations, d.Iterations())
+
+			if d.Variant() == VariantCachingSHA2 {
+				assert.Len(t, d.Salt(), SaltLength)
+				assert.NotContains(t, string(d.Salt()), "$")
+
+				for _, c := range d.Salt() {
+					assert.True(t, c > 0 && c < 0x80)
+				}
+			}
+
+			assert.True(t, digest.Match(tc.password))
+			assert.False(t, digest.Match("wrong"))
+
+			decoded, err := Decode(digest.Encode())
+			require.NoError(t, err)
+
+			assert.Equal(t, digest.Encode(), decoded.Encode())
+			assert.True(t, decoded.Match(tc.password))
+		})
+	}
+}
+
+func TestHasherWithSalt(t *testing.T) {
+	salt, err := hex.DecodeString("F9CC98CE08892924F50A213B6BC571A2C11778C5")
+	require.NoError(t, err)
+
+	hasher, err := NewCachingSHA2()
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt("hashcat", salt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "$A$005$"+string(salt)+"bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/", digest.Encode())
+
+	digest, err = hasher.HashWithSalt("hashcat", []byte("salt"))
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql hashing error: salt is invalid: salt bytes must have a length of 20 but has a length of 4")
+
+	assert.True(t, hasher.MustHash("password").Match("password"))
+}
+
+func TestHasherOptionErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have Opt
+		err  string
+	}{
+		{"ShouldErrVariant", WithVariant(VariantOldPassword), "mysql validation error: parameter is invalid: variant '2' is invalid"},
+		{"ShouldErrVariantName", WithVariantName(AlgIdentifierOldPassword), "mysql validation error: parameter is invalid: variant identifier 'mysql_old_password' is invalid"},
+		{"ShouldErrVariantNameUnknown", WithVariantName("sha256_password"), "mysql validation error: parameter is invalid: variant identifier 'sha256_password' is invalid"},
+		{"ShouldErrIterationsMin", WithIterations(4000), "mysql validation error: parameter is invalid: parameter 'iterations' must be between 5000 and 4095000 but is set to '4000'"},
+		{"ShouldErrIterationsMax", WithIterations(4096000), "mysql validation error: parameter is invalid: parameter 'iterations' must be between 5000 and 4095000 but is set to '4096000'"},
+		{"ShouldErrIterationsMultiple", WithIterations(5500), "mysql validation error: parameter is invalid: parameter 'iterations' must be a multiple of 1000 but is set to '5500'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.have)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	require.NoError(t, err)
+
+	assert.IsType(t, &Digest{}, digest)
+	assert.True(t, digest.Match("password"))
+
+	hasher, err := NewCachingSHA2()
+	require.NoError(t, err)
+
+	digest, err = d.Decode(hasher.MustHash("password").Encode())
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("password"))
+
+	_, err = d.Decode("5d2e19393cc5ef67")
+	assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+
+	require.NoError(t, RegisterDecoderOldPassword(d))
+
+	digest, err = d.Decode("5d2e19393cc5ef67")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("password"))
+
+	assert.False(t, MatchEncodedNative("*2470C0C06DEE42FD"))
+	assert.False(t, MatchEncodedNative("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1EZZ"))
+	assert.False(t, MatchEncodedOldPassword("5d2e19393cc5ef6z"))
+
+	var _ algorithm.CanonicalDigest = &Digest{}
+}
+
+func TestNewDigest(t *testing.T) {
+	native, err := Decode("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantNative, 0, nil, native.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, native.Encode(), digest.Encode())
+	assert.True(t, digest.Match("password"))
+
+	salt, err := hex.DecodeString("F9CC98CE08892924F50A213B6BC571A2C11778C5")
+	require.NoError(t, err)
+
+	digest, err = NewDigest(VariantCachingSHA2, 5000, salt, []byte("bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/"))
+	require.NoError(t, err)
+
+	decoded, err := Decode(digest.Encode())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(VariantNative, 5000, nil, native.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: parameter is invalid: the iterations and salt are only valid for the caching_sha2_password variant")
+
+	digest, err = NewDigest(VariantOldPassword, 0, nil, native.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: parameter is invalid: key has 20 bytes but must have 8 bytes")
+
+	digest, err = NewDigest(VariantCachingSHA2, 5500, salt, []byte("bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/"))
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: parameter is invalid: iterations must be a multiple of 1000 but is '5500'")
+
+	digest, err = NewDigest(VariantCachingSHA2, 5000, salt[1:], []byte("bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/"))
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: parameter is invalid: salt has 19 bytes but must have 20 bytes")
+
+	digest, err = NewDigest(VariantNone, 0, nil, native.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "mysql decode error: parameter is invalid: variant must be specified")
+}
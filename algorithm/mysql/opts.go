@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the mysql.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithVariant configures the mysql.Variant of the resulting mysql.Digest. The mysql.VariantOldPassword variant can
+// only be decoded and is not accepted.
+// Default is mysql.VariantCachingSHA2.
+func WithVariant(variant Variant) Opt {
+	return func(h *Hasher) (err error) {
+		switch variant {
+		case VariantNone:
+			return nil
+		case VariantNative, VariantCachingSHA2:
+			h.variant = variant
+
+			return nil
+		default:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant))
+		}
+	}
+}
+
+// WithVariantName uses the variant name or identifier to configure the mysql.Variant of the resulting mysql.Digest.
+// The mysql.VariantOldPassword variant can only be decoded and is not accepted.
+// Default is mysql.VariantCachingSHA2.
+func WithVariantName(identifier string) Opt {
+	return func(h *Hasher) (err error) {
+		if identifier == "" {
+			return nil
+		}
+
+		variant := NewVariant(identifier)
+
+		if variant == VariantNone || variant == VariantOldPassword {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
+		}
+
+		h.variant = variant
+
+		return nil
+	}
+}
+
+// WithIterations sets the iterations parameter of the resulting caching_sha2_password mysql.Digest. The iterations
+// must be a multiple of 1000.
+// Minimum is 5000, Maximum is 4095000. Default is 5000.
+func WithIterations(iterations int) Opt {
+	return func(h *Hasher) (err error) {
+		if iterations < IterationsMin || iterations > IterationsMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations))
+		}
+
+		if iterations%IterationsMultiplier != 0 {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: parameter 'iterations' must be a multiple of %d but is set to '%d'", algorithm.ErrParameterInvalid, IterationsMultiplier, iterations))
+		}
+
+		h.iterations = iterations
+
+		return nil
+	}
+}
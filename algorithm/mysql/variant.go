@@ -0,0 +1,57 @@
+package mysql
+
+// NewVariant converts an identifier string to a mysql.Variant.
+func NewVariant(identifier string) (variant Variant) {
+	switch identifier {
+	case AlgIdentifierNative, "native":
+		return VariantNative
+	case AlgIdentifierOldPassword, "old", "old_password":
+		return VariantOldPassword
+	case AlgIdentifierCachingSHA2, "caching_sha2_password", "caching_sha2":
+		return VariantCachingSHA2
+	default:
+		return VariantNone
+	}
+}
+
+// Variant is a variant of the mysql.Digest.
+type Variant int
+
+const (
+	// VariantNone is a variant of the mysql.Digest which is unknown.
+	VariantNone Variant = iota
+
+	// VariantNative is the mysql_native_password variant of the mysql.Digest.
+	VariantNative
+
+	// VariantOldPassword is the pre-4.1 mysql_old_password variant of the mysql.Digest. This variant can only be
+	// decoded and verified.
+	VariantOldPassword
+
+	// VariantCachingSHA2 is the caching_sha2_password variant of the mysql.Digest.
+	VariantCachingSHA2
+)
+
+// String implements the fmt.Stringer returning a string representation of the mysql.Variant.
+func (v Variant) String() (name string) {
+	switch v {
+	case VariantNative:
+		return AlgIdentifierNative
+	case VariantOldPassword:
+		return AlgIdentifierOldPassword
+	case VariantCachingSHA2:
+		return "caching_sha2_password"
+	default:
+		return
+	}
+}
+
+// Prefix returns the mysql.Variant prefix identifier.
+func (v Variant) Prefix() (prefix string) {
+	switch v {
+	case VariantCachingSHA2:
+		return AlgIdentifierCachingSHA2
+	default:
+		return
+	}
+}
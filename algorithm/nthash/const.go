@@ -0,0 +1,34 @@
+package nthash
+
+const (
+	// EncodingFmt is the encoding format for this algorithm.
+	EncodingFmt = "$%s$$%x"
+
+	// EncodingFmtRaw is the encoding format for this algorithm when using nthash.VariantRaw.
+	EncodingFmtRaw = "%x"
+
+	// EncodingFmtRawUpper is the encoding format for this algorithm when using nthash.VariantRaw and the digest was
+	// decoded from the uppercase form.
+	EncodingFmtRawUpper = "%X"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "nthash"
+
+	// AlgIdentifier is the identifier used in this algorithm.
+	AlgIdentifier = "3"
+
+	// AlgIdentifierRaw is the identifier used to register the decoder for nthash.VariantRaw.
+	AlgIdentifierRaw = AlgName
+
+	// VariantNameFreeBSD is the nthash.Variant name for nthash.VariantFreeBSD.
+	VariantNameFreeBSD = "freebsd"
+
+	// VariantNameRaw is the nthash.Variant name for nthash.VariantRaw.
+	VariantNameRaw = "raw"
+)
+
+const (
+	variantDefault = VariantFreeBSD
+
+	keyLength = 16
+)
@@ -0,0 +1,139 @@
+package nthash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderRegister. This only registers the nthash.VariantFreeBSD
+// variant, the nthash.VariantRaw variant must be registered explicitly via RegisterDecoderRaw.
+func RegisterDecoder(r algorithm.DecoderRegister) (err error) {
+	if err = RegisterDecoderFreeBSD(r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderFreeBSD registers specifically the FreeBSD decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderFreeBSD(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, DecodeVariant(VariantFreeBSD)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderRaw registers specifically the raw decoder variant with the algorithm.DecoderMatchRegister. The
+// decoder is selected for any encoded digest which consists of exactly 32 hex characters, so this should only be
+// registered when the digests are known to be in this format.
+func RegisterDecoderRaw(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifierRaw, DecodeVariant(VariantRaw)); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifierRaw, MatchEncodedRaw); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncodedRaw returns true if the encoded digest consists of exactly 32 hex characters.
+func MatchEncodedRaw(encodedDigest string) (match bool) {
+	if len(encodedDigest) != hex.EncodedLen(keyLength) {
+		return false
+	}
+
+	_, err := hex.DecodeString(encodedDigest)
+
+	return err == nil
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeVariant(VariantNone)(encodedDigest)
+}
+
+// DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided nthash.Variant. If
+// nthash.VariantNone is used all variants can be decoded.
+func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
+	return func(encodedDigest string) (digest algorithm.Digest, err error) {
+		var (
+			key     string
+			variant Variant
+		)
+
+		if variant, key, err = decoderParts(encodedDigest); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		if v != VariantNone && v != variant {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
+		}
+
+		if digest, err = decode(variant, key); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+}
+
+// NewDigest returns a nthash.Digest from the raw key which is useful when the digest is not stored in the encoded
+// format.
+func NewDigest(variant Variant, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant != VariantFreeBSD && variant != VariantRaw:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantFreeBSD, VariantRaw))
+	case len(key) != keyLength:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(key), keyLength))
+	}
+
+	return &Digest{variant: variant, key: key}, nil
+}
+
+func decoderParts(encodedDigest string) (variant Variant, key string, err error) {
+	if len(encodedDigest) == 0 || rune(encodedDigest[0]) != encoding.Delimiter {
+		return VariantRaw, encodedDigest, nil
+	}
+
+	parts := phc.Split(encodedDigest, -1)
+
+	if len(parts) != 4 {
+		return VariantNone, "", algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	if parts[1] != AlgIdentifier {
+		return VariantNone, "", fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
+	}
+
+	if parts[2] != "" {
+		return VariantNone, "", fmt.Errorf("%w: the salt must be empty", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	return VariantFreeBSD, parts[3], nil
+}
+
+func decode(variant Variant, value string) (digest algorithm.Digest, err error) {
+	decoded := &Digest{
+		variant: variant,
+	}
+
+	if decoded.key, err = hex.DecodeString(value); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(decoded.key) != keyLength {
+		return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(decoded.key), keyLength)
+	}
+
+	decoded.uppercase = variant == VariantRaw && value == strings.ToUpper(value)
+
+	return decoded, nil
+}
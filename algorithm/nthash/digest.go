@@ -0,0 +1,119 @@
+package nthash
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/md4"
+)
+
+// Digest is a nthash.Digest which handles the Windows NT hash.
+type Digest struct {
+	variant Variant
+
+	key []byte
+
+	uppercase bool
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current nthash.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current nthash.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, derive(passwordBytes)) == 1, nil
+}
+
+// Encode returns the encoded form of this nthash.Digest.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this nthash.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	switch {
+	case d.variant != VariantRaw:
+		return fmt.Sprintf(EncodingFmt, d.variant.Prefix(), d.key)
+	case d.uppercase:
+		return fmt.Sprintf(EncodingFmtRawUpper, d.key)
+	default:
+		return fmt.Sprintf(EncodingFmtRaw, d.key)
+	}
+}
+
+// String returns the storable format of the nthash.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw nthash.Digest key which has been derived.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns nil as the NT hash is unsalted.
+func (d *Digest) Salt() (salt []byte) {
+	return nil
+}
+
+// Variant returns the nthash.Variant of this nthash.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Canonical returns a copy of this nthash.Digest which is encoded in the canonical form which is the FreeBSD crypt
+// format.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix, c.variant, c.uppercase = algorithm.Prefix{}, VariantFreeBSD, false
+
+	return &c
+}
+
+func (d *Digest) defaults() {
+	switch d.variant {
+	case VariantFreeBSD, VariantRaw:
+		break
+	default:
+		d.variant = variantDefault
+	}
+}
+
+// derive the key which is the MD4 digest of the UTF-16LE encoded password.
+func derive(passwordBytes []byte) (key []byte) {
+	encoded := utf16.Encode([]rune(string(passwordBytes)))
+
+	value := make([]byte, len(encoded)*2)
+
+	for i, c := range encoded {
+		binary.LittleEndian.PutUint16(value[i*2:], c)
+	}
+
+	sum := md4.Sum(value)
+
+	return sum[:]
+}
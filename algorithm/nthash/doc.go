@@ -0,0 +1,10 @@
+// Package nthash provides helpful abstractions for an implementation of the Windows NT hash which is the MD4 digest of
+// the UTF-16LE encoded password and implements github.com/go-crypt/crypt interfaces.
+//
+// Digests are encoded in the FreeBSD crypt format. The raw hex encoded form used by Samba and Active Directory exports
+// can also be decoded, however it must be registered explicitly via RegisterDecoderRaw as it can't be distinguished
+// from other hex encoded values.
+//
+// This implementation is cryptographically broken as it's unsalted and uses MD4, it should only be used for backwards
+// compatibility or auditing. This implementation is loaded by crypt.NewDecoderAll.
+package nthash
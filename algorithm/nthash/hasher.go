@@ -0,0 +1,90 @@
+package nthash
+
+import (
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// New returns a new nthash.Hasher with the provided functional options applied.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for the Windows NT hash which can be initialized via New using a functional options pattern.
+type Hasher struct {
+	variant Variant
+
+	d bool
+}
+
+// WithOptions defines the options for this nthash.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	d := &Digest{
+		variant: h.variant,
+	}
+
+	d.defaults()
+
+	d.key = derive([]byte(password))
+
+	return d, nil
+}
+
+// HashWithSalt is an overload of nthash.Digest that also accepts a salt. The salt is ignored as the NT hash is
+// unsalted.
+func (h *Hasher) HashWithSalt(password string, _ []byte) (digest algorithm.Digest, err error) {
+	return h.Hash(password)
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	h.defaults()
+
+	return nil
+}
+
+func (h *Hasher) defaults() {
+	if h.d {
+		return
+	}
+
+	h.d = true
+
+	if h.variant == VariantNone {
+		h.variant = variantDefault
+	}
+}
@@ -0,0 +1,186 @@
+package nthash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+func TestNewVariant(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Variant
+	}{
+		{"ShouldReturnFreeBSD", AlgIdentifier, VariantFreeBSD},
+		{"ShouldReturnFreeBSDName", VariantNameFreeBSD, VariantFreeBSD},
+		{"ShouldReturnRaw", VariantNameRaw, VariantRaw},
+		{"ShouldReturnNoneForUnknown", "lm", VariantNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewVariant(tc.have))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		password  string
+		variant   Variant
+		canonical string
+	}{
+		{"ShouldDecodeFreeBSD", "$3$$8846f7eaee8fb117ad06bdd830b7586c", "password", VariantFreeBSD, "$3$$8846f7eaee8fb117ad06bdd830b7586c"},
+		{"ShouldDecodeFreeBSDEmpty", "$3$$31d6cfe0d16ae931b73c59d7e0c089c0", "", VariantFreeBSD, "$3$$31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"ShouldDecodeRaw", "8846f7eaee8fb117ad06bdd830b7586c", "password", VariantRaw, "$3$$8846f7eaee8fb117ad06bdd830b7586c"},
+		{"ShouldDecodeRawUppercase", "8846F7EAEE8FB117AD06BDD830B7586C", "password", VariantRaw, "$3$$8846f7eaee8fb117ad06bdd830b7586c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, tc.variant, d.Variant())
+			assert.Equal(t, tc.have, d.Encode())
+			assert.Equal(t, tc.have, d.String())
+			assert.Equal(t, tc.canonical, d.Canonical().Encode())
+			assert.Equal(t, tc.have == tc.canonical, d.Encode() == d.Canonical().Encode())
+			assert.Nil(t, d.Salt())
+			assert.Len(t, d.Key(), 16)
+
+			assert.True(t, d.Match(tc.password))
+			assert.False(t, d.Match("wrong"))
+
+			match, err := d.MatchAdvanced(tc.password)
+			assert.NoError(t, err)
+			assert.True(t, match)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrFormat", "$3$8846f7eaee8fb117ad06bdd830b7586c", "nthash decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrIdentifier", "$4$$8846f7eaee8fb117ad06bdd830b7586c", "nthash decode error: provided encoded hash has an invalid identifier: identifier '4' is not an encoded nthash digest"},
+		{"ShouldErrSalt", "$3$salt$8846f7eaee8fb117ad06bdd830b7586c", "nthash decode error: provided encoded hash has an invalid format: the salt must be empty"},
+		{"ShouldErrKeyEncoding", "$3$$8846f7eaee8fb117ad06bdd830b758zz", "nthash decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: invalid byte: U+007A 'z'"},
+		{"ShouldErrKeyLength", "8846f7eaee8fb117", "nthash decode error: provided encoded hash has a key value that can't be decoded: key has 8 bytes but must have 16 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecodeVariant(t *testing.T) {
+	digest, err := DecodeVariant(VariantFreeBSD)("8846f7eaee8fb117ad06bdd830b7586c")
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "nthash decode error: the 'raw' variant cannot be decoded only the 'freebsd' variant can be")
+}
+
+func TestHasher(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Opt
+		password string
+		expected string
+	}{
+		{"ShouldHashDefault", nil, "password", "$3$$8846f7eaee8fb117ad06bdd830b7586c"},
+		{"ShouldHashRaw", []Opt{WithVariant(VariantRaw)}, "password", "8846f7eaee8fb117ad06bdd830b7586c"},
+		{"ShouldHashVariantName", []Opt{WithVariantName(VariantNameRaw)}, "password", "8846f7eaee8fb117ad06bdd830b7586c"},
+		{"ShouldHashEmpty", nil, "", "$3$$31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"ShouldHashUnicode", nil, "pässwörd😀", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt(tc.password, []byte("ignored"))
+			require.NoError(t, err)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, digest.Encode())
+			}
+
+			assert.True(t, digest.Match(tc.password))
+			assert.False(t, digest.Match("wrong"))
+			assert.True(t, hasher.MustHash(tc.password).Match(tc.password))
+
+			decoded, err := Decode(digest.Encode())
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match(tc.password))
+		})
+	}
+}
+
+func TestHasherOptionErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have Opt
+		err  string
+	}{
+		{"ShouldErrVariant", WithVariant(Variant(99)), "nthash validation error: parameter is invalid: variant '99' is invalid"},
+		{"ShouldErrVariantName", WithVariantName("lm"), "nthash validation error: parameter is invalid: variant identifier 'lm' is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.have)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestMatchEncodedRaw(t *testing.T) {
+	assert.True(t, MatchEncodedRaw("8846f7eaee8fb117ad06bdd830b7586c"))
+	assert.True(t, MatchEncodedRaw("8846F7EAEE8FB117AD06BDD830B7586C"))
+	assert.False(t, MatchEncodedRaw("8846f7eaee8fb117ad06bdd830b758zz"))
+	assert.False(t, MatchEncodedRaw("8846f7eaee8fb117"))
+	assert.False(t, MatchEncodedRaw("$3$$8846f7eaee8fb117ad06bdd830b7586c"))
+
+	var _ algorithm.CanonicalDigest = &Digest{}
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("$3$$8846f7eaee8fb117ad06bdd830b7586c")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantFreeBSD, decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+	assert.True(t, digest.Match("password"))
+
+	digest, err = NewDigest(VariantNone, decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "nthash decode error: parameter is invalid: variant must be either the 'freebsd' or 'raw' variant")
+
+	digest, err = NewDigest(VariantRaw, decoded.Key()[1:])
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "nthash decode error: parameter is invalid: key has 15 bytes but must have 16 bytes")
+}
@@ -0,0 +1,47 @@
+package nthash
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the nthash.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithVariant configures the nthash.Variant of the resulting nthash.Digest.
+// Default is nthash.VariantFreeBSD.
+func WithVariant(variant Variant) Opt {
+	return func(h *Hasher) (err error) {
+		switch variant {
+		case VariantNone:
+			return nil
+		case VariantFreeBSD, VariantRaw:
+			h.variant = variant
+
+			return nil
+		default:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant))
+		}
+	}
+}
+
+// WithVariantName uses the variant name or identifier to configure the nthash.Variant of the resulting nthash.Digest.
+// Default is nthash.VariantFreeBSD.
+func WithVariantName(identifier string) Opt {
+	return func(h *Hasher) (err error) {
+		if identifier == "" {
+			return nil
+		}
+
+		variant := NewVariant(identifier)
+
+		if variant == VariantNone {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
+		}
+
+		h.variant = variant
+
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package nthash
+
+// NewVariant converts an identifier string to a nthash.Variant.
+func NewVariant(identifier string) (variant Variant) {
+	switch identifier {
+	case AlgIdentifier, VariantNameFreeBSD:
+		return VariantFreeBSD
+	case VariantNameRaw:
+		return VariantRaw
+	default:
+		return VariantNone
+	}
+}
+
+// Variant is a variant of the nthash.Digest.
+type Variant int
+
+const (
+	// VariantNone is a variant of the nthash.Digest which is unknown.
+	VariantNone Variant = iota
+
+	// VariantFreeBSD is the FreeBSD crypt variant of the nthash.Digest which is encoded with the $3$ identifier.
+	VariantFreeBSD
+
+	// VariantRaw is the raw hex encoded variant of the nthash.Digest.
+	VariantRaw
+)
+
+// String implements the fmt.Stringer returning a string representation of the nthash.Variant.
+func (v Variant) String() (name string) {
+	switch v {
+	case VariantFreeBSD:
+		return VariantNameFreeBSD
+	case VariantRaw:
+		return VariantNameRaw
+	default:
+		return
+	}
+}
+
+// Prefix returns the nthash.Variant prefix identifier.
+func (v Variant) Prefix() (prefix string) {
+	switch v {
+	case VariantFreeBSD:
+		return AlgIdentifier
+	default:
+		return
+	}
+}
@@ -8,6 +8,13 @@ const (
 	// EncodingFmt is the encoding format for this algorithm.
 	EncodingFmt = "$%s$%d$%s$%s"
 
+	// EncodingFmtLDAP is the encoding format for this algorithm when stored with a LDAP scheme prefix such as
+	// {PBKDF2-SHA256} which replaces the identifier.
+	EncodingFmtLDAP = "%s%d$%s$%s"
+
+	// StoragePrefixLDAP is the start of the LDAP scheme prefixes for this algorithm such as {PBKDF2-SHA256}.
+	StoragePrefixLDAP = "{PBKDF2"
+
 	// AlgName is the name for this algorithm.
 	AlgName = "pbkdf2"
 
@@ -29,6 +36,18 @@ const (
 	// AlgIdentifierSHA512 is the identifier used in encoded SHA512 variants of this algorithm.
 	AlgIdentifierSHA512 = "pbkdf2-sha512"
 
+	// AlgIdentifierSHA512_256 is the identifier used in encoded SHA512/256 variants of this algorithm.
+	AlgIdentifierSHA512_256 = "pbkdf2-sha512-256"
+
+	// AlgIdentifierSHA3_256 is the identifier used in encoded SHA3-256 variants of this algorithm.
+	AlgIdentifierSHA3_256 = "pbkdf2-sha3-256"
+
+	// AlgIdentifierSHA3_512 is the identifier used in encoded SHA3-512 variants of this algorithm.
+	AlgIdentifierSHA3_512 = "pbkdf2-sha3-512"
+
+	// AlgIdentifierBLAKE2b_512 is the identifier used in encoded BLAKE2b-512 variants of this algorithm.
+	AlgIdentifierBLAKE2b_512 = "pbkdf2-blake2b-512"
+
 	// KeyLengthMax is the maximum tag size accepted.
 	KeyLengthMax = math.MaxInt32
 
@@ -47,10 +66,11 @@ const (
 	// IterationsDefaultSHA1 is the default iterations for algorithms SHA1 and SHA224.
 	IterationsDefaultSHA1 = 720000
 
-	// IterationsDefaultSHA256 is the default iterations for algorithms SHA256 and SHA384.
+	// IterationsDefaultSHA256 is the default iterations for algorithms SHA256, SHA384, SHA512/256, SHA3-256, and
+	// custom variants.
 	IterationsDefaultSHA256 = 310000
 
-	// IterationsDefaultSHA512 is the default iterations for algorithms SHA512.
+	// IterationsDefaultSHA512 is the default iterations for algorithms SHA512, SHA3-512, and BLAKE2b-512.
 	IterationsDefaultSHA512 = 120000
 
 	variantDefault = VariantSHA256
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -30,6 +31,22 @@ func RegisterDecoder(r algorithm.DecoderRegister) (err error) {
 		return err
 	}
 
+	if err = RegisterDecoderSHA512_256(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderSHA3_256(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderSHA3_512(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderBLAKE2b_512(r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -84,6 +101,40 @@ func RegisterDecoderSHA512(r algorithm.DecoderRegister) (err error) {
 	return nil
 }
 
+// RegisterDecoderSHA512_256 registers specifically the sha512-256 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderSHA512_256(r algorithm.DecoderRegister) (err error) {
+	return RegisterDecoderVariant(r, VariantSHA512_256)
+}
+
+// RegisterDecoderSHA3_256 registers specifically the sha3-256 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderSHA3_256(r algorithm.DecoderRegister) (err error) {
+	return RegisterDecoderVariant(r, VariantSHA3_256)
+}
+
+// RegisterDecoderSHA3_512 registers specifically the sha3-512 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderSHA3_512(r algorithm.DecoderRegister) (err error) {
+	return RegisterDecoderVariant(r, VariantSHA3_512)
+}
+
+// RegisterDecoderBLAKE2b_512 registers specifically the blake2b-512 decoder variant with the algorithm.DecoderRegister.
+func RegisterDecoderBLAKE2b_512(r algorithm.DecoderRegister) (err error) {
+	return RegisterDecoderVariant(r, VariantBLAKE2b_512)
+}
+
+// RegisterDecoderVariant registers specifically the decoder for the provided pbkdf2.Variant with the
+// algorithm.DecoderRegister. This is primarily intended for variants registered with pbkdf2.RegisterVariant.
+func RegisterDecoderVariant(r algorithm.DecoderRegister, variant Variant) (err error) {
+	if !variant.valid() {
+		return fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant)
+	}
+
+	if err = r.RegisterDecodeFunc(variant.Prefix(), DecodeVariant(variant)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Decode the encoded digest into a algorithm.Digest.
 func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	return DecodeVariant(VariantNone)(encodedDigest)
@@ -94,11 +145,12 @@ func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
 	return func(encodedDigest string) (digest algorithm.Digest, err error) {
 		var (
-			parts   []string
-			variant Variant
+			parts      []string
+			variant    Variant
+			identifier string
 		)
 
-		if variant, parts, err = decoderParts(encodedDigest); err != nil {
+		if variant, identifier, parts, err = decoderParts(encodedDigest); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -106,7 +158,7 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
 		}
 
-		if digest, err = decode(variant, parts); err != nil {
+		if digest, err = decode(variant, identifier, parts); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -114,27 +166,46 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
-func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+// NewDigest returns a pbkdf2.Digest from the raw iterations, salt, and key which is useful when the values of a digest
+// are not stored in the encoded format. The key length is the length of the key.
+func NewDigest(variant Variant, iterations int, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case !variant.valid():
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be specified", algorithm.ErrParameterInvalid))
+	case iterations < 1:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", 1, "", IterationsMax, iterations))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, t: len(key), salt: salt, key: key}, nil
+}
+
+func decoderParts(encodedDigest string) (variant Variant, identifier string, parts []string, err error) {
+	parts = phc.Split(encodedDigest, -1)
 
 	if len(parts) != 5 {
-		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
+		return VariantNone, "", nil, algorithm.ErrEncodedHashInvalidFormat
 	}
 
 	variant = NewVariant(parts[1])
 
 	if variant == VariantNone {
-		return variant, nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
+		return variant, "", nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[1], AlgName)
 	}
 
-	return variant, parts[2:], nil
+	return variant, parts[1], parts[2:], nil
 }
 
-func decode(variant Variant, parts []string) (digest algorithm.Digest, err error) {
+func decode(variant Variant, identifier string, parts []string) (digest algorithm.Digest, err error) {
 	decoded := &Digest{
 		variant: variant,
 	}
 
+	if identifier != variant.Prefix() {
+		decoded.identifier = identifier
+	}
+
 	decoded.variant = variant
 
 	if decoded.iterations, err = strconv.Atoi(parts[0]); err != nil {
@@ -3,6 +3,7 @@ package pbkdf2
 import (
 	"crypto/subtle"
 	"fmt"
+	"strings"
 
 	"github.com/go-crypt/x/pbkdf2"
 
@@ -17,6 +18,10 @@ type Digest struct {
 	iterations int
 	t          int
 	salt, key  []byte
+
+	algorithm.Prefix
+
+	identifier string
 }
 
 // Match returns true if the string password matches the current pbkdf2.Digest.
@@ -45,10 +50,30 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 	return subtle.ConstantTimeCompare(d.key, pbkdf2.Key(passwordBytes, d.salt, d.iterations, d.t, d.variant.HashFunc())) == 1, nil
 }
 
-// Encode returns the encoded form of this pbkdf2.Digest.
+// Encode returns the encoded form of this pbkdf2.Digest. If the storage prefix is one of the LDAP scheme prefixes such
+// as {PBKDF2-SHA256} it replaces the identifier.
 func (d *Digest) Encode() string {
+	if prefix := d.StoragePrefix(); strings.HasPrefix(prefix, StoragePrefixLDAP) {
+		return fmt.Sprintf(EncodingFmtLDAP,
+			prefix,
+			d.iterations,
+			encoding.Base64RawAdaptedEncoding.EncodeToString(d.salt), encoding.Base64RawAdaptedEncoding.EncodeToString(d.key),
+		)
+	}
+
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this pbkdf2.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	identifier := d.variant.Prefix()
+
+	if d.identifier != "" {
+		identifier = d.identifier
+	}
+
 	return fmt.Sprintf(EncodingFmt,
-		d.variant.Prefix(),
+		identifier,
 		d.iterations,
 		encoding.Base64RawAdaptedEncoding.EncodeToString(d.salt), encoding.Base64RawAdaptedEncoding.EncodeToString(d.key),
 	)
@@ -69,11 +94,27 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the pbkdf2.Variant of this pbkdf2.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Iterations returns the iteration count used to generate this digest.
+func (d *Digest) Iterations() (iterations int) {
+	return d.iterations
+}
+
+// Canonical returns a copy of this pbkdf2.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix, c.identifier = algorithm.Prefix{}, ""
+
+	return &c
+}
+
 func (d *Digest) defaults() {
-	switch d.variant {
-	case VariantSHA1, VariantSHA224, VariantSHA256, VariantSHA384, VariantSHA512:
-		break
-	default:
+	if !d.variant.valid() {
 		d.variant = variantDefault
 	}
 
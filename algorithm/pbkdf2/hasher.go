@@ -26,63 +26,61 @@ func New(opts ...Opt) (hasher *Hasher, err error) {
 
 // NewSHA1 returns a SHA1 variant *pbkdf2.Hasher with the additional opts applied if any.
 func NewSHA1(opts ...Opt) (hasher *Hasher, err error) {
-	if hasher, err = New(opts...); err != nil {
-		return nil, err
-	}
-
-	if err = hasher.WithOptions(WithVariant(VariantSHA1)); err != nil {
-		return nil, err
-	}
-
-	return hasher, nil
+	return newVariant(VariantSHA1, opts...)
 }
 
 // NewSHA224 returns a SHA224 variant *pbkdf2.Hasher with the additional opts applied if any.
 func NewSHA224(opts ...Opt) (hasher *Hasher, err error) {
-	if hasher, err = New(opts...); err != nil {
-		return nil, err
-	}
-
-	if err = hasher.WithOptions(WithVariant(VariantSHA224)); err != nil {
-		return nil, err
-	}
-
-	return hasher, nil
+	return newVariant(VariantSHA224, opts...)
 }
 
 // NewSHA256 returns a SHA256 variant *pbkdf2.Hasher with the additional opts applied if any.
 func NewSHA256(opts ...Opt) (hasher *Hasher, err error) {
-	if hasher, err = New(opts...); err != nil {
-		return nil, err
-	}
-
-	if err = hasher.WithOptions(WithVariant(VariantSHA256)); err != nil {
-		return nil, err
-	}
-
-	return hasher, nil
+	return newVariant(VariantSHA256, opts...)
 }
 
 // NewSHA384 returns a SHA384 variant *pbkdf2.Hasher with the additional opts applied if any.
 func NewSHA384(opts ...Opt) (hasher *Hasher, err error) {
-	if hasher, err = New(opts...); err != nil {
-		return nil, err
-	}
-
-	if err = hasher.WithOptions(WithVariant(VariantSHA384)); err != nil {
-		return nil, err
-	}
-
-	return hasher, nil
+	return newVariant(VariantSHA384, opts...)
 }
 
 // NewSHA512 returns a SHA512 variant *pbkdf2.Hasher with the additional opts applied if any.
 func NewSHA512(opts ...Opt) (hasher *Hasher, err error) {
-	if hasher, err = New(opts...); err != nil {
+	return newVariant(VariantSHA512, opts...)
+}
+
+// NewSHA512_256 returns a SHA512/256 variant *pbkdf2.Hasher with the additional opts applied if any.
+func NewSHA512_256(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantSHA512_256, opts...)
+}
+
+// NewSHA3_256 returns a SHA3-256 variant *pbkdf2.Hasher with the additional opts applied if any.
+func NewSHA3_256(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantSHA3_256, opts...)
+}
+
+// NewSHA3_512 returns a SHA3-512 variant *pbkdf2.Hasher with the additional opts applied if any.
+func NewSHA3_512(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantSHA3_512, opts...)
+}
+
+// NewBLAKE2b_512 returns a BLAKE2b-512 variant *pbkdf2.Hasher with the additional opts applied if any.
+func NewBLAKE2b_512(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantBLAKE2b_512, opts...)
+}
+
+func newVariant(variant Variant, opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
 		return nil, err
 	}
 
-	if err = hasher.WithOptions(WithVariant(VariantSHA512)); err != nil {
+	if err = hasher.WithOptions(WithVariant(variant)); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
 		return nil, err
 	}
 
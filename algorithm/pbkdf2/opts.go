@@ -13,10 +13,10 @@ type Opt func(h *Hasher) (err error)
 // Default is pbkdf2.VariantSHA256.
 func WithVariant(variant Variant) Opt {
 	return func(h *Hasher) (err error) {
-		switch variant {
-		case VariantNone:
+		switch {
+		case variant == VariantNone:
 			return nil
-		case VariantSHA1, VariantSHA224, VariantSHA256, VariantSHA384, VariantSHA512:
+		case variant.valid():
 			h.variant = variant
 
 			return nil
@@ -1,10 +1,13 @@
 package pbkdf2
 
 import (
+	"crypto/sha256"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
 )
 
 func TestNewVariant(t *testing.T) {
@@ -24,6 +27,14 @@ func TestNewVariant(t *testing.T) {
 		{"ShouldReturnSHA384ForName", "sha384", VariantSHA384},
 		{"ShouldReturnSHA512", "pbkdf2-sha512", VariantSHA512},
 		{"ShouldReturnSHA512ForName", "sha512", VariantSHA512},
+		{"ShouldReturnSHA512_256", "pbkdf2-sha512-256", VariantSHA512_256},
+		{"ShouldReturnSHA512_256ForName", "sha512-256", VariantSHA512_256},
+		{"ShouldReturnSHA3_256", "pbkdf2-sha3-256", VariantSHA3_256},
+		{"ShouldReturnSHA3_256ForName", "sha3-256", VariantSHA3_256},
+		{"ShouldReturnSHA3_512", "pbkdf2-sha3-512", VariantSHA3_512},
+		{"ShouldReturnSHA3_512ForName", "sha3-512", VariantSHA3_512},
+		{"ShouldReturnBLAKE2b_512", "pbkdf2-blake2b-512", VariantBLAKE2b_512},
+		{"ShouldReturnBLAKE2b_512ForName", "blake2b-512", VariantBLAKE2b_512},
 		{"ShouldReturnNoneForUnknown", "unknown", VariantNone},
 	}
 
@@ -45,6 +56,10 @@ func TestVariantString(t *testing.T) {
 		{"ShouldReturnSHA256", VariantSHA256, "sha256"},
 		{"ShouldReturnSHA384", VariantSHA384, "sha384"},
 		{"ShouldReturnSHA512", VariantSHA512, "sha512"},
+		{"ShouldReturnSHA512_256", VariantSHA512_256, "sha512-256"},
+		{"ShouldReturnSHA3_256", VariantSHA3_256, "sha3-256"},
+		{"ShouldReturnSHA3_512", VariantSHA3_512, "sha3-512"},
+		{"ShouldReturnBLAKE2b_512", VariantBLAKE2b_512, "blake2b-512"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -66,6 +81,10 @@ func TestVariantPrefix(t *testing.T) {
 		{"ShouldReturnSHA256Prefix", VariantSHA256, "pbkdf2-sha256"},
 		{"ShouldReturnSHA384Prefix", VariantSHA384, "pbkdf2-sha384"},
 		{"ShouldReturnSHA512Prefix", VariantSHA512, "pbkdf2-sha512"},
+		{"ShouldReturnSHA512_256Prefix", VariantSHA512_256, "pbkdf2-sha512-256"},
+		{"ShouldReturnSHA3_256Prefix", VariantSHA3_256, "pbkdf2-sha3-256"},
+		{"ShouldReturnSHA3_512Prefix", VariantSHA3_512, "pbkdf2-sha3-512"},
+		{"ShouldReturnBLAKE2b_512Prefix", VariantBLAKE2b_512, "pbkdf2-blake2b-512"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -85,6 +104,10 @@ func TestVariantHashFunc(t *testing.T) {
 		{"ShouldReturnSHA1HashFunc", VariantSHA1, false},
 		{"ShouldReturnSHA256HashFunc", VariantSHA256, false},
 		{"ShouldReturnSHA512HashFunc", VariantSHA512, false},
+		{"ShouldReturnSHA512_256HashFunc", VariantSHA512_256, false},
+		{"ShouldReturnSHA3_256HashFunc", VariantSHA3_256, false},
+		{"ShouldReturnSHA3_512HashFunc", VariantSHA3_512, false},
+		{"ShouldReturnBLAKE2b_512HashFunc", VariantBLAKE2b_512, false},
 		{"ShouldReturnNilForNone", VariantNone, true},
 	}
 
@@ -112,6 +135,10 @@ func TestVariantDefaultIterations(t *testing.T) {
 		{"ShouldReturnSHA256Default", VariantSHA256, 310000},
 		{"ShouldReturnSHA384Default", VariantSHA384, 310000},
 		{"ShouldReturnSHA512Default", VariantSHA512, 120000},
+		{"ShouldReturnSHA512_256Default", VariantSHA512_256, 310000},
+		{"ShouldReturnSHA3_256Default", VariantSHA3_256, 310000},
+		{"ShouldReturnSHA3_512Default", VariantSHA3_512, 120000},
+		{"ShouldReturnBLAKE2b_512Default", VariantBLAKE2b_512, 120000},
 	}
 
 	for _, tc := range testCases {
@@ -396,6 +423,38 @@ func TestDigestKeySalt(t *testing.T) {
 	assert.NotEmpty(t, d.Salt())
 }
 
+func TestDigestStoragePrefix(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{"ShouldEncodeWithoutPrefix", "", "$pbkdf2-sha256$100000$c2FsdHNhbHQ$Dq/FSAE9aDFLhneTCYV8JA1eJS7pdgr51O28euBcxUc"},
+		{"ShouldEncodeCRYPT", "{CRYPT}", "{CRYPT}$pbkdf2-sha256$100000$c2FsdHNhbHQ$Dq/FSAE9aDFLhneTCYV8JA1eJS7pdgr51O28euBcxUc"},
+		{"ShouldEncodeLDAPScheme", "{PBKDF2-SHA256}", "{PBKDF2-SHA256}100000$c2FsdHNhbHQ$Dq/FSAE9aDFLhneTCYV8JA1eJS7pdgr51O28euBcxUc"},
+		{"ShouldNotEncodeLDAPSchemeWhenNotLeading", "{X}{PBKDF2}", "{X}{PBKDF2}$pbkdf2-sha256$100000$c2FsdHNhbHQ$Dq/FSAE9aDFLhneTCYV8JA1eJS7pdgr51O28euBcxUc"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := NewSHA256(WithIterations(100000))
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt("password", []byte("saltsalt"))
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			d.WithStoragePrefix(tc.prefix)
+
+			assert.Equal(t, tc.prefix, d.StoragePrefix())
+			assert.Equal(t, tc.expected, d.Encode())
+			assert.True(t, d.Match("password"))
+		})
+	}
+}
+
 func TestDigestMatch(t *testing.T) {
 	hasher, err := NewSHA256(WithIterations(100000))
 	require.NoError(t, err)
@@ -418,3 +477,162 @@ func TestDigestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAdditionalVariants(t *testing.T) {
+	testCases := []struct {
+		name     string
+		new      func(opts ...Opt) (*Hasher, error)
+		expected string
+	}{
+		{"ShouldHashSHA512_256", NewSHA512_256, "$pbkdf2-sha512-256$100000$c2FsdHNhbHRzYWx0c2FsdA$9hiJghtBBDKd1PScX1FcwiOR4lsiaKv3ZXf8l1KQn60"},
+		{"ShouldHashSHA3_256", NewSHA3_256, "$pbkdf2-sha3-256$100000$c2FsdHNhbHRzYWx0c2FsdA$6rD.bQJ1sk.mC0QWGlnPXebYzIDYio9AeXPOaMObAZ0"},
+		{"ShouldHashSHA3_512", NewSHA3_512, "$pbkdf2-sha3-512$100000$c2FsdHNhbHRzYWx0c2FsdA$F4lfhZZjOvd./9wcOP8k7gNn0fgWJIwgCqJJYPynTcauZbQlT0YHRe8axZkqVAXaat1aqqBzdYBCxT/ChLhZ2w"},
+		{"ShouldHashBLAKE2b_512", NewBLAKE2b_512, "$pbkdf2-blake2b-512$100000$c2FsdHNhbHRzYWx0c2FsdA$IhrWV5YRbRkKlBR85IGwdNkcA.NpuWcOlXODqak2WWZL6Sq4DgdD1swa/UO2Zt4abtl2QjkZRL8zaVJva2IS9g"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := tc.new(WithIterations(100000))
+			require.NoError(t, err)
+
+			d, err := h.HashWithSalt("password", []byte("saltsaltsaltsalt"))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, d.Encode())
+
+			decoded, err := Decode(tc.expected)
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match("password"))
+			assert.False(t, decoded.Match("wrong"))
+			assert.Equal(t, tc.expected, decoded.Encode())
+		})
+	}
+}
+
+func TestRegisterVariant(t *testing.T) {
+	variant, err := RegisterVariant("test-sha256", sha256.New, 0)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		customVariants.unregister(variant)
+	})
+
+	assert.Equal(t, "test-sha256", variant.String())
+	assert.Equal(t, "pbkdf2-test-sha256", variant.Prefix())
+	assert.Equal(t, IterationsDefaultSHA256, variant.DefaultIterations())
+	assert.NotNil(t, variant.HashFunc())
+	assert.Equal(t, variant, NewVariant("pbkdf2-test-sha256"))
+	assert.Equal(t, variant, NewVariant("test-sha256"))
+
+	h, err := New(WithVariant(variant), WithIterations(100000))
+	require.NoError(t, err)
+
+	d, err := h.HashWithSalt("password", []byte("saltsaltsaltsalt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "$pbkdf2-test-sha256$100000$c2FsdHNhbHRzYWx0c2FsdA$T78tEi/mr8Yageny/jk6s5.Qanjd3ceXdjwOeEhX6bQ", d.Encode())
+
+	r := &testDecoderRegister{decoders: map[string]func(string) (algorithm.Digest, error){}}
+
+	require.NoError(t, RegisterDecoderVariant(r, variant))
+	require.Contains(t, r.decoders, "pbkdf2-test-sha256")
+
+	decoded, err := r.decoders["pbkdf2-test-sha256"](d.Encode())
+	require.NoError(t, err)
+
+	assert.True(t, decoded.Match("password"))
+
+	_, err = r.decoders["pbkdf2-test-sha256"]("$pbkdf2-sha256$100000$c2FsdHNhbHRzYWx0c2FsdA$T78tEi/mr8Yageny/jk6s5.Qanjd3ceXdjwOeEhX6bQ")
+	assert.EqualError(t, err, "pbkdf2 decode error: the 'sha256' variant cannot be decoded only the 'test-sha256' variant can be")
+
+	testCases := []struct {
+		name       string
+		have       string
+		hashFunc   algorithm.HashFunc
+		iterations int
+		err        string
+	}{
+		{"ShouldErrEmptyName", "", sha256.New, 0, "parameter is invalid: variant name '' is invalid"},
+		{"ShouldErrInvalidName", "te$t", sha256.New, 0, "parameter is invalid: variant name 'te$t' is invalid"},
+		{"ShouldErrUpperCaseName", "Test", sha256.New, 0, "parameter is invalid: variant name 'Test' is invalid"},
+		{"ShouldErrLongName", "test-abcdefghijklmnopqrstuvwxyz01", sha256.New, 0, "parameter is invalid: variant name 'test-abcdefghijklmnopqrstuvwxyz01' is invalid"},
+		{"ShouldErrNilHashFunc", "test-nil", nil, 0, "parameter is invalid: variant 'test-nil' must have a hash func"},
+		{"ShouldErrIterations", "test-iterations", sha256.New, 10, "parameter is invalid: parameter 'iterations' must be between 100000 and 2147483647 but is set to '10'"},
+		{"ShouldErrDuplicate", "test-sha256", sha256.New, 0, "parameter is invalid: variant 'test-sha256' is already registered"},
+		{"ShouldErrBuiltin", "sha3-256", sha256.New, 0, "parameter is invalid: variant 'sha3-256' is already registered"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := RegisterVariant(tc.have, tc.hashFunc, tc.iterations)
+
+			assert.Equal(t, VariantNone, v)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	assert.EqualError(t, RegisterDecoderVariant(r, Variant(99)), "parameter is invalid: variant '99' is invalid")
+}
+
+func TestUnregisterVariant(t *testing.T) {
+	variant, err := RegisterVariant("test-unregister", sha256.New, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, variant, NewVariant("pbkdf2-test-unregister"))
+
+	customVariants.unregister(variant)
+
+	assert.Equal(t, VariantNone, NewVariant("pbkdf2-test-unregister"))
+	assert.Nil(t, variant.HashFunc())
+
+	again, err := RegisterVariant("test-unregister", sha256.New, 0)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		customVariants.unregister(again)
+	})
+
+	assert.NotEqual(t, variant, again)
+	assert.Equal(t, again, NewVariant("test-unregister"))
+}
+
+type testDecoderRegister struct {
+	decoders map[string]func(string) (algorithm.Digest, error)
+}
+
+func (r *testDecoderRegister) RegisterDecodeFunc(prefix string, decoder algorithm.DecodeFunc) (err error) {
+	r.decoders[prefix] = decoder
+
+	return nil
+}
+
+func (r *testDecoderRegister) RegisterDecodePrefix(prefix, identifier string) (err error) {
+	return nil
+}
+
+func (r *testDecoderRegister) Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return Decode(encodedDigest)
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("$pbkdf2-sha256$100000$c2FsdHNhbHRzYWx0c2FsdA$T78tEi/mr8Yageny/jk6s5.Qanjd3ceXdjwOeEhX6bQ")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantSHA256, 100000, decoded.Salt(), decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(VariantNone, 100000, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "pbkdf2 decode error: parameter is invalid: variant must be specified")
+
+	digest, err = NewDigest(VariantSHA256, 0, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "pbkdf2 decode error: parameter is invalid: parameter 'iterations' must be between 1 and 2147483647 but is set to '0'")
+
+	digest, err = NewDigest(VariantSHA256, 100000, decoded.Salt(), nil)
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "pbkdf2 decode error: parameter is invalid: key has 0 bytes")
+}
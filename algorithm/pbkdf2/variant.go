@@ -3,7 +3,13 @@ package pbkdf2
 import (
 	"crypto/sha1" //nolint:gosec
 	"crypto/sha256"
+	"crypto/sha3"
 	"crypto/sha512"
+	"fmt"
+	"hash"
+	"sync"
+
+	"github.com/go-crypt/x/blake2b"
 
 	"github.com/go-crypt/crypt/algorithm"
 )
@@ -21,8 +27,16 @@ func NewVariant(identifier string) (variant Variant) {
 		return VariantSHA384
 	case AlgIdentifierSHA512, algorithm.DigestSHA512:
 		return VariantSHA512
+	case AlgIdentifierSHA512_256, algorithm.DigestSHA512_256:
+		return VariantSHA512_256
+	case AlgIdentifierSHA3_256, algorithm.DigestSHA3_256:
+		return VariantSHA3_256
+	case AlgIdentifierSHA3_512, algorithm.DigestSHA3_512:
+		return VariantSHA3_512
+	case AlgIdentifierBLAKE2b_512, algorithm.DigestBLAKE2b_512:
+		return VariantBLAKE2b_512
 	default:
-		return VariantNone
+		return customVariants.lookup(identifier)
 	}
 }
 
@@ -47,6 +61,21 @@ const (
 
 	// VariantSHA512 is a variant of the pbkdf2.Digest which uses HMAC-SHA-512.
 	VariantSHA512
+
+	// VariantSHA512_256 is a variant of the pbkdf2.Digest which uses HMAC-SHA-512/256.
+	VariantSHA512_256
+
+	// VariantSHA3_256 is a variant of the pbkdf2.Digest which uses HMAC-SHA3-256.
+	VariantSHA3_256
+
+	// VariantSHA3_512 is a variant of the pbkdf2.Digest which uses HMAC-SHA3-512.
+	VariantSHA3_512
+
+	// VariantBLAKE2b_512 is a variant of the pbkdf2.Digest which uses HMAC-BLAKE2b-512.
+	VariantBLAKE2b_512
+
+	// variantCustom is the first pbkdf2.Variant value used by variants registered with pbkdf2.RegisterVariant.
+	variantCustom
 )
 
 // String implements the fmt.Stringer returning a string representation of the pbkdf2.Variant.
@@ -62,7 +91,19 @@ func (v Variant) String() (variant string) {
 		return algorithm.DigestSHA384
 	case VariantSHA512:
 		return algorithm.DigestSHA512
+	case VariantSHA512_256:
+		return algorithm.DigestSHA512_256
+	case VariantSHA3_256:
+		return algorithm.DigestSHA3_256
+	case VariantSHA3_512:
+		return algorithm.DigestSHA3_512
+	case VariantBLAKE2b_512:
+		return algorithm.DigestBLAKE2b_512
 	default:
+		if c, ok := customVariants.get(v); ok {
+			return c.name
+		}
+
 		return
 	}
 }
@@ -80,7 +121,19 @@ func (v Variant) Prefix() (prefix string) {
 		return AlgIdentifierSHA384
 	case VariantSHA512:
 		return AlgIdentifierSHA512
+	case VariantSHA512_256:
+		return AlgIdentifierSHA512_256
+	case VariantSHA3_256:
+		return AlgIdentifierSHA3_256
+	case VariantSHA3_512:
+		return AlgIdentifierSHA3_512
+	case VariantBLAKE2b_512:
+		return AlgIdentifierBLAKE2b_512
 	default:
+		if c, ok := customVariants.get(v); ok {
+			return c.identifier
+		}
+
 		return
 	}
 }
@@ -98,7 +151,19 @@ func (v Variant) HashFunc() algorithm.HashFunc {
 		return sha512.New384
 	case VariantSHA512:
 		return sha512.New
+	case VariantSHA512_256:
+		return sha512.New512_256
+	case VariantSHA3_256:
+		return newSHA3x256
+	case VariantSHA3_512:
+		return newSHA3x512
+	case VariantBLAKE2b_512:
+		return newBLAKE2b512
 	default:
+		if c, ok := customVariants.get(v); ok {
+			return c.hashFunc
+		}
+
 		return nil
 	}
 }
@@ -108,11 +173,171 @@ func (v Variant) DefaultIterations() int {
 	switch v {
 	case VariantSHA1, VariantSHA224:
 		return IterationsDefaultSHA1
-	case VariantSHA256, VariantSHA384:
+	case VariantSHA256, VariantSHA384, VariantSHA512_256, VariantSHA3_256:
 		return IterationsDefaultSHA256
-	case VariantSHA512:
+	case VariantSHA512, VariantSHA3_512, VariantBLAKE2b_512:
 		return IterationsDefaultSHA512
 	default:
+		if c, ok := customVariants.get(v); ok {
+			return c.iterations
+		}
+
 		return IterationsDefaultSHA1
 	}
 }
+
+// valid returns true if the pbkdf2.Variant is either a known variant or a registered custom variant.
+func (v Variant) valid() bool {
+	if v > VariantNone && v < variantCustom {
+		return true
+	}
+
+	_, ok := customVariants.get(v)
+
+	return ok
+}
+
+// RegisterVariant registers a custom pbkdf2.Variant which uses HMAC with the provided algorithm.HashFunc as the
+// pseudorandom function. The name is used as the string representation of the variant and the identifier used in
+// encoded digests is the name prefixed with 'pbkdf2-'. The name must be between 1 and 32 characters from the set
+// [a-z0-9-]. If the iterations are 0 the pbkdf2.IterationsDefaultSHA256
+// value is used as the default iterations for the variant.
+//
+// The returned pbkdf2.Variant can be used with pbkdf2.WithVariant and its decoder can be registered with
+// pbkdf2.RegisterDecoderVariant. Custom variants are registered globally and should be registered during
+// initialization.
+func RegisterVariant(name string, hashFunc algorithm.HashFunc, iterations int) (variant Variant, err error) {
+	if !validVariantName(name) {
+		return VariantNone, fmt.Errorf("%w: variant name '%s' is invalid", algorithm.ErrParameterInvalid, name)
+	}
+
+	if hashFunc == nil {
+		return VariantNone, fmt.Errorf("%w: variant '%s' must have a hash func", algorithm.ErrParameterInvalid, name)
+	}
+
+	switch {
+	case iterations == 0:
+		iterations = IterationsDefaultSHA256
+	case iterations < IterationsMin || iterations > IterationsMax:
+		return VariantNone, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations)
+	}
+
+	identifier := AlgName + "-" + name
+
+	if NewVariant(name) != VariantNone || NewVariant(identifier) != VariantNone {
+		return VariantNone, fmt.Errorf("%w: variant '%s' is already registered", algorithm.ErrParameterInvalid, name)
+	}
+
+	if variant = customVariants.register(customVariant{name: name, identifier: identifier, hashFunc: hashFunc, iterations: iterations}); variant == VariantNone {
+		return VariantNone, fmt.Errorf("%w: variant '%s' is already registered", algorithm.ErrParameterInvalid, name)
+	}
+
+	return variant, nil
+}
+
+// validVariantName returns true if the name is between 1 and 32 characters from the set [a-z0-9-].
+func validVariantName(name string) bool {
+	if len(name) == 0 || len(name) > 32 {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-':
+			continue
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+type customVariant struct {
+	name, identifier string
+	hashFunc         algorithm.HashFunc
+	iterations       int
+}
+
+type customVariantRegistry struct {
+	mu       sync.RWMutex
+	variants []customVariant
+}
+
+var customVariants = &customVariantRegistry{}
+
+// register adds the custom variant to the registry returning pbkdf2.VariantNone if the name or identifier is already
+// registered. The existence check and insert are performed while holding the lock.
+func (r *customVariantRegistry) register(c customVariant) (variant Variant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index(c.name) != -1 || r.index(c.identifier) != -1 {
+		return VariantNone
+	}
+
+	r.variants = append(r.variants, c)
+
+	return variantCustom + Variant(len(r.variants)-1)
+}
+
+func (r *customVariantRegistry) get(v Variant) (c customVariant, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := int(v - variantCustom)
+
+	if i < 0 || i >= len(r.variants) || r.variants[i].hashFunc == nil {
+		return c, false
+	}
+
+	return r.variants[i], true
+}
+
+func (r *customVariantRegistry) lookup(identifier string) (variant Variant) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i := r.index(identifier); i != -1 {
+		return variantCustom + Variant(i)
+	}
+
+	return VariantNone
+}
+
+// unregister removes the custom variant from the registry. The slot is cleared rather than removed so the values of
+// the other registered variants remain stable.
+func (r *customVariantRegistry) unregister(v Variant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := int(v - variantCustom); i >= 0 && i < len(r.variants) {
+		r.variants[i] = customVariant{}
+	}
+}
+
+// index returns the index of the registered custom variant with the name or identifier or -1. The caller must hold
+// the lock.
+func (r *customVariantRegistry) index(identifier string) int {
+	for i, c := range r.variants {
+		if c.hashFunc != nil && (c.identifier == identifier || c.name == identifier) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func newSHA3x256() hash.Hash {
+	return sha3.New256()
+}
+
+func newSHA3x512() hash.Hash {
+	return sha3.New512()
+}
+
+func newBLAKE2b512() hash.Hash {
+	h, _ := blake2b.New512(nil)
+
+	return h
+}
@@ -0,0 +1,16 @@
+package pgmd5
+
+const (
+	// EncodingFmt is the encoding format for this algorithm.
+	EncodingFmt = "%s%x"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "pgmd5"
+
+	// AlgIdentifier is the identifier used in this algorithm.
+	AlgIdentifier = "md5"
+)
+
+const (
+	keyLength = 16
+)
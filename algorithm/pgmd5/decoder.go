@@ -0,0 +1,77 @@
+package pgmd5
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which begin with md5 followed by 32 hex characters.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgName, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgName, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest begins with md5 followed by 32 hex characters.
+func MatchEncoded(encodedDigest string) (match bool) {
+	if len(encodedDigest) != len(AlgIdentifier)+hex.EncodedLen(keyLength) || !strings.HasPrefix(encodedDigest, AlgIdentifier) {
+		return false
+	}
+
+	_, err := hex.DecodeString(encodedDigest[len(AlgIdentifier):])
+
+	return err == nil
+}
+
+// Decode the encoded digest into a algorithm.Digest. The username is not part of the encoded digest so it must be
+// provided when matching via the algorithm.ContextualMatcher implementation or via DecodeWithUsername.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeWithUsername(encodedDigest, "")
+}
+
+// DecodeWithUsername the encoded digest into a algorithm.Digest which is matched using the provided username.
+func DecodeWithUsername(encodedDigest, username string) (digest algorithm.Digest, err error) {
+	if digest, err = decode(encodedDigest, username); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+// NewDigest returns a pgmd5.Digest from the raw key and the username the key was derived with which is useful when the
+// digest is not stored in the encoded format.
+func NewDigest(username string, key []byte) (digest *Digest, err error) {
+	if len(key) != keyLength {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(key), keyLength))
+	}
+
+	return &Digest{username: username, key: key}, nil
+}
+
+func decode(encodedDigest, username string) (digest algorithm.Digest, err error) {
+	if !strings.HasPrefix(encodedDigest, AlgIdentifier) {
+		return nil, fmt.Errorf("%w: the digest doesn't begin with the '%s' identifier", algorithm.ErrEncodedHashInvalidIdentifier, AlgIdentifier)
+	}
+
+	var key []byte
+
+	if key, err = hex.DecodeString(encodedDigest[len(AlgIdentifier):]); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(key) != keyLength {
+		return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(key), keyLength)
+	}
+
+	return &Digest{username: username, key: key}, nil
+}
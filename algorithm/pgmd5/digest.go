@@ -0,0 +1,121 @@
+package pgmd5
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a pgmd5.Digest which handles the PostgreSQL md5 password format.
+type Digest struct {
+	username string
+
+	key []byte
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current pgmd5.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current pgmd5.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well. The username the
+// pgmd5.Digest was decoded or hashed with is used, if there is no username an error is returned.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	return d.matchContext(passwordBytes, algorithm.MatchContext{})
+}
+
+// MatchWithContext returns true if the string password matches the current pgmd5.Digest using the username from the
+// algorithm.MatchContext.
+func (d *Digest) MatchWithContext(password string, ctx algorithm.MatchContext) (match bool) {
+	match, _ = d.MatchAdvancedWithContext(password, ctx)
+
+	return match
+}
+
+// MatchAdvancedWithContext is the same as MatchWithContext except if there is an error it returns that as well.
+func (d *Digest) MatchAdvancedWithContext(password string, ctx algorithm.MatchContext) (match bool, err error) {
+	return d.matchContext([]byte(password), ctx)
+}
+
+func (d *Digest) matchContext(passwordBytes []byte, ctx algorithm.MatchContext) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	username := d.username
+
+	if ctx.Username != "" {
+		username = ctx.Username
+	}
+
+	if username == "" {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: username", algorithm.ErrMatchContextMissing))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, derive(passwordBytes, username)) == 1, nil
+}
+
+// Encode returns the encoded form of this pgmd5.Digest. The username is not part of the encoded form.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this pgmd5.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	return fmt.Sprintf(EncodingFmt, AlgIdentifier, d.key)
+}
+
+// String returns the storable format of the pgmd5.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw pgmd5.Digest key which has been derived.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns nil as the PostgreSQL md5 password format uses the username instead of a salt.
+func (d *Digest) Salt() (salt []byte) {
+	return nil
+}
+
+// Username returns the username this pgmd5.Digest was decoded or hashed with if any.
+func (d *Digest) Username() (username string) {
+	return d.username
+}
+
+// Canonical returns a copy of this pgmd5.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+// derive the key which is the MD5 digest of the password followed by the username.
+func derive(passwordBytes []byte, username string) (key []byte) {
+	h := md5.New() //nolint:gosec
+
+	h.Write(passwordBytes)
+	h.Write([]byte(username))
+
+	return h.Sum(nil)
+}
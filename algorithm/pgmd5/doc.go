@@ -0,0 +1,9 @@
+// Package pgmd5 provides helpful abstractions for an implementation of the PostgreSQL md5 password format and
+// implements github.com/go-crypt/crypt interfaces.
+//
+// The key is the MD5 digest of the password followed by the username, as such the username must be provided to match
+// a password either via the algorithm.ContextualMatcher implementation or by hashing the password with the username.
+//
+// This implementation is cryptographically broken, is not loaded by any of the crypt decoders, and must be registered
+// explicitly.
+package pgmd5
@@ -0,0 +1,23 @@
+package pgmd5
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the pgmd5.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithUsername sets the username of the resulting pgmd5.Digest which is mixed into the key. This option is required.
+func WithUsername(username string) Opt {
+	return func(h *Hasher) (err error) {
+		if username == "" {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: username must not be empty", algorithm.ErrParameterInvalid))
+		}
+
+		h.username = username
+
+		return nil
+	}
+}
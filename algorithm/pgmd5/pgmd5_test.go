@@ -0,0 +1,144 @@
+package pgmd5
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+func TestDecode(t *testing.T) {
+	digest, err := Decode("md532e12f215ba27cb750c9e093ce4b5127")
+	require.NoError(t, err)
+
+	d, ok := digest.(*Digest)
+	require.True(t, ok)
+
+	assert.Equal(t, "md532e12f215ba27cb750c9e093ce4b5127", d.Encode())
+	assert.Equal(t, "md532e12f215ba27cb750c9e093ce4b5127", d.String())
+	assert.Equal(t, "", d.Username())
+	assert.Nil(t, d.Salt())
+	assert.Len(t, d.Key(), 16)
+	assert.Equal(t, d.Encode(), d.Canonical().Encode())
+
+	assert.False(t, d.Match("password"))
+
+	match, err := d.MatchAdvanced("password")
+	assert.False(t, match)
+	assert.EqualError(t, err, "pgmd5 match error: match context is missing a required value: username")
+
+	assert.True(t, d.MatchWithContext("password", algorithm.MatchContext{Username: "postgres"}))
+	assert.False(t, d.MatchWithContext("wrong", algorithm.MatchContext{Username: "postgres"}))
+	assert.False(t, d.MatchWithContext("password", algorithm.MatchContext{Username: "john"}))
+
+	digest, err = DecodeWithUsername("md532e12f215ba27cb750c9e093ce4b5127", "postgres")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match("password"))
+	assert.False(t, digest.Match("wrong"))
+
+	d, ok = digest.(*Digest)
+	require.True(t, ok)
+
+	assert.Equal(t, "postgres", d.Username())
+	assert.False(t, d.MatchWithContext("password", algorithm.MatchContext{Username: "john"}))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrIdentifier", "sha32e12f215ba27cb750c9e093ce4b5127", "pgmd5 decode error: provided encoded hash has an invalid identifier: the digest doesn't begin with the 'md5' identifier"},
+		{"ShouldErrKeyEncoding", "md532e12f215ba27cb750c9e093ce4b51zz", "pgmd5 decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: invalid byte: U+007A 'z'"},
+		{"ShouldErrKeyLength", "md532e12f215ba27cb75", "pgmd5 decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: odd length hex string"},
+		{"ShouldErrKeyBytes", "md532e12f215ba27cb750", "pgmd5 decode error: provided encoded hash has a key value that can't be decoded: key has 9 bytes but must have 16 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestHasher(t *testing.T) {
+	hasher, err := New(WithUsername("postgres"))
+	require.NoError(t, err)
+
+	digest, err := hasher.Hash("password")
+	require.NoError(t, err)
+
+	assert.Equal(t, "md532e12f215ba27cb750c9e093ce4b5127", digest.Encode())
+	assert.True(t, digest.Match("password"))
+	assert.False(t, digest.Match("wrong"))
+
+	digest, err = hasher.HashWithSalt("password", []byte("ignored"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "md532e12f215ba27cb750c9e093ce4b5127", digest.Encode())
+	assert.True(t, hasher.MustHash("password").Match("password"))
+}
+
+func TestHasherErrors(t *testing.T) {
+	hasher, err := New()
+
+	assert.Nil(t, hasher)
+	assert.EqualError(t, err, "pgmd5 validation error: parameter is invalid: username must not be empty")
+
+	hasher, err = New(WithUsername(""))
+
+	assert.Nil(t, hasher)
+	assert.EqualError(t, err, "pgmd5 validation error: parameter is invalid: username must not be empty")
+
+	digest, err := (&Hasher{}).Hash("password")
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "pgmd5 validation error: parameter is invalid: username must not be empty")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode("md532e12f215ba27cb750c9e093ce4b5127")
+	require.NoError(t, err)
+
+	assert.IsType(t, &Digest{}, digest)
+
+	match, err := crypt.MatchWithContext(digest, "password", algorithm.MatchContext{Username: "postgres"})
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	assert.False(t, MatchEncoded("md532e12f215ba27cb750c9e093ce4b51zz"))
+	assert.False(t, MatchEncoded("md532e12f215ba27cb750"))
+	assert.False(t, MatchEncoded("$md5$rounds=1000$salt$$key"))
+
+	var (
+		_ algorithm.CanonicalDigest   = &Digest{}
+		_ algorithm.ContextualMatcher = &Digest{}
+	)
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := DecodeWithUsername("md532e12f215ba27cb750c9e093ce4b5127", "postgres")
+	require.NoError(t, err)
+
+	digest, err := NewDigest("postgres", decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+	assert.Equal(t, decoded.Match("password"), digest.Match("password"))
+
+	digest, err = NewDigest("postgres", nil)
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "pgmd5 decode error: parameter is invalid: key has 0 bytes but must have 16 bytes")
+}
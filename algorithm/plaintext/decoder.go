@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-crypt/crypt/algorithm"
-	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -69,7 +69,7 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 }
 
 func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, 3)
+	parts = phc.Split(encodedDigest, 3)
 
 	if len(parts) != 3 {
 		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
@@ -32,6 +32,8 @@ type Digest struct {
 	variant Variant
 
 	key []byte
+
+	algorithm.Prefix
 }
 
 // Match returns true if the string password matches the current plaintext.Digest.
@@ -66,6 +68,11 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode returns the encoded form of this plaintext.Digest.
 func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this plaintext.Digest without the storage prefix.
+func (d *Digest) encode() string {
 	return fmt.Sprintf(EncodingFmt, d.variant.Prefix(), d.variant.Encode(d.key))
 }
 
@@ -84,6 +91,15 @@ func (d *Digest) Salt() (salt []byte) {
 	return nil
 }
 
+// Canonical returns a copy of this plaintext.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
 func (d *Digest) defaults() {
 	switch d.variant {
 	case VariantPlainText, VariantBase64:
@@ -0,0 +1,17 @@
+package algorithm
+
+// Prefix is embedded in the digests to retain the storage prefix such as the OpenLDAP {CRYPT} prefix the digest was
+// decoded with. It implements the StoragePrefix and WithStoragePrefix methods of the StoragePrefixDigest interface.
+type Prefix struct {
+	prefix string
+}
+
+// StoragePrefix returns the storage prefix the digest was decoded with if any.
+func (p *Prefix) StoragePrefix() (prefix string) {
+	return p.prefix
+}
+
+// WithStoragePrefix sets the storage prefix the digest is encoded with.
+func (p *Prefix) WithStoragePrefix(prefix string) {
+	p.prefix = prefix
+}
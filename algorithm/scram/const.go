@@ -0,0 +1,47 @@
+package scram
+
+import (
+	"math"
+)
+
+const (
+	// EncodingFmt is the format of the encoded digest which is the PostgreSQL SCRAM secret format.
+	EncodingFmt = "%s$%d:%s$%s:%s"
+
+	// EncodingFmtMongoDB is the format of the encoded digest of a MongoDB SCRAM-SHA-1 credential which is the
+	// PostgreSQL SCRAM secret format followed by the base64 encoded username.
+	EncodingFmtMongoDB = "%s$%d:%s$%s:%s$%s"
+
+	// AlgName is the name for this algorithm.
+	AlgName = "scram"
+
+	// AlgIdentifierSHA1 is the identifier used in encoded SHA-1 variants of this algorithm.
+	AlgIdentifierSHA1 = "SCRAM-SHA-1"
+
+	// AlgIdentifierSHA256 is the identifier used in encoded SHA-256 variants of this algorithm.
+	AlgIdentifierSHA256 = "SCRAM-SHA-256"
+
+	// SaltLengthMin is the minimum salt length accepted.
+	SaltLengthMin = 8
+
+	// SaltLengthMax is the maximum salt length accepted.
+	SaltLengthMax = 1024
+
+	// IterationsMin is the minimum number of iterations accepted.
+	IterationsMin = 4096
+
+	// IterationsMax is the maximum number of iterations accepted.
+	IterationsMax = math.MaxInt32
+
+	// IterationsDefault is the default number of iterations which is the PostgreSQL default.
+	IterationsDefault = 4096
+)
+
+const (
+	variantDefault = VariantSHA256
+
+	keyClient = "Client Key"
+	keyServer = "Server Key"
+
+	mongoDBSeparator = ":mongo:"
+)
@@ -0,0 +1,181 @@
+package scram
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/encoding"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which begin with the SCRAM-SHA-1 or SCRAM-SHA-256 identifier followed by the delimiter.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgName, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgName, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest begins with the SCRAM-SHA-1 or SCRAM-SHA-256 identifier followed by
+// the delimiter.
+func MatchEncoded(encodedDigest string) (match bool) {
+	identifier, _, found := strings.Cut(encodedDigest, encoding.DelimiterStr)
+
+	return found && (identifier == AlgIdentifierSHA1 || identifier == AlgIdentifierSHA256)
+}
+
+// Decode the encoded digest into a algorithm.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	return DecodeVariant(VariantNone)(encodedDigest)
+}
+
+// DecodeVariant the encoded digest into a algorithm.Digest provided it matches the provided scram.Variant. If
+// scram.VariantNone is used all variants can be decoded.
+func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Digest, err error) {
+	return func(encodedDigest string) (digest algorithm.Digest, err error) {
+		var (
+			parts   []string
+			variant Variant
+		)
+
+		if variant, parts, err = decoderParts(encodedDigest); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		if v != VariantNone && v != variant {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
+		}
+
+		if digest, err = decode(variant, parts); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+}
+
+// NewDigest returns a scram.Digest from the raw iterations, salt, StoredKey, and ServerKey which is useful when the
+// values of a digest are not stored in the encoded format.
+func NewDigest(variant Variant, iterations int, salt, storedKey, serverKey []byte) (digest *Digest, err error) {
+	if variant != VariantSHA1 && variant != VariantSHA256 {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantSHA1, VariantSHA256))
+	}
+
+	size := variant.HashFunc()().Size()
+
+	switch {
+	case iterations < 1:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: iterations must be greater than 0 but is '%d'", algorithm.ErrParameterInvalid, iterations))
+	case len(salt) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt has 0 bytes", algorithm.ErrParameterInvalid))
+	case len(storedKey) != size:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: stored key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(storedKey), size))
+	case len(serverKey) != size:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: server key has %d bytes but must have %d bytes", algorithm.ErrParameterInvalid, len(serverKey), size))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, salt: salt, storedKey: storedKey, serverKey: serverKey}, nil
+}
+
+func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
+	parts = strings.Split(encodedDigest, encoding.DelimiterStr)
+
+	if len(parts) != 3 && len(parts) != 4 {
+		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	if parts[0] != AlgIdentifierSHA1 && parts[0] != AlgIdentifierSHA256 {
+		return VariantNone, nil, fmt.Errorf("%w: identifier '%s' is not an encoded %s digest", algorithm.ErrEncodedHashInvalidIdentifier, parts[0], AlgName)
+	}
+
+	if len(parts) == 4 && parts[0] != AlgIdentifierSHA1 {
+		return VariantNone, nil, fmt.Errorf("%w: the username is only supported by the SCRAM-SHA-1 mechanism", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	return NewVariant(parts[0]), parts[1:], nil
+}
+
+func decode(variant Variant, parts []string) (digest algorithm.Digest, err error) {
+	iterations, salt, found := strings.Cut(parts[0], ":")
+
+	if !found {
+		return nil, fmt.Errorf("%w: the iterations and salt are not separated by a colon", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	storedKey, serverKey, found := strings.Cut(parts[1], ":")
+
+	if !found {
+		return nil, fmt.Errorf("%w: the stored key and server key are not separated by a colon", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	decoded := &Digest{
+		variant: variant,
+	}
+
+	if decoded.iterations, err = strconv.Atoi(iterations); err != nil {
+		return nil, fmt.Errorf("%w: iterations could not be parsed: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+	}
+
+	if decoded.salt, decoded.storedKey, decoded.serverKey, err = decodeValues(variant, decoded.iterations, salt, storedKey, serverKey); err != nil {
+		return nil, err
+	}
+
+	if len(parts) == 3 {
+		var username []byte
+
+		if username, err = base64.StdEncoding.DecodeString(parts[2]); err != nil {
+			return nil, fmt.Errorf("%w: username could not be decoded: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+		}
+
+		if len(username) == 0 {
+			return nil, fmt.Errorf("%w: the username is required for the SCRAM-SHA-1 mechanism", algorithm.ErrEncodedHashInvalidOptionValue)
+		}
+
+		decoded.username = string(username)
+	}
+
+	return decoded, nil
+}
+
+// decodeValues decodes and validates the base64 encoded salt, StoredKey, and ServerKey values.
+func decodeValues(variant Variant, iterations int, salt, storedKey, serverKey string) (rawSalt, rawStoredKey, rawServerKey []byte, err error) {
+	if iterations < 1 {
+		return nil, nil, nil, fmt.Errorf("%w: iterations must be greater than 0 but is '%d'", algorithm.ErrEncodedHashInvalidOptionValue, iterations)
+	}
+
+	if rawSalt, err = base64.StdEncoding.DecodeString(salt); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashSaltEncoding, err)
+	}
+
+	if len(rawSalt) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: salt has 0 bytes", algorithm.ErrEncodedHashSaltEncoding)
+	}
+
+	size := variant.HashFunc()().Size()
+
+	if rawStoredKey, err = base64.StdEncoding.DecodeString(storedKey); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: stored key: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(rawStoredKey) != size {
+		return nil, nil, nil, fmt.Errorf("%w: stored key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(rawStoredKey), size)
+	}
+
+	if rawServerKey, err = base64.StdEncoding.DecodeString(serverKey); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: server key: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(rawServerKey) != size {
+		return nil, nil, nil, fmt.Errorf("%w: server key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(rawServerKey), size)
+	}
+
+	return rawSalt, rawStoredKey, rawServerKey, nil
+}
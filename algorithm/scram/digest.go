@@ -0,0 +1,199 @@
+package scram
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-crypt/x/pbkdf2"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is a scram.Digest which handles the SCRAM StoredKey and ServerKey verifiers.
+type Digest struct {
+	variant Variant
+
+	iterations int
+
+	salt, storedKey, serverKey []byte
+
+	// username is only used by the scram.VariantSHA1 variant when the scram.Digest is a MongoDB credential.
+	username string
+
+	algorithm.Prefix
+}
+
+// Match returns true if the string password matches the current scram.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current scram.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well. The username of a
+// MongoDB SCRAM-SHA-1 credential is the one the scram.Digest was decoded or hashed with.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	return d.matchContext(passwordBytes, algorithm.MatchContext{})
+}
+
+// MatchWithContext returns true if the string password matches the current scram.Digest. The username from the
+// algorithm.MatchContext takes precedence if this scram.Digest is a MongoDB SCRAM-SHA-1 credential and is ignored
+// otherwise, as the PostgreSQL SCRAM secrets and the MongoDB SCRAM-SHA-256 credentials don't derive the key from the
+// username. This is an exception to the precedence described by algorithm.ContextualMatcher.
+func (d *Digest) MatchWithContext(password string, ctx algorithm.MatchContext) (match bool) {
+	match, _ = d.MatchAdvancedWithContext(password, ctx)
+
+	return match
+}
+
+// MatchAdvancedWithContext is the same as MatchWithContext except if there is an error it returns that as well.
+func (d *Digest) MatchAdvancedWithContext(password string, ctx algorithm.MatchContext) (match bool, err error) {
+	return d.matchContext([]byte(password), ctx)
+}
+
+func (d *Digest) matchContext(passwordBytes []byte, ctx algorithm.MatchContext) (match bool, err error) {
+	if len(d.storedKey) == 0 || len(d.serverKey) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	username := d.username
+
+	if username != "" && ctx.Username != "" {
+		username = ctx.Username
+	}
+
+	storedKey, serverKey := d.derive(passwordBytes, username)
+
+	return subtle.ConstantTimeCompare(d.storedKey, storedKey)&subtle.ConstantTimeCompare(d.serverKey, serverKey) == 1, nil
+}
+
+// Encode returns the encoded form of this scram.Digest which is the PostgreSQL SCRAM secret format. A MongoDB
+// SCRAM-SHA-1 credential can't be represented in this format as the key is derived from the username, as such the
+// base64 encoded username is appended as an additional segment which PostgreSQL can't use but this package can decode.
+func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this scram.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	if d.username != "" {
+		return fmt.Sprintf(EncodingFmtMongoDB, d.variant.Prefix(), d.iterations,
+			base64.StdEncoding.EncodeToString(d.salt),
+			base64.StdEncoding.EncodeToString(d.storedKey),
+			base64.StdEncoding.EncodeToString(d.serverKey),
+			base64.StdEncoding.EncodeToString([]byte(d.username)),
+		)
+	}
+
+	return fmt.Sprintf(EncodingFmt, d.variant.Prefix(), d.iterations,
+		base64.StdEncoding.EncodeToString(d.salt),
+		base64.StdEncoding.EncodeToString(d.storedKey),
+		base64.StdEncoding.EncodeToString(d.serverKey),
+	)
+}
+
+// String returns the storable format of the scram.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the StoredKey of this digest.
+func (d *Digest) Key() (key []byte) {
+	return d.storedKey
+}
+
+// ServerKey returns the ServerKey of this digest.
+func (d *Digest) ServerKey() (key []byte) {
+	return d.serverKey
+}
+
+// Salt returns the salt used to generate this digest.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Iterations returns the iteration count used to generate this digest.
+func (d *Digest) Iterations() (iterations int) {
+	return d.iterations
+}
+
+// Username returns the username of this scram.Digest if it's a MongoDB SCRAM-SHA-1 credential.
+func (d *Digest) Username() (username string) {
+	return d.username
+}
+
+// Variant returns the scram.Variant of this scram.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Canonical returns a copy of this scram.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+// derive the StoredKey and ServerKey for this scram.Digest using the password bytes and the username of a MongoDB
+// SCRAM-SHA-1 credential.
+func (d *Digest) derive(passwordBytes []byte, username string) (storedKey, serverKey []byte) {
+	hf := d.variant.HashFunc()
+
+	salted := pbkdf2.Key(d.prepare(passwordBytes, username), d.salt, d.iterations, hf().Size(), hf)
+
+	mac := hmac.New(hf, salted)
+	mac.Write([]byte(keyClient))
+
+	h := hf()
+	h.Write(mac.Sum(nil))
+
+	storedKey = h.Sum(nil)
+
+	mac = hmac.New(hf, salted)
+	mac.Write([]byte(keyServer))
+
+	return storedKey, mac.Sum(nil)
+}
+
+// prepare the password for this scram.Digest. The MongoDB SCRAM-SHA-1 credentials use the hex encoded MD5 digest of
+// the username and password instead of the password.
+func (d *Digest) prepare(passwordBytes []byte, username string) (prepared []byte) {
+	if d.variant == VariantSHA1 && username != "" {
+		h := md5.New() //nolint:gosec
+		h.Write([]byte(username + mongoDBSeparator))
+		h.Write(passwordBytes)
+
+		return []byte(hex.EncodeToString(h.Sum(nil)))
+	}
+
+	return saslPrep(passwordBytes)
+}
+
+func (d *Digest) defaults() {
+	switch d.variant {
+	case VariantSHA1, VariantSHA256:
+		break
+	default:
+		d.variant = variantDefault
+	}
+
+	if d.iterations < 1 {
+		d.iterations = IterationsDefault
+	}
+}
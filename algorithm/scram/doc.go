@@ -0,0 +1,11 @@
+// Package scram provides helpful abstractions for an implementation of the SCRAM verifiers described in RFC5802 and
+// RFC7677 and implements github.com/go-crypt/crypt interfaces.
+//
+// Digests are encoded in the PostgreSQL SCRAM secret format and can also be converted to and from the MongoDB SCRAM
+// credential documents. The MongoDB SCRAM-SHA-1 credentials derive the key from the username, as such they can't be
+// encoded in the PostgreSQL SCRAM secret format and are instead encoded with the base64 encoded username appended as an
+// additional segment. The username can also be provided when matching via the algorithm.ContextualMatcher
+// implementation.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package scram
@@ -0,0 +1,167 @@
+package scram
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/random"
+)
+
+// New returns a new scram.Hasher with the provided functional options applied.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// NewSHA1 returns a new scram.Hasher with the provided functional options applied as well as the scram.VariantSHA1
+// variant.
+func NewSHA1(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantSHA1, opts...)
+}
+
+// NewSHA256 returns a new scram.Hasher with the provided functional options applied as well as the scram.VariantSHA256
+// variant.
+func NewSHA256(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantSHA256, opts...)
+}
+
+func newVariant(variant Variant, opts ...Opt) (hasher *Hasher, err error) {
+	if hasher, err = New(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.WithOptions(WithVariant(variant)); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for SCRAM which can be initialized via New using a functional options pattern.
+type Hasher struct {
+	variant Variant
+
+	iterations, bytesSalt int
+
+	username string
+
+	d bool
+}
+
+// WithOptions defines the options for this scram.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hash(password); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hash(password string) (digest algorithm.Digest, err error) {
+	var salt []byte
+
+	if salt, err = random.Bytes(h.bytesSalt); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrSaltReadRandomBytes, err)
+	}
+
+	return h.hashWithSalt(password, salt)
+}
+
+// HashWithSalt overloads the Hash method allowing the user to provide a salt. It's recommended instead to configure the
+// salt size and let this be a random value generated using crypto/rand.
+func (h *Hasher) HashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hashWithSalt(password, salt); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	if s := len(salt); s > SaltLengthMax || s < SaltLengthMin {
+		return nil, fmt.Errorf("%w: salt bytes must have a length of between %d and %d but has a length of %d", algorithm.ErrSaltInvalid, SaltLengthMin, SaltLengthMax, len(salt))
+	}
+
+	d := &Digest{
+		variant:    h.variant,
+		iterations: h.iterations,
+		salt:       salt,
+	}
+
+	d.defaults()
+
+	if d.variant == VariantSHA1 {
+		d.username = h.username
+	}
+
+	d.storedKey, d.serverKey = d.derive([]byte(password), d.username)
+
+	return d, nil
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	h.defaults()
+
+	return nil
+}
+
+func (h *Hasher) defaults() {
+	if h.d {
+		return
+	}
+
+	h.d = true
+
+	if h.variant == VariantNone {
+		h.variant = variantDefault
+	}
+
+	if h.iterations == 0 {
+		h.iterations = IterationsDefault
+	}
+
+	if h.bytesSalt == 0 {
+		h.bytesSalt = algorithm.SaltLengthDefault
+	}
+}
@@ -0,0 +1,104 @@
+package scram
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// MongoDBCredentials is the MongoDB credentials document of a user which contains a MongoDBCredential for each of the
+// SCRAM mechanisms the user supports.
+type MongoDBCredentials struct {
+	SHA1   *MongoDBCredential `json:"SCRAM-SHA-1,omitempty" bson:"SCRAM-SHA-1,omitempty"`
+	SHA256 *MongoDBCredential `json:"SCRAM-SHA-256,omitempty" bson:"SCRAM-SHA-256,omitempty"`
+}
+
+// MongoDBCredential is the MongoDB credential document of a single SCRAM mechanism. The Salt, StoredKey, and
+// ServerKey values are base64 encoded.
+type MongoDBCredential struct {
+	IterationCount int    `json:"iterationCount" bson:"iterationCount"`
+	Salt           string `json:"salt" bson:"salt"`
+	StoredKey      string `json:"storedKey" bson:"storedKey"`
+	ServerKey      string `json:"serverKey" bson:"serverKey"`
+}
+
+// DecodeMongoDB decodes the MongoDB credential document of the SCRAM mechanism described by the scram.Variant into a
+// scram.Digest. The username is required for the scram.VariantSHA1 variant as MongoDB derives the SCRAM-SHA-1 key from
+// the hex encoded MD5 digest of the username and password, it's ignored for the scram.VariantSHA256 variant.
+func DecodeMongoDB(variant Variant, username string, credential MongoDBCredential) (digest *Digest, err error) {
+	switch variant {
+	case VariantSHA1:
+		if username == "" {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: the username is required for the %s mechanism", algorithm.ErrEncodedHashInvalidOptionValue, variant))
+		}
+	case VariantSHA256:
+		username = ""
+	default:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrEncodedHashInvalidIdentifier, variant))
+	}
+
+	digest = &Digest{
+		variant:    variant,
+		iterations: credential.IterationCount,
+		username:   username,
+	}
+
+	if digest.salt, digest.storedKey, digest.serverKey, err = decodeValues(variant, credential.IterationCount, credential.Salt, credential.StoredKey, credential.ServerKey); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+// DecodeMongoDBCredentials decodes each of the MongoDB credential documents in the credentials document into a
+// scram.Digest. The username is only used by the SCRAM-SHA-1 credential.
+func DecodeMongoDBCredentials(username string, credentials MongoDBCredentials) (digests []*Digest, err error) {
+	var digest *Digest
+
+	if credentials.SHA1 != nil {
+		if digest, err = DecodeMongoDB(VariantSHA1, username, *credentials.SHA1); err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, digest)
+	}
+
+	if credentials.SHA256 != nil {
+		if digest, err = DecodeMongoDB(VariantSHA256, username, *credentials.SHA256); err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, digest)
+	}
+
+	return digests, nil
+}
+
+// MongoDBCredential returns the MongoDB credential document of this scram.Digest. The SCRAM-SHA-1 credential of this
+// scram.Digest can only be used with MongoDB if the scram.Digest was created with the username of the user.
+func (d *Digest) MongoDBCredential() (credential MongoDBCredential) {
+	return MongoDBCredential{
+		IterationCount: d.iterations,
+		Salt:           base64.StdEncoding.EncodeToString(d.salt),
+		StoredKey:      base64.StdEncoding.EncodeToString(d.storedKey),
+		ServerKey:      base64.StdEncoding.EncodeToString(d.serverKey),
+	}
+}
+
+// NewMongoDBCredentials returns the MongoDB credentials document which contains the credential document of each of the
+// provided scram.Digest's. If more than one scram.Digest uses the same scram.Variant the last one is used.
+func NewMongoDBCredentials(digests ...*Digest) (credentials MongoDBCredentials) {
+	for _, digest := range digests {
+		credential := digest.MongoDBCredential()
+
+		switch digest.variant {
+		case VariantSHA1:
+			credentials.SHA1 = &credential
+		case VariantSHA256:
+			credentials.SHA256 = &credential
+		}
+	}
+
+	return credentials
+}
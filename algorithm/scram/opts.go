@@ -0,0 +1,92 @@
+package scram
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Opt describes the functional option pattern for the scram.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithVariant configures the scram.Variant of the resulting scram.Digest.
+// Default is scram.VariantSHA256.
+func WithVariant(variant Variant) Opt {
+	return func(h *Hasher) (err error) {
+		switch variant {
+		case VariantNone:
+			return nil
+		case VariantSHA1, VariantSHA256:
+			h.variant = variant
+
+			return nil
+		default:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant '%d' is invalid", algorithm.ErrParameterInvalid, variant))
+		}
+	}
+}
+
+// WithVariantName uses the variant name or identifier to configure the scram.Variant of the resulting scram.Digest.
+// Default is scram.VariantSHA256.
+func WithVariantName(identifier string) Opt {
+	return func(h *Hasher) (err error) {
+		if identifier == "" {
+			return nil
+		}
+
+		variant := NewVariant(identifier)
+
+		if variant == VariantNone {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
+		}
+
+		h.variant = variant
+
+		return nil
+	}
+}
+
+// WithIterations sets the iterations parameter of the resulting scram.Digest.
+// Minimum is 4096, Maximum is 2147483647. Default is 4096.
+func WithIterations(iterations int) Opt {
+	return func(h *Hasher) (err error) {
+		if iterations < IterationsMin || iterations > IterationsMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", IterationsMin, "", IterationsMax, iterations))
+		}
+
+		h.iterations = iterations
+
+		return nil
+	}
+}
+
+// WithSaltLength adjusts the salt size (in bytes) of the resulting scram.Digest.
+// Minimum is 8, Maximum is 1024. Default is 16.
+func WithSaltLength(bytes int) Opt {
+	return func(h *Hasher) (err error) {
+		if bytes < SaltLengthMin || bytes > SaltLengthMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "salt length", SaltLengthMin, "", SaltLengthMax, bytes))
+		}
+
+		h.bytesSalt = bytes
+
+		return nil
+	}
+}
+
+// WithMongoDBUsername sets the username used by the MongoDB SCRAM-SHA-1 credentials which derive the key from the hex
+// encoded MD5 digest of the username and password. This option only applies to the scram.VariantSHA1 variant. The
+// resulting digests can't be encoded in the PostgreSQL SCRAM secret format, as such they're encoded with the base64
+// encoded username appended and can be converted to a MongoDB credential using the scram.Digest.MongoDBCredential
+// method.
+func WithMongoDBUsername(username string) Opt {
+	return func(h *Hasher) (err error) {
+		if username == "" {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: username must not be empty", algorithm.ErrParameterInvalid))
+		}
+
+		h.username = username
+
+		return nil
+	}
+}
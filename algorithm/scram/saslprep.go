@@ -0,0 +1,150 @@
+package scram
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/bidi"
+	"golang.org/x/text/unicode/norm"
+)
+
+// saslPrep prepares the password using the SASLprep profile described in RFC4013 in the same manner as PostgreSQL. The
+// password is used as is if it's ASCII, if it's not valid UTF-8, or if the prepared password contains prohibited or
+// unassigned characters or doesn't satisfy the bidirectional requirements. Otherwise the non-ASCII space characters
+// are mapped to a space, the characters commonly mapped to nothing are removed, and the result is normalized using
+// Unicode NFKC. Unassigned characters are determined by the Unicode version of the Go standard library rather than the
+// Unicode 3.2 version referenced by RFC3454.
+func saslPrep(password []byte) (prepared []byte) {
+	ascii := true
+
+	for _, c := range password {
+		if c >= utf8.RuneSelf {
+			ascii = false
+
+			break
+		}
+	}
+
+	if ascii || !utf8.Valid(password) {
+		return password
+	}
+
+	prepared = make([]byte, 0, len(password))
+
+	for _, r := range string(password) {
+		switch {
+		case saslPrepMapToNothing(r):
+			continue
+		case saslPrepMapToSpace(r):
+			r = ' '
+		}
+
+		prepared = utf8.AppendRune(prepared, r)
+	}
+
+	prepared = norm.NFKC.Bytes(prepared)
+
+	if !saslPrepValid(prepared) {
+		return password
+	}
+
+	return prepared
+}
+
+// saslPrepValid returns true if the mapped and normalized password has no prohibited or unassigned characters and
+// satisfies the bidirectional requirements of RFC3454 section 6.
+func saslPrepValid(prepared []byte) bool {
+	var (
+		first, last rune
+		randAL, l   bool
+	)
+
+	for i, r := range string(prepared) {
+		if saslPrepMapToSpace(r) || saslPrepProhibited(r) || saslPrepUnassigned(r) {
+			return false
+		}
+
+		if i == 0 {
+			first = r
+		}
+
+		last = r
+
+		switch {
+		case saslPrepRandALCat(r):
+			randAL = true
+		case saslPrepLCat(r):
+			l = true
+		}
+	}
+
+	if randAL && (l || !saslPrepRandALCat(first) || !saslPrepRandALCat(last)) {
+		return false
+	}
+
+	return true
+}
+
+// saslPrepRandALCat returns true for the characters with the bidirectional property R or AL in RFC3454 table D.1.
+func saslPrepRandALCat(r rune) bool {
+	props, _ := bidi.LookupRune(r)
+
+	switch props.Class() {
+	case bidi.R, bidi.AL:
+		return true
+	default:
+		return false
+	}
+}
+
+// saslPrepLCat returns true for the characters with the bidirectional property L in RFC3454 table D.2.
+func saslPrepLCat(r rune) bool {
+	props, _ := bidi.LookupRune(r)
+
+	return props.Class() == bidi.L
+}
+
+// saslPrepUnassigned returns true for the characters which are unassigned code points as described by RFC3454 table
+// A.1. Private use and surrogate code points are prohibited instead.
+func saslPrepUnassigned(r rune) bool {
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+// saslPrepMapToSpace returns true for the non-ASCII space characters in RFC3454 table C.1.2.
+func saslPrepMapToSpace(r rune) bool {
+	switch {
+	case r == 0x00A0, r == 0x1680, r >= 0x2000 && r <= 0x200B, r == 0x202F, r == 0x205F, r == 0x3000:
+		return true
+	default:
+		return false
+	}
+}
+
+// saslPrepMapToNothing returns true for the characters commonly mapped to nothing in RFC3454 table B.1.
+func saslPrepMapToNothing(r rune) bool {
+	switch {
+	case r == 0x00AD, r == 0x034F, r == 0x1806, r >= 0x180B && r <= 0x180D, r >= 0x200B && r <= 0x200D,
+		r == 0x2060, r >= 0xFE00 && r <= 0xFE0F, r == 0xFEFF:
+		return true
+	default:
+		return false
+	}
+}
+
+// saslPrepProhibited returns true for the prohibited characters in RFC3454 tables C.2 to C.9.
+func saslPrepProhibited(r rune) bool {
+	switch {
+	case r < 0x20, r >= 0x7F && r <= 0x9F:
+		return true
+	case r == 0x0340, r == 0x0341, r == 0x06DD, r == 0x070F, r == 0x180E, r == 0x200E, r == 0x200F,
+		r >= 0x2028 && r <= 0x202E, r >= 0x2060 && r <= 0x2063, r >= 0x206A && r <= 0x206F:
+		return true
+	case r >= 0x2FF0 && r <= 0x2FFB, r >= 0xD800 && r <= 0xDFFF, r >= 0xE000 && r <= 0xF8FF,
+		r >= 0xFDD0 && r <= 0xFDEF, r >= 0xFFF9 && r <= 0xFFFD, r&0xFFFE == 0xFFFE:
+		return true
+	case r >= 0x1D173 && r <= 0x1D17A, r == 0xE0001, r >= 0xE0020 && r <= 0xE007F, r >= 0xF0000:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,494 @@
+package scram
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+func TestNewVariant(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Variant
+	}{
+		{"ShouldReturnSHA1", AlgIdentifierSHA1, VariantSHA1},
+		{"ShouldReturnSHA1Name", "sha1", VariantSHA1},
+		{"ShouldReturnSHA256", AlgIdentifierSHA256, VariantSHA256},
+		{"ShouldReturnSHA256Name", "sha256", VariantSHA256},
+		{"ShouldReturnNoneForUnknown", "SCRAM-SHA-512", VariantNone},
+		{"ShouldReturnNoneForEmpty", "", VariantNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewVariant(tc.have))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		password string
+		variant  Variant
+	}{
+		{
+			"ShouldDecodePostgreSQL",
+			"SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$4PSH04DiBM59z6mw0gs6x1r6+duXYQ+R0KwGZr+W5/o=:IgPInY95tTazYxnARISZb/eTxuX/JRwWgrM9ByaOUIk=",
+			"password",
+			VariantSHA256,
+		},
+		{
+			"ShouldDecodeRFC7677",
+			"SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU=",
+			"pencil",
+			VariantSHA256,
+		},
+		{
+			"ShouldDecodeRFC5802",
+			"SCRAM-SHA-1$4096:QSXCR+Q6sek8bf92$6dlGYMOdZcOPutkcNY8U2g7vK9Y=:D+CSWLOshSulAsxiupA+qs2/fTE=",
+			"pencil",
+			VariantSHA1,
+		},
+		{
+			"ShouldDecodeAndMapNonASCIISpace",
+			"SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$j/VoiRO65AYD0ylfz3CvwkSu8iMA9H/5/EC2AVeQ1ZU=:YtkGKEUB7bKpC1VrVUaT8cFztKwgoDnGKfqw4XknsY0=",
+			"pass word",
+			VariantSHA256,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, MatchEncoded(tc.have))
+
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, tc.variant, d.Variant())
+			assert.Equal(t, 4096, d.Iterations())
+			assert.Equal(t, tc.have, d.Encode())
+			assert.Equal(t, tc.have, d.String())
+			assert.Equal(t, d.Encode(), d.Canonical().Encode())
+
+			assert.True(t, d.Match(tc.password))
+			assert.False(t, d.Match("wrong"))
+
+			match, err := d.MatchAdvanced(tc.password)
+			assert.NoError(t, err)
+			assert.True(t, match)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrFormat", "SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==", "scram decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrIdentifier", "SCRAM-SHA-512$4096:AAECAwQFBgcICQoLDA0ODw==$AAAA:AAAA", "scram decode error: provided encoded hash has an invalid identifier: identifier 'SCRAM-SHA-512' is not an encoded scram digest"},
+		{"ShouldErrIterationsSeparator", "SCRAM-SHA-256$4096$AAAA:AAAA", "scram decode error: provided encoded hash has an invalid format: the iterations and salt are not separated by a colon"},
+		{"ShouldErrSaltSeparator", "SCRAM-SHA-256$4096;AAAA$AAAA:AAAA", "scram decode error: provided encoded hash has an invalid format: the iterations and salt are not separated by a colon"},
+		{"ShouldErrKeySeparator", "SCRAM-SHA-256$4096:AAAA$AAAA", "scram decode error: provided encoded hash has an invalid format: the stored key and server key are not separated by a colon"},
+		{"ShouldErrIterations", "SCRAM-SHA-256$abc:AAAA$AAAA:AAAA", "scram decode error: provided encoded hash has an invalid option value: iterations could not be parsed: strconv.Atoi: parsing \"abc\": invalid syntax"},
+		{"ShouldErrIterationsZero", "SCRAM-SHA-256$0:AAAA$AAAA:AAAA", "scram decode error: provided encoded hash has an invalid option value: iterations must be greater than 0 but is '0'"},
+		{"ShouldErrSalt", "SCRAM-SHA-256$4096:!!!!$AAAA:AAAA", "scram decode error: provided encoded hash has a salt value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrSaltEmpty", "SCRAM-SHA-256$4096:$AAAA:AAAA", "scram decode error: provided encoded hash has a salt value that can't be decoded: salt has 0 bytes"},
+		{"ShouldErrStoredKey", "SCRAM-SHA-256$4096:AAAA$!!!!:AAAA", "scram decode error: provided encoded hash has a key value that can't be decoded: stored key: illegal base64 data at input byte 0"},
+		{"ShouldErrStoredKeyLength", "SCRAM-SHA-256$4096:AAAA$AAAA:AAAA", "scram decode error: provided encoded hash has a key value that can't be decoded: stored key has 3 bytes but must have 32 bytes"},
+		{"ShouldErrServerKey", "SCRAM-SHA-1$4096:AAAA$6dlGYMOdZcOPutkcNY8U2g7vK9Y=:!!!!", "scram decode error: provided encoded hash has a key value that can't be decoded: server key: illegal base64 data at input byte 0"},
+		{"ShouldErrServerKeyLength", "SCRAM-SHA-1$4096:AAAA$6dlGYMOdZcOPutkcNY8U2g7vK9Y=:AAAA", "scram decode error: provided encoded hash has a key value that can't be decoded: server key has 3 bytes but must have 20 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecodeVariant(t *testing.T) {
+	digest, err := DecodeVariant(VariantSHA1)("SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU=")
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram decode error: the 'SCRAM-SHA-256' variant cannot be decoded only the 'SCRAM-SHA-1' variant can be")
+}
+
+func TestHasher(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hasher   func(opts ...Opt) (*Hasher, error)
+		opts     []Opt
+		password string
+		expected string
+	}{
+		{
+			"ShouldHashDefault",
+			New,
+			nil,
+			"password",
+			"SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$4PSH04DiBM59z6mw0gs6x1r6+duXYQ+R0KwGZr+W5/o=:IgPInY95tTazYxnARISZb/eTxuX/JRwWgrM9ByaOUIk=",
+		},
+		{
+			"ShouldHashSHA1",
+			NewSHA1,
+			nil,
+			"password",
+			"",
+		},
+		{
+			"ShouldHashSHA256WithIterations",
+			NewSHA256,
+			[]Opt{WithIterations(15000)},
+			"password",
+			"",
+		},
+	}
+
+	salt := []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := tc.hasher(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt(tc.password, salt)
+			require.NoError(t, err)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, digest.Encode())
+			}
+
+			assert.True(t, digest.Match(tc.password))
+			assert.False(t, digest.Match("wrong"))
+
+			random, err := hasher.Hash(tc.password)
+			require.NoError(t, err)
+
+			assert.Len(t, random.Salt(), 16)
+			assert.True(t, random.Match(tc.password))
+
+			decoded, err := Decode(random.Encode())
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match(tc.password))
+		})
+	}
+}
+
+func TestHasherErrors(t *testing.T) {
+	hasher, err := New()
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt("password", []byte("salt"))
+
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram hashing error: salt is invalid: salt bytes must have a length of between 8 and 1024 but has a length of 4")
+
+	assert.True(t, hasher.MustHash("password").Match("password"))
+}
+
+func TestHasherOptionErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have Opt
+		err  string
+	}{
+		{"ShouldErrVariant", WithVariant(Variant(99)), "scram validation error: parameter is invalid: variant '99' is invalid"},
+		{"ShouldErrVariantName", WithVariantName("SCRAM-SHA-512"), "scram validation error: parameter is invalid: variant identifier 'SCRAM-SHA-512' is invalid"},
+		{"ShouldErrIterations", WithIterations(4095), "scram validation error: parameter is invalid: parameter 'iterations' must be between 4096 and 2147483647 but is set to '4095'"},
+		{"ShouldErrSaltLength", WithSaltLength(7), "scram validation error: parameter is invalid: parameter 'salt length' must be between 8 and 1024 but is set to '7'"},
+		{"ShouldErrUsername", WithMongoDBUsername(""), "scram validation error: parameter is invalid: username must not be empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.have)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestMongoDB(t *testing.T) {
+	doc := []byte(`{
+		"SCRAM-SHA-1": {
+			"iterationCount": 10000,
+			"salt": "QSXCR+Q6sek8bf92",
+			"storedKey": "9vuFA0rimi6r5low+ZaNrWi9lZE=",
+			"serverKey": "ds04Gsi3sGu9+Egbw/xpLB0khbM="
+		},
+		"SCRAM-SHA-256": {
+			"iterationCount": 15000,
+			"salt": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGw==",
+			"storedKey": "nZbtY9VzVP9yYKIVwzBEMz4ZaM7isQFV5bLB3Gbk7qU=",
+			"serverKey": "TUlTs98yxOG0rskHccVTbZOKJm4PWRjsAz7F1H6Rgyg="
+		}
+	}`)
+
+	var credentials MongoDBCredentials
+
+	require.NoError(t, json.Unmarshal(doc, &credentials))
+
+	digests, err := DecodeMongoDBCredentials("user", credentials)
+	require.NoError(t, err)
+	require.Len(t, digests, 2)
+
+	assert.Equal(t, VariantSHA1, digests[0].Variant())
+	assert.Equal(t, VariantSHA256, digests[1].Variant())
+
+	for _, digest := range digests {
+		assert.True(t, digest.Match("pencil"))
+		assert.False(t, digest.Match("wrong"))
+	}
+
+	assert.Equal(t, credentials, NewMongoDBCredentials(digests...))
+
+	hasher, err := NewSHA1(WithMongoDBUsername("user"), WithIterations(10000))
+	require.NoError(t, err)
+
+	salt, err := base64.StdEncoding.DecodeString("QSXCR+Q6sek8bf92")
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt("pencil", salt)
+	require.NoError(t, err)
+
+	assert.Equal(t, *credentials.SHA1, digest.(*Digest).MongoDBCredential())
+	assert.Equal(t, "user", digest.(*Digest).Username())
+	assert.Equal(t, "SCRAM-SHA-1$10000:QSXCR+Q6sek8bf92$9vuFA0rimi6r5low+ZaNrWi9lZE=:ds04Gsi3sGu9+Egbw/xpLB0khbM=$dXNlcg==", digest.Encode())
+	assert.Equal(t, digest.Encode(), digest.String())
+	assert.Equal(t, digest.Encode(), digests[0].Encode())
+	assert.NotEqual(t, "", digests[1].Encode())
+
+	decoded, err := Decode(digest.Encode())
+	require.NoError(t, err)
+
+	assert.Equal(t, "user", decoded.(*Digest).Username())
+	assert.Equal(t, *credentials.SHA1, decoded.(*Digest).MongoDBCredential())
+	assert.True(t, decoded.Match("pencil"))
+	assert.False(t, decoded.Match("wrong"))
+	assert.Equal(t, digest.Encode(), decoded.Encode())
+}
+
+func TestMongoDBHashEncodeRoundTrip(t *testing.T) {
+	hasher, err := NewSHA1(WithMongoDBUsername("user:$name"), WithIterations(IterationsMin))
+	require.NoError(t, err)
+
+	digest, err := hasher.Hash("pencil")
+	require.NoError(t, err)
+
+	encoded := digest.Encode()
+	require.NotEqual(t, "", encoded)
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, "user:$name", decoded.(*Digest).Username())
+	assert.Equal(t, encoded, decoded.Encode())
+	assert.True(t, decoded.Match("pencil"))
+	assert.False(t, decoded.Match("wrong"))
+}
+
+func TestMongoDBDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldErrSHA256Username", "SCRAM-SHA-256$4096:QSXCR+Q6sek8bf92$9vuFA0rimi6r5low+ZaNrWi9lZE=:ds04Gsi3sGu9+Egbw/xpLB0khbM=$dXNlcg==", "scram decode error: provided encoded hash has an invalid format: the username is only supported by the SCRAM-SHA-1 mechanism"},
+		{"ShouldErrUsernameEncoding", "SCRAM-SHA-1$10000:QSXCR+Q6sek8bf92$9vuFA0rimi6r5low+ZaNrWi9lZE=:ds04Gsi3sGu9+Egbw/xpLB0khbM=$!!", "scram decode error: provided encoded hash has an invalid option value: username could not be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrUsernameEmpty", "SCRAM-SHA-1$10000:QSXCR+Q6sek8bf92$9vuFA0rimi6r5low+ZaNrWi9lZE=:ds04Gsi3sGu9+Egbw/xpLB0khbM=$", "scram decode error: provided encoded hash has an invalid option value: the username is required for the SCRAM-SHA-1 mechanism"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestMongoDBMatchWithContext(t *testing.T) {
+	credential := MongoDBCredential{IterationCount: 10000, Salt: "QSXCR+Q6sek8bf92", StoredKey: "9vuFA0rimi6r5low+ZaNrWi9lZE=", ServerKey: "ds04Gsi3sGu9+Egbw/xpLB0khbM="}
+
+	digest, err := DecodeMongoDB(VariantSHA1, "other", credential)
+	require.NoError(t, err)
+
+	var _ algorithm.ContextualMatcher = digest
+
+	assert.False(t, digest.Match("pencil"))
+	assert.True(t, digest.MatchWithContext("pencil", algorithm.MatchContext{Username: "user"}))
+	assert.False(t, digest.MatchWithContext("pencil", algorithm.MatchContext{Username: "other"}))
+	assert.False(t, digest.MatchWithContext("wrong", algorithm.MatchContext{Username: "user"}))
+
+	match, err := digest.MatchAdvancedWithContext("pencil", algorithm.MatchContext{})
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	decoded, err := Decode("SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU=")
+	require.NoError(t, err)
+
+	c, ok := decoded.(algorithm.ContextualMatcher)
+	require.True(t, ok)
+
+	match, err = c.MatchAdvancedWithContext("wrong", algorithm.MatchContext{Username: "user"})
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestMatchWithContextIgnoresUsername(t *testing.T) {
+	testCases := []struct {
+		name string
+		new  func(opts ...Opt) (hasher *Hasher, err error)
+	}{
+		{"ShouldIgnoreUsernameSHA1", NewSHA1},
+		{"ShouldIgnoreUsernameSHA256", NewSHA256},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := tc.new()
+			require.NoError(t, err)
+
+			digest, err := hasher.Hash("pencil")
+			require.NoError(t, err)
+
+			decoded, err := Decode(digest.Encode())
+			require.NoError(t, err)
+
+			c, ok := decoded.(algorithm.ContextualMatcher)
+			require.True(t, ok)
+
+			match, err := c.MatchAdvancedWithContext("pencil", algorithm.MatchContext{Username: "user"})
+			assert.NoError(t, err)
+			assert.True(t, match)
+
+			assert.False(t, c.MatchWithContext("wrong", algorithm.MatchContext{Username: "user"}))
+		})
+	}
+}
+
+func TestMongoDBErrors(t *testing.T) {
+	credential := MongoDBCredential{IterationCount: 10000, Salt: "QSXCR+Q6sek8bf92", StoredKey: "9vuFA0rimi6r5low+ZaNrWi9lZE=", ServerKey: "ds04Gsi3sGu9+Egbw/xpLB0khbM="}
+
+	testCases := []struct {
+		name       string
+		variant    Variant
+		username   string
+		credential MongoDBCredential
+		err        string
+	}{
+		{"ShouldErrUsername", VariantSHA1, "", credential, "scram decode error: provided encoded hash has an invalid option value: the username is required for the SCRAM-SHA-1 mechanism"},
+		{"ShouldErrVariant", VariantNone, "user", credential, "scram decode error: provided encoded hash has an invalid identifier: variant '0' is invalid"},
+		{"ShouldErrKeyLength", VariantSHA256, "user", credential, "scram decode error: provided encoded hash has a key value that can't be decoded: stored key has 20 bytes but must have 32 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := DecodeMongoDB(tc.variant, tc.username, tc.credential)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	digests, err := DecodeMongoDBCredentials("", MongoDBCredentials{SHA1: &credential})
+
+	assert.Nil(t, digests)
+	assert.Error(t, err)
+}
+
+func TestSASLPrep(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldNotModifyASCII", "password", "password"},
+		{"ShouldNotModifyASCIIControl", "pass\x00word", "pass\x00word"},
+		{"ShouldMapSpace", "pass word", "pass word"},
+		{"ShouldMapToNothing", "pass­word", "password"},
+		{"ShouldNotModifyProhibited", "pass­word‎", "pass­word‎"},
+		{"ShouldNotModifyInvalidUTF8", "pass\xffword ", "pass\xffword "},
+		{"ShouldNotModifyOtherNonASCII", "pässwörd", "pässwörd"},
+		{"ShouldNormalizeNFKCFeminineOrdinal", "\u00AA", "a"},
+		{"ShouldNormalizeNFKCRomanNumeral", "\u2168", "IX"},
+		{"ShouldNormalizeNFKCLigature", "\uFB01le", "file"},
+		{"ShouldNormalizeNFKCComposition", "pa\u0308sswort", "pässwort"},
+		{"ShouldMapToNothingAndNormalize", "I\u00ADX\u2168", "IXIX"},
+		{"ShouldNotModifyUnassigned", "pass\u0378word\u00AA", "pass\u0378word\u00AA"},
+		{"ShouldPrepareRandALCat", "\u0627\u0644\u0633", "\u0627\u0644\u0633"},
+		{"ShouldNotModifyRandALCatWithLCat", "\u0627a\u0627", "\u0627a\u0627"},
+		{"ShouldNotModifyRandALCatNotLast", "\u06271", "\u06271"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(saslPrep([]byte(tc.have))))
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode("SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU=")
+	require.NoError(t, err)
+
+	assert.IsType(t, &Digest{}, digest)
+	assert.True(t, digest.Match("pencil"))
+
+	assert.False(t, MatchEncoded("SCRAM-SHA-512$4096:AAAA$AAAA:AAAA"))
+	assert.False(t, MatchEncoded("$pbkdf2-sha256$4096$AAAA$AAAA"))
+
+	var _ algorithm.CanonicalDigest = &Digest{}
+}
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$4PSH04DiBM59z6mw0gs6x1r6+duXYQ+R0KwGZr+W5/o=:IgPInY95tTazYxnARISZb/eTxuX/JRwWgrM9ByaOUIk=")
+	require.NoError(t, err)
+
+	d := decoded.(*Digest)
+
+	digest, err := NewDigest(VariantSHA256, d.Iterations(), d.Salt(), d.Key(), d.ServerKey())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(VariantNone, d.Iterations(), d.Salt(), d.Key(), d.ServerKey())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram decode error: parameter is invalid: variant must be either the 'SCRAM-SHA-1' or 'SCRAM-SHA-256' variant")
+
+	digest, err = NewDigest(VariantSHA256, 0, d.Salt(), d.Key(), d.ServerKey())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram decode error: parameter is invalid: iterations must be greater than 0 but is '0'")
+
+	digest, err = NewDigest(VariantSHA256, d.Iterations(), nil, d.Key(), d.ServerKey())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram decode error: parameter is invalid: salt has 0 bytes")
+
+	digest, err = NewDigest(VariantSHA1, d.Iterations(), d.Salt(), d.Key(), d.ServerKey())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "scram decode error: parameter is invalid: stored key has 32 bytes but must have 20 bytes")
+}
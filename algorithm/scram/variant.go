@@ -0,0 +1,63 @@
+package scram
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// NewVariant converts an identifier string to a scram.Variant.
+func NewVariant(identifier string) (variant Variant) {
+	switch identifier {
+	case AlgIdentifierSHA1, "sha1":
+		return VariantSHA1
+	case AlgIdentifierSHA256, "sha256":
+		return VariantSHA256
+	default:
+		return VariantNone
+	}
+}
+
+// Variant is a variant of the scram.Digest.
+type Variant int
+
+const (
+	// VariantNone is a variant of the scram.Digest which is unknown.
+	VariantNone Variant = iota
+
+	// VariantSHA1 is the SCRAM-SHA-1 variant of the scram.Digest.
+	VariantSHA1
+
+	// VariantSHA256 is the SCRAM-SHA-256 variant of the scram.Digest.
+	VariantSHA256
+)
+
+// String implements the fmt.Stringer returning a string representation of the scram.Variant.
+func (v Variant) String() (identifier string) {
+	switch v {
+	case VariantSHA1:
+		return AlgIdentifierSHA1
+	case VariantSHA256:
+		return AlgIdentifierSHA256
+	default:
+		return
+	}
+}
+
+// Prefix returns the scram.Variant prefix identifier.
+func (v Variant) Prefix() (prefix string) {
+	return v.String()
+}
+
+// HashFunc returns the internal HMAC algorithm.HashFunc.
+func (v Variant) HashFunc() algorithm.HashFunc {
+	switch v {
+	case VariantSHA1:
+		return sha1.New
+	case VariantSHA256:
+		return sha256.New
+	default:
+		return nil
+	}
+}
@@ -2,6 +2,8 @@ package scrypt
 
 import (
 	"math"
+
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
 const (
@@ -11,12 +13,27 @@ const (
 	// EncodingFmtYescrypt is the format of the encoded digest.
 	EncodingFmtYescrypt = "$%s$%s$%s$%s"
 
+	// EncodingFmtScryptCrypt is the format of the encoded digest.
+	EncodingFmtScryptCrypt = "$%s$%s%s$%s"
+
+	// EncodingFmtFirebase is the format of the encoded digest.
+	EncodingFmtFirebase = "$%s$ln=%d,r=%d,p=%d,ss=%s,sk=%s$%s$%s"
+
 	// AlgName is the name for this algorithm.
 	AlgName = "scrypt"
 
 	// AlgNameYescrypt is the name for this algorithm's yescrypt variant.
 	AlgNameYescrypt = "yescrypt"
 
+	// AlgNameScryptCrypt is the name for this algorithm's libxcrypt scrypt variant.
+	AlgNameScryptCrypt = "scrypt-crypt"
+
+	// AlgNameGostYescrypt is the name for this algorithm's gost-yescrypt variant.
+	AlgNameGostYescrypt = "gost-yescrypt"
+
+	// AlgNameFirebase is the name for this algorithm's Firebase modified scrypt variant.
+	AlgNameFirebase = "firebase-scrypt"
+
 	// KeyLengthMin is the minimum key length accepted.
 	KeyLengthMin = 1
 
@@ -60,18 +77,68 @@ const (
 
 	// ParallelismDefault is the default parallelism factor.
 	ParallelismDefault = ParallelismMin
+
+	// TimeMin is the minimum yescrypt time parameter accepted.
+	TimeMin = 0
+
+	// TimeMax is the maximum yescrypt time parameter accepted.
+	TimeMax = math.MaxInt32
+
+	// TimeDefault is the default yescrypt time parameter.
+	TimeDefault = TimeMin
+
+	// FirebaseRoundsMin is the minimum Firebase rounds parameter accepted which is the block size.
+	FirebaseRoundsMin = 1
+
+	// FirebaseRoundsMax is the maximum Firebase rounds parameter accepted which is the block size.
+	FirebaseRoundsMax = 8
+
+	// FirebaseMemCostMin is the minimum Firebase mem_cost parameter accepted which is the log2 of N.
+	FirebaseMemCostMin = 1
+
+	// FirebaseMemCostMax is the maximum Firebase mem_cost parameter accepted which is the log2 of N.
+	FirebaseMemCostMax = 14
+)
+
+const (
+	// YescryptFlagsScrypt is the yescrypt flags value which results in the classic scrypt algorithm being used with
+	// the yescrypt encoding.
+	YescryptFlagsScrypt = yescrypt.FlagsScrypt
+
+	// YescryptFlagsWORM is the yescrypt flags value which results in the write-once-read-many mode being used. This mode
+	// is a conservative enhancement of scrypt.
+	YescryptFlagsWORM = yescrypt.FlagWORM
+
+	// YescryptFlagsDefault is the yescrypt flags value which results in the read-write mode with the default pwxform
+	// settings being used. This is the only read-write flavor supported by libxcrypt and the reference implementation.
+	YescryptFlagsDefault = yescrypt.FlagsDefault
 )
 
 const (
 	AlgIdentifier = AlgName
 
 	AlgIdentifierYescrypt = "y"
+
+	AlgIdentifierScryptCrypt = "7"
+
+	AlgIdentifierGostYescrypt = "gy"
+
+	AlgIdentifierFirebase = AlgNameFirebase
 )
 
 const (
 	oP  = "p"
 	oR  = "r"
 	oLN = "ln"
+	oSS = "ss"
+	oSK = "sk"
 
 	variantDefault = VariantScrypt
+
+	yescryptIterationsMin = 2
+	yescryptIterationsMax = 31
+
+	gostYescryptKeyLength = 32
+
+	firebaseKeyLength = 32
 )
@@ -1,13 +1,11 @@
 package scrypt
 
 import (
-	"encoding/base64"
 	"fmt"
 
-	"github.com/go-crypt/x/yescrypt"
-
 	"github.com/go-crypt/crypt/algorithm"
-	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/internal/yescrypt"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -20,6 +18,18 @@ func RegisterDecoder(r algorithm.DecoderRegister) (err error) {
 		return err
 	}
 
+	if err = RegisterDecoderScryptCrypt(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderGostYescrypt(r); err != nil {
+		return err
+	}
+
+	if err = RegisterDecoderFirebase(r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -41,6 +51,33 @@ func RegisterDecoderYescrypt(r algorithm.DecoderRegister) (err error) {
 	return nil
 }
 
+// RegisterDecoderScryptCrypt the libxcrypt scrypt decoder with the algorithm.DecoderRegister.
+func RegisterDecoderScryptCrypt(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantScryptCrypt.Prefix(), Decode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderGostYescrypt the gost-yescrypt decoder with the algorithm.DecoderRegister.
+func RegisterDecoderGostYescrypt(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantGostYescrypt.Prefix(), Decode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterDecoderFirebase the Firebase modified scrypt decoder with the algorithm.DecoderRegister.
+func RegisterDecoderFirebase(r algorithm.DecoderRegister) (err error) {
+	if err = r.RegisterDecodeFunc(VariantFirebase.Prefix(), Decode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Decode the encoded digest into a algorithm.Digest.
 func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	return DecodeVariant(VariantNone)(encodedDigest)
@@ -63,7 +100,7 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("the '%s' variant cannot be decoded only the '%s' variant can be", variant.String(), v.String()))
 		}
 
-		if digest, err = decode(variant, parts); err != nil {
+		if digest, err = decode(variant, encodedDigest, parts); err != nil {
 			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
 		}
 
@@ -71,10 +108,38 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
+// NewDigest returns a scrypt.Digest from the raw parameters, salt, and key which is useful when the values of a digest
+// are not stored in the encoded format. Only the scrypt.VariantScrypt and scrypt.VariantScryptCrypt variants are
+// supported as the other variants have additional parameters.
+func NewDigest(variant Variant, ln, r, p int, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant != VariantScrypt && variant != VariantScryptCrypt:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantScrypt, VariantScryptCrypt))
+	case ln < IterationsMin || ln > IterationsMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "ln", IterationsMin, "", IterationsMax, ln))
+	case r < BlockSizeMin || r > BlockSizeMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "r", BlockSizeMin, "", BlockSizeMax, r))
+	case p < ParallelismMin || p > ParallelismMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "p", ParallelismMin, "", ParallelismMax, p))
+	case uint64(r)*uint64(p) >= 1<<30:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: parameters 'r' and 'p' must be less than %d when multiplied but they are '%d'", algorithm.ErrParameterInvalid, 1<<30, uint64(r)*uint64(p)))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	}
+
+	return &Digest{variant: variant, ln: ln, r: r, p: p, salt: salt, key: key}, nil
+}
+
 func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+	parts = phc.Split(encodedDigest, -1)
+
+	n := 5
+
+	if len(parts) >= 2 && parts[1] == AlgIdentifierScryptCrypt {
+		n = 4
+	}
 
-	if len(parts) != 5 {
+	if len(parts) != n {
 		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
 	}
 
@@ -87,7 +152,7 @@ func decoderParts(encodedDigest string) (variant Variant, parts []string, err er
 	return variant, parts[2:], nil
 }
 
-func decode(variant Variant, parts []string) (digest algorithm.Digest, err error) {
+func decode(variant Variant, encodedDigest string, parts []string) (digest algorithm.Digest, err error) {
 	decoded := &Digest{
 		variant: variant,
 		ln:      IterationsDefault,
@@ -96,20 +161,46 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 	}
 
 	switch variant {
-	case VariantYescrypt:
-		if _, decoded.ln, decoded.r, err = yescrypt.DecodeSetting([]byte(parts[0])); err != nil {
-			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	case VariantScryptCrypt:
+		var (
+			setting yescrypt.Setting
+			salt    string
+		)
+
+		if setting, salt, err = yescrypt.DecodeScryptSetting(parts[0]); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashInvalidOption, err)
+		}
+
+		decoded.ln, decoded.r, decoded.p, decoded.salt = setting.LN, setting.R, setting.P, []byte(salt)
+
+		if decoded.key = yescrypt.Decode64([]byte(parts[1])); decoded.key == nil {
+			return nil, fmt.Errorf("%w: key is not valid yescrypt base64", algorithm.ErrEncodedHashKeyEncoding)
 		}
+	case VariantYescrypt, VariantGostYescrypt:
+		var setting yescrypt.Setting
+
+		if setting, err = yescrypt.DecodeSetting(parts[0]); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashInvalidOption, err)
+		}
+
+		decoded.flags, decoded.ln, decoded.r, decoded.p = setting.Flags, setting.LN, setting.R, setting.P
+		decoded.t, decoded.g, decoded.lnROM = setting.T, setting.G, setting.LNROM
 
-		decoded.salt, decoded.key = yescrypt.Decode64([]byte(parts[1])), yescrypt.Decode64([]byte(parts[2]))
+		if decoded.salt = yescrypt.Decode64([]byte(parts[1])); decoded.salt == nil {
+			return nil, fmt.Errorf("%w: salt is not valid yescrypt base64", algorithm.ErrEncodedHashSaltEncoding)
+		}
+
+		if decoded.key = yescrypt.Decode64([]byte(parts[2])); decoded.key == nil {
+			return nil, fmt.Errorf("%w: key is not valid yescrypt base64", algorithm.ErrEncodedHashKeyEncoding)
+		}
 	default:
-		var params []encoding.Parameter
+		var encoded *phc.String
 
-		if params, err = encoding.DecodeParameterStr(parts[0]); err != nil {
+		if encoded, err = phc.Decode(encodedDigest, phc.WithHashRequired(), phc.WithParameterEncoding(phc.B64, oSS, oSK)); err != nil {
 			return nil, err
 		}
 
-		for _, param := range params {
+		for _, param := range encoded.Parameters {
 			switch param.Key {
 			case oLN:
 				decoded.ln, err = param.Int()
@@ -117,6 +208,20 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 				decoded.r, err = param.Int()
 			case oP:
 				decoded.p, err = param.Int()
+			case oSS, oSK:
+				if variant != VariantFirebase {
+					return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
+				}
+
+				var value []byte
+
+				if value, err = phc.B64.DecodeString(param.Value); err == nil {
+					if param.Key == oSS {
+						decoded.saltSeparator = value
+					} else {
+						decoded.signerKey = value
+					}
+				}
 			default:
 				return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
 			}
@@ -126,11 +231,15 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 			}
 		}
 
-		if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil {
+		if encoded.Version != "" {
+			return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, phc.VersionKey, encoded.Version)
+		}
+
+		if decoded.salt, err = phc.B64.DecodeString(encoded.Salt); err != nil {
 			return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashSaltEncoding, err)
 		}
 
-		if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+		if decoded.key, err = phc.B64.DecodeString(encoded.Hash); err != nil {
 			return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
 		}
 	}
@@ -139,5 +248,24 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 		return nil, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrEncodedHashKeyEncoding)
 	}
 
+	if variant == VariantFirebase {
+		if len(decoded.signerKey) == 0 {
+			return nil, fmt.Errorf("%w: option '%s' is required", algorithm.ErrEncodedHashInvalidOption, oSK)
+		}
+
+		if len(decoded.key) != len(decoded.signerKey) {
+			return nil, fmt.Errorf("%w: key has %d bytes but must have the same length as the signer key which has %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(decoded.key), len(decoded.signerKey))
+		}
+
+		switch {
+		case decoded.ln < FirebaseMemCostMin || decoded.ln > FirebaseMemCostMax:
+			return nil, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrEncodedHashInvalidOptionValue, oLN, FirebaseMemCostMin, "", FirebaseMemCostMax, decoded.ln)
+		case decoded.r < FirebaseRoundsMin || decoded.r > FirebaseRoundsMax:
+			return nil, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrEncodedHashInvalidOptionValue, oR, FirebaseRoundsMin, "", FirebaseRoundsMax, decoded.r)
+		case decoded.p != ParallelismMin:
+			return nil, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrEncodedHashInvalidOptionValue, oP, ParallelismMin, "", ParallelismMin, decoded.p)
+		}
+	}
+
 	return decoded, nil
 }
@@ -2,18 +2,57 @@ package scrypt
 
 import (
 	"crypto/subtle"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
+// NewFirebaseDigest returns a scrypt.Digest using the scrypt.VariantFirebase variant from the values of a Firebase
+// Authentication user export and the password hash parameters of the Firebase project. The passwordHash and salt are
+// the decoded values of the user and the signerKey, saltSeparator, rounds, and memCost are the project parameters.
+func NewFirebaseDigest(passwordHash, salt, signerKey, saltSeparator []byte, rounds, memCost int) (digest *Digest, err error) {
+	switch {
+	case len(passwordHash) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrEncodedHashKeyEncoding))
+	case len(signerKey) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: signer key has 0 bytes", algorithm.ErrEncodedHashInvalidOptionValue))
+	case len(passwordHash) != len(signerKey):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has %d bytes but must have the same length as the signer key which has %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(passwordHash), len(signerKey)))
+	case rounds < FirebaseRoundsMin || rounds > FirebaseRoundsMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrEncodedHashInvalidOptionValue, "rounds", FirebaseRoundsMin, "", FirebaseRoundsMax, rounds))
+	case memCost < FirebaseMemCostMin || memCost > FirebaseMemCostMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrEncodedHashInvalidOptionValue, "mem_cost", FirebaseMemCostMin, "", FirebaseMemCostMax, memCost))
+	}
+
+	return &Digest{
+		variant:       VariantFirebase,
+		ln:            memCost,
+		r:             rounds,
+		p:             ParallelismMin,
+		saltSeparator: saltSeparator,
+		signerKey:     signerKey,
+		salt:          salt,
+		key:           passwordHash,
+	}, nil
+}
+
 // Digest is a scrypt.Digest which handles scrypt hashes.
 type Digest struct {
 	variant Variant
 
 	ln, r, p int
 
+	// The following are only used by the scrypt.VariantYescrypt and scrypt.VariantGostYescrypt variants.
+	flags, t, g, lnROM int
+
+	// The following are only used by the scrypt.VariantFirebase variant.
+	saltSeparator, signerKey []byte
+
 	salt, key []byte
+
+	algorithm.Prefix
 }
 
 // Match returns true if the string password matches the current scrypt.Digest.
@@ -45,7 +84,7 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 	var key []byte
 
-	if key, err = d.variant.KeyFunc()(passwordBytes, d.salt, d.n(), d.r, d.p, len(d.key)); err != nil {
+	if key, err = d.derive(passwordBytes, len(d.key)); err != nil {
 		return false, err
 	}
 
@@ -54,7 +93,19 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode returns the encoded form of this scrypt.Digest.
 func (d *Digest) Encode() string {
-	return d.variant.Encode(d.ln, d.r, d.p, d.salt, d.key)
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this scrypt.Digest without the storage prefix.
+func (d *Digest) encode() string {
+	if d.variant == VariantFirebase {
+		return fmt.Sprintf(EncodingFmtFirebase, d.variant.Prefix(), d.ln, d.r, d.p,
+			base64.RawStdEncoding.EncodeToString(d.saltSeparator), base64.RawStdEncoding.EncodeToString(d.signerKey),
+			base64.RawStdEncoding.EncodeToString(d.salt), base64.RawStdEncoding.EncodeToString(d.key),
+		)
+	}
+
+	return d.variant.encode(d.setting(), d.salt, d.key)
 }
 
 // String returns the storable format of the scrypt.Digest encoded hash.
@@ -72,6 +123,60 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the scrypt.Variant of this scrypt.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// LN returns the log2 of the CPU/memory cost parameter N used to generate this digest.
+func (d *Digest) LN() (ln int) {
+	return d.ln
+}
+
+// R returns the block size parameter used to generate this digest.
+func (d *Digest) R() (r int) {
+	return d.r
+}
+
+// P returns the parallelism parameter used to generate this digest.
+func (d *Digest) P() (p int) {
+	return d.p
+}
+
+// Canonical returns a copy of this scrypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
+// derive the key for this scrypt.Digest using the password bytes.
+func (d *Digest) derive(passwordBytes []byte, keyLen int) (key []byte, err error) {
+	switch d.variant {
+	case VariantYescrypt:
+		var nrom int
+
+		if d.lnROM != 0 {
+			nrom = 1 << d.lnROM
+		}
+
+		return yescrypt.Key(passwordBytes, d.salt, d.flags, d.n(), d.r, d.p, d.t, nrom, d.g, keyLen)
+	case VariantGostYescrypt:
+		return gostYescrypt(passwordBytes, d.salt, d.setting())
+	case VariantFirebase:
+		return firebaseKey(passwordBytes, d.salt, d.saltSeparator, d.signerKey, d.ln, d.r, d.p)
+	default:
+		return d.variant.KeyFunc()(passwordBytes, d.salt, d.n(), d.r, d.p, keyLen)
+	}
+}
+
+// setting returns the yescrypt.Setting for this scrypt.Digest.
+func (d *Digest) setting() (setting yescrypt.Setting) {
+	return yescrypt.Setting{Flags: d.flags, LN: d.ln, R: d.r, P: d.p, T: d.t, G: d.g, LNROM: d.lnROM}
+}
+
 // n returns 2 to the power of log N i.e d.ln.
 func (d *Digest) n() (n int) {
 	return 1 << d.ln
@@ -79,7 +184,7 @@ func (d *Digest) n() (n int) {
 
 func (d *Digest) defaults() {
 	switch d.variant {
-	case VariantScrypt, VariantYescrypt:
+	case VariantScrypt, VariantYescrypt, VariantScryptCrypt, VariantGostYescrypt, VariantFirebase:
 		break
 	default:
 		d.variant = variantDefault
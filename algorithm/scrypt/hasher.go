@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/internal/random"
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
 // scrypt RFC7914: https://www.rfc-editor.org/rfc/rfc7914.html.
@@ -38,11 +39,31 @@ func NewScrypt(opts ...Opt) (hasher *Hasher, err error) {
 }
 
 func NewYescrypt(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantYescrypt, opts...)
+}
+
+// NewScryptCrypt returns a new scrypt.Hasher with the provided functional options applied which produces digests
+// using the libxcrypt '$7$' encoding.
+func NewScryptCrypt(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantScryptCrypt, opts...)
+}
+
+// NewGostYescrypt returns a new scrypt.Hasher with the provided functional options applied which produces
+// gost-yescrypt digests using the libxcrypt '$gy$' encoding.
+func NewGostYescrypt(opts ...Opt) (hasher *Hasher, err error) {
+	return newVariant(VariantGostYescrypt, opts...)
+}
+
+func newVariant(variant Variant, opts ...Opt) (hasher *Hasher, err error) {
 	if hasher, err = New(opts...); err != nil {
 		return nil, err
 	}
 
-	if err = hasher.WithOptions(WithVariant(VariantYescrypt)); err != nil {
+	if err = hasher.WithOptions(WithVariant(variant)); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -55,6 +76,10 @@ type Hasher struct {
 
 	ln, r, k, p, bytesSalt int
 
+	// The following are only used by the scrypt.VariantYescrypt and scrypt.VariantGostYescrypt variants.
+	flags, t int
+	hasFlags bool
+
 	d bool
 }
 
@@ -109,6 +134,11 @@ func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Di
 		return nil, fmt.Errorf("%w: salt bytes must have a length of between %d and %d but has a length of %d", algorithm.ErrSaltInvalid, SaltLengthMin, SaltLengthMax, len(salt))
 	}
 
+	if h.variant == VariantScryptCrypt {
+		// The libxcrypt scrypt variant uses the salt in its encoded form.
+		salt = yescrypt.Encode64(salt)
+	}
+
 	d := &Digest{
 		variant: h.variant,
 		ln:      h.ln,
@@ -119,7 +149,12 @@ func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Di
 
 	d.defaults()
 
-	if d.key, err = d.variant.KeyFunc()([]byte(password), d.salt, d.n(), d.r, d.p, h.k); err != nil {
+	switch d.variant {
+	case VariantYescrypt, VariantGostYescrypt:
+		d.flags, d.t = h.yescryptFlags(), h.t
+	}
+
+	if d.key, err = d.derive([]byte(password), h.k); err != nil {
 		return nil, fmt.Errorf("%w: %v", algorithm.ErrKeyDerivation, err)
 	}
 
@@ -150,6 +185,13 @@ func (h *Hasher) Validate() (err error) {
 }
 
 func (h *Hasher) validate() (err error) {
+	switch h.variant {
+	case VariantYescrypt, VariantGostYescrypt:
+		if err = h.validateYescrypt(); err != nil {
+			return err
+		}
+	}
+
 	rp := uint64(h.r) * uint64(h.p)
 
 	if rp >= 1<<30 {
@@ -185,6 +227,33 @@ func (h *Hasher) validate() (err error) {
 	return nil
 }
 
+func (h *Hasher) validateYescrypt() (err error) {
+	flags := h.yescryptFlags()
+
+	if flags == YescryptFlagsScrypt && h.t != TimeMin {
+		return fmt.Errorf("%w: parameter 't' must be %d when the yescrypt flags are %d but it is set to '%d'", algorithm.ErrParameterInvalid, TimeMin, flags, h.t)
+	}
+
+	if h.ln != 0 && (h.ln < yescryptIterationsMin || h.ln > yescryptIterationsMax) {
+		return fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", yescryptIterationsMin, "", yescryptIterationsMax, h.ln)
+	}
+
+	if flags&yescrypt.FlagRW != 0 && h.ln != 0 && h.p > 0 && (1<<h.ln)/h.p <= 3 {
+		return fmt.Errorf("%w: parameter 'ln' when raised to the power of 2 and divided by parameter 'p' must be greater than 3 but it is '%d'", algorithm.ErrParameterInvalid, (1<<h.ln)/h.p)
+	}
+
+	return nil
+}
+
+// yescryptFlags returns the configured yescrypt flags or the default yescrypt flags if they're not configured.
+func (h *Hasher) yescryptFlags() (flags int) {
+	if h.hasFlags {
+		return h.flags
+	}
+
+	return YescryptFlagsDefault
+}
+
 func (h *Hasher) defaults() {
 	if h.d {
 		return
@@ -2,8 +2,10 @@ package scrypt
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
 // Opt describes the functional option pattern for the scrypt.Hasher.
@@ -16,7 +18,7 @@ func WithVariant(variant Variant) Opt {
 		switch variant {
 		case VariantNone:
 			return nil
-		case VariantScrypt, VariantYescrypt:
+		case VariantScrypt, VariantYescrypt, VariantScryptCrypt, VariantGostYescrypt:
 			h.variant = variant
 
 			return nil
@@ -36,7 +38,7 @@ func WithVariantName(identifier string) Opt {
 
 		variant := NewVariant(identifier)
 
-		if variant == VariantNone {
+		if variant == VariantNone || variant == VariantFirebase {
 			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: variant identifier '%s' is invalid", algorithm.ErrParameterInvalid, identifier))
 		}
 
@@ -85,7 +87,9 @@ func WithSaltLength(s int) Opt {
 }
 
 // WithLN sets the ln parameter (logN) of the resulting scrypt.Digest.
-// Minimum is 1, Maximum is 58. Default is 16.
+// Minimum is 1, Maximum is 58. Default is 16. When r is set the hasher validation further limits 2^ln to
+// math.MaxInt / 128 / r which is a maximum of 52 with an r of 8 on 64-bit platforms, and the yescrypt variants limit ln
+// to between 2 and 31.
 func WithLN(ln int) Opt {
 	return func(h *Hasher) (err error) {
 		if ln < IterationsMin || ln > IterationsMax {
@@ -135,3 +139,54 @@ func WithP(p int) Opt {
 func WithParallelism(p int) Opt {
 	return WithP(p)
 }
+
+// WithN sets the N parameter (iterations) of the resulting scrypt.Digest. This is an alternative to WithLN and the
+// value must be a power of 2. Minimum is 2, Maximum is 2^58. Default is 65536. When r is set the hasher validation
+// further limits N to math.MaxInt / 128 / r which is a maximum of 2^52 with an r of 8 on 64-bit platforms, and the
+// yescrypt variants limit N to between 2^2 and 2^31.
+func WithN(n int) Opt {
+	return func(h *Hasher) (err error) {
+		if n < 1<<IterationsMin || n&(n-1) != 0 || bits.Len(uint(n))-1 > IterationsMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: parameter 'N' must be a power of 2 between %d and %d but is set to '%d'", algorithm.ErrParameterInvalid, 1<<IterationsMin, uint64(1)<<IterationsMax, n))
+		}
+
+		h.ln = bits.Len(uint(n)) - 1
+
+		return nil
+	}
+}
+
+// WithFlags sets the yescrypt flags of the resulting scrypt.Digest. This option only applies to the
+// scrypt.VariantYescrypt and scrypt.VariantGostYescrypt variants. The supported values are scrypt.YescryptFlagsScrypt, scrypt.YescryptFlagsWORM, and
+// scrypt.YescryptFlagsDefault. Default is scrypt.YescryptFlagsDefault.
+func WithFlags(flags int) Opt {
+	return func(h *Hasher) (err error) {
+		if err = yescrypt.ValidateFlags(flags); err != nil {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: flags '%d' are not supported", algorithm.ErrParameterInvalid, flags))
+		}
+
+		h.flags, h.hasFlags = flags, true
+
+		return nil
+	}
+}
+
+// WithT sets the yescrypt time parameter of the resulting scrypt.Digest. This option only applies to the
+// scrypt.VariantYescrypt and scrypt.VariantGostYescrypt variants and must be 0 when the flags are scrypt.YescryptFlagsScrypt.
+// Minimum is 0, Maximum is 2147483647. Default is 0.
+func WithT(t int) Opt {
+	return func(h *Hasher) (err error) {
+		if t < TimeMin || t > TimeMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "time", TimeMin, "", TimeMax, t))
+		}
+
+		h.t = t
+
+		return nil
+	}
+}
+
+// WithTime is an alias for WithT.
+func WithTime(t int) Opt {
+	return WithT(t)
+}
@@ -1,11 +1,15 @@
 package scrypt
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
 func TestNewVariant(t *testing.T) {
@@ -17,6 +21,11 @@ func TestNewVariant(t *testing.T) {
 		{"ShouldReturnScrypt", "scrypt", VariantScrypt},
 		{"ShouldReturnYescrypt", "yescrypt", VariantYescrypt},
 		{"ShouldReturnYescryptForY", "y", VariantYescrypt},
+		{"ShouldReturnScryptCrypt", "scrypt-crypt", VariantScryptCrypt},
+		{"ShouldReturnScryptCryptFor7", "7", VariantScryptCrypt},
+		{"ShouldReturnGostYescrypt", "gost-yescrypt", VariantGostYescrypt},
+		{"ShouldReturnGostYescryptForGY", "gy", VariantGostYescrypt},
+		{"ShouldReturnFirebase", "firebase-scrypt", VariantFirebase},
 		{"ShouldReturnNoneForUnknown", "unknown", VariantNone},
 		{"ShouldReturnNoneForEmpty", "", VariantNone},
 	}
@@ -36,6 +45,9 @@ func TestVariantString(t *testing.T) {
 	}{
 		{"ShouldReturnScrypt", VariantScrypt, "scrypt"},
 		{"ShouldReturnYescrypt", VariantYescrypt, "y"},
+		{"ShouldReturnScryptCrypt", VariantScryptCrypt, "7"},
+		{"ShouldReturnGostYescrypt", VariantGostYescrypt, "gy"},
+		{"ShouldReturnFirebase", VariantFirebase, "firebase-scrypt"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -54,6 +66,8 @@ func TestVariantPrefix(t *testing.T) {
 	}{
 		{"ShouldReturnScryptPrefix", VariantScrypt, "scrypt"},
 		{"ShouldReturnYescryptPrefix", VariantYescrypt, "y"},
+		{"ShouldReturnScryptCryptPrefix", VariantScryptCrypt, "7"},
+		{"ShouldReturnGostYescryptPrefix", VariantGostYescrypt, "gy"},
 		{"ShouldReturnEmptyForNone", VariantNone, ""},
 	}
 
@@ -72,6 +86,9 @@ func TestVariantKeyFunc(t *testing.T) {
 	}{
 		{"ShouldReturnScryptKeyFunc", VariantScrypt, false},
 		{"ShouldReturnYescryptKeyFunc", VariantYescrypt, false},
+		{"ShouldReturnScryptCryptKeyFunc", VariantScryptCrypt, false},
+		{"ShouldReturnGostYescryptKeyFunc", VariantGostYescrypt, false},
+		{"ShouldReturnFirebaseKeyFunc", VariantFirebase, false},
 		{"ShouldReturnNilForNone", VariantNone, true},
 	}
 
@@ -96,7 +113,10 @@ func TestWithVariant(t *testing.T) {
 	}{
 		{"ShouldNotErrScrypt", VariantScrypt, ""},
 		{"ShouldNotErrYescrypt", VariantYescrypt, ""},
+		{"ShouldNotErrScryptCrypt", VariantScryptCrypt, ""},
+		{"ShouldNotErrGostYescrypt", VariantGostYescrypt, ""},
 		{"ShouldNotErrNone", VariantNone, ""},
+		{"ShouldErrFirebase", VariantFirebase, "scrypt validation error: parameter is invalid: variant '5' is invalid"},
 		{"ShouldErrInvalid", Variant(99), "scrypt validation error: parameter is invalid: variant '99' is invalid"},
 	}
 
@@ -122,6 +142,7 @@ func TestWithVariantName(t *testing.T) {
 	}{
 		{"ShouldNotErrScrypt", "scrypt", ""},
 		{"ShouldNotErrEmpty", "", ""},
+		{"ShouldErrFirebase", "firebase-scrypt", "scrypt validation error: parameter is invalid: variant identifier 'firebase-scrypt' is invalid"},
 		{"ShouldErrInvalid", "invalid", "scrypt validation error: parameter is invalid: variant identifier 'invalid' is invalid"},
 	}
 
@@ -463,3 +484,466 @@ func TestDigestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestWithN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     int
+		expected int
+		err      string
+	}{
+		{"ShouldSetMin", 2, 1, ""},
+		{"ShouldSetPowerOfTwo", 4096, 12, ""},
+		{"ShouldErrNotPowerOfTwo", 4000, 0, "scrypt validation error: parameter is invalid: parameter 'N' must be a power of 2 between 2 and 288230376151711744 but is set to '4000'"},
+		{"ShouldErrTooSmall", 1, 0, "scrypt validation error: parameter is invalid: parameter 'N' must be a power of 2 between 2 and 288230376151711744 but is set to '1'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+
+			err := WithN(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, h.ln)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithNValidationMaximum(t *testing.T) {
+	if strconv.IntSize != 64 {
+		t.Skip("the maximum only applies to 64-bit platforms")
+	}
+
+	_, err := New(WithN(1<<52), WithR(8), WithP(1))
+	assert.NoError(t, err)
+
+	_, err = New(WithN(1<<53), WithR(8), WithP(1))
+	assert.EqualError(t, err, "scrypt validation error: parameter is invalid: parameter 'ln' when raised to the power of 2 must be less than or equal to 9007199254740991 (72057594037927935 / r) but it is set to '53' which is equal to '9007199254740992'")
+}
+
+func TestWithFlags(t *testing.T) {
+	testCases := []struct {
+		name string
+		have int
+		err  string
+	}{
+		{"ShouldNotErrScrypt", YescryptFlagsScrypt, ""},
+		{"ShouldNotErrWORM", YescryptFlagsWORM, ""},
+		{"ShouldNotErrDefault", YescryptFlagsDefault, ""},
+		{"ShouldErrUnsupportedFlavor", 2, "scrypt validation error: parameter is invalid: flags '2' are not supported"},
+		{"ShouldErrUnsupportedMode", 3, "scrypt validation error: parameter is invalid: flags '3' are not supported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+
+			err := WithFlags(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.have, h.yescryptFlags())
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithT(t *testing.T) {
+	testCases := []struct {
+		name string
+		have int
+		err  string
+	}{
+		{"ShouldNotErrMin", 0, ""},
+		{"ShouldNotErrValue", 4, ""},
+		{"ShouldErrNegative", -1, "scrypt validation error: parameter is invalid: parameter 'time' must be between 0 and 2147483647 but is set to '-1'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hasher{}
+
+			err := WithT(tc.have)(h)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.have, h.t)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+
+	h := &Hasher{}
+
+	assert.NoError(t, WithTime(2)(h))
+	assert.Equal(t, 2, h.t)
+}
+
+func TestNewYescryptValidation(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Opt
+		err  string
+	}{
+		{"ShouldErrScryptFlagsWithTime", []Opt{WithFlags(YescryptFlagsScrypt), WithT(1)}, "scrypt validation error: parameter is invalid: parameter 't' must be 0 when the yescrypt flags are 0 but it is set to '1'"},
+		{"ShouldErrIterationsTooLarge", []Opt{WithLN(32)}, "scrypt validation error: parameter is invalid: parameter 'iterations' must be between 2 and 31 but is set to '32'"},
+		{"ShouldErrIterationsTooSmallForParallelism", []Opt{WithLN(4), WithP(8)}, "scrypt validation error: parameter is invalid: parameter 'ln' when raised to the power of 2 and divided by parameter 'p' must be greater than 3 but it is '2'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewYescrypt(tc.opts...)
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestYescryptDecodeEncode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		have          string
+		password      string
+		flags, ln     int
+		r, p, tc, rom int
+		err           string
+	}{
+		{"ShouldDecodeDefault", "$y$j75$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J.", "password", YescryptFlagsDefault, 10, 8, 1, 0, 0, ""},
+		{"ShouldDecodeParallelism", "$y$j75..$saltsaltsaltsalt$m89vc6HyWxsfkkH188K53LeyR7/KCYBs.jfnq610747", "password", YescryptFlagsDefault, 10, 8, 2, 0, 0, ""},
+		{"ShouldDecodeTime", "$y$j75/.$saltsaltsaltsalt$sFYLocQqUtAxuWjBNStLIcLA4LRy9tBVhDBqtZfP/a5", "password", YescryptFlagsDefault, 10, 8, 1, 1, 0, ""},
+		{"ShouldDecodeParallelismAndTime", "$y$j750//$saltsaltsaltsalt$E.bpTc857WEVvUt4el8pJg8f.Swhxr4xLSauXF3jnX6", "password", YescryptFlagsDefault, 10, 8, 3, 2, 0, ""},
+		{"ShouldDecodeWORM", "$y$/75$saltsaltsaltsalt$ajDS8nt1YQa5qYzZoBxnzBW5fej7mTblixS1htlz7L4", "password", YescryptFlagsWORM, 10, 8, 1, 0, 0, ""},
+		{"ShouldDecodeScrypt", "$y$.75$saltsaltsaltsalt$htRE.RgnnyJvLmIBls.xMqAJWqQLqZrpM8.Zksar5H.", "password", YescryptFlagsScrypt, 10, 8, 1, 0, 0, ""},
+		{"ShouldDecodeROM", "$y$j755H$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J.", "password", YescryptFlagsDefault, 10, 8, 1, 0, 20, "scrypt match error: yescrypt: ROM is not supported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, tc.flags, d.flags)
+			assert.Equal(t, tc.ln, d.ln)
+			assert.Equal(t, tc.r, d.r)
+			assert.Equal(t, tc.p, d.p)
+			assert.Equal(t, tc.tc, d.t)
+			assert.Equal(t, tc.rom, d.lnROM)
+			assert.Equal(t, tc.have, d.Encode())
+
+			match, err := d.MatchAdvanced(tc.password)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.True(t, match)
+				assert.False(t, d.Match("wrong"))
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.False(t, match)
+			}
+		})
+	}
+}
+
+func TestYescryptDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldFailBadSetting", "$y$j7$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J.", "scrypt decode error: provided encoded hash has an invalid option: yescrypt: bad setting"},
+		{"ShouldFailBadSalt", "$y$j75$ab$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J.", "scrypt decode error: provided encoded hash has a salt value that can't be decoded: salt is not valid yescrypt base64"},
+		{"ShouldFailBadKey", "$y$j75$saltsaltsaltsalt$!!", "scrypt decode error: provided encoded hash has a key value that can't be decoded: key is not valid yescrypt base64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.have)
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestHashYescryptParameters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Opt
+		expected string
+	}{
+		{"ShouldHashDefault", []Opt{WithLN(10), WithR(8), WithP(1)}, "$y$j75$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J."},
+		{"ShouldHashN", []Opt{WithN(1024), WithR(8), WithP(1)}, "$y$j75$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J."},
+		{"ShouldHashParallelismAndTime", []Opt{WithLN(10), WithR(8), WithP(3), WithT(2)}, "$y$j750//$saltsaltsaltsalt$E.bpTc857WEVvUt4el8pJg8f.Swhxr4xLSauXF3jnX6"},
+		{"ShouldHashWORM", []Opt{WithLN(10), WithR(8), WithP(1), WithFlags(YescryptFlagsWORM)}, "$y$/75$saltsaltsaltsalt$ajDS8nt1YQa5qYzZoBxnzBW5fej7mTblixS1htlz7L4"},
+		{"ShouldHashScrypt", []Opt{WithLN(10), WithR(8), WithP(1), WithFlags(YescryptFlagsScrypt)}, "$y$.75$saltsaltsaltsalt$htRE.RgnnyJvLmIBls.xMqAJWqQLqZrpM8.Zksar5H."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := NewYescrypt(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt("password", yescrypt.Decode64([]byte("saltsaltsaltsalt")))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, digest.Encode())
+		})
+	}
+}
+
+func TestVariantEncodeYescryptParallelism(t *testing.T) {
+	salt := yescrypt.Decode64([]byte("saltsaltsaltsalt"))
+	key := yescrypt.Decode64([]byte("m89vc6HyWxsfkkH188K53LeyR7/KCYBs.jfnq610747"))
+
+	assert.Equal(t, "$y$j75..$saltsaltsaltsalt$m89vc6HyWxsfkkH188K53LeyR7/KCYBs.jfnq610747", VariantYescrypt.Encode(10, 8, 2, salt, key))
+}
+
+func TestScryptCryptDecodeEncode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		ln, r, p int
+		salt     string
+	}{
+		{"ShouldDecode", "$7$A/..../....saltsalt$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4", 12, 1, 1, "saltsalt"},
+		{"ShouldDecodeParallelism", "$7$A/....0....s$lMxXBgK70ZVye/KvAJXMEU7UT8X77peuKwLC5Y2BTO3", 12, 1, 2, "s"},
+		{"ShouldDecodeBlockSize", "$7$A0..../....saltsaltsaltsaltsalt$HrzFNCYKvJG6IH9Ku3lKwOM//ZagfmupXjztE9Up3.3", 12, 2, 1, "saltsaltsaltsaltsalt"},
+		{"ShouldDecodeEmptySalt", "$7$AU..../....$lSdxqDDAe/D/QhQTxdruBliS4129.1IbXLqGaPuTDZD", 12, 32, 1, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, VariantScryptCrypt, d.variant)
+			assert.Equal(t, tc.ln, d.ln)
+			assert.Equal(t, tc.r, d.r)
+			assert.Equal(t, tc.p, d.p)
+			assert.Equal(t, tc.salt, string(d.Salt()))
+			assert.Equal(t, tc.have, d.Encode())
+
+			assert.True(t, d.Match("password"))
+			assert.False(t, d.Match("wrong"))
+		})
+	}
+}
+
+func TestGostYescryptDecodeEncode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		flags, ln int
+		r, p, tc  int
+	}{
+		{"ShouldDecodeDefault", "$gy$j75$saltsaltsaltsalt$ay0RnPzeHnpJWVwfMGx3wS3yP/c5bVfWY9qMfl56DD8", YescryptFlagsDefault, 10, 8, 1, 0},
+		{"ShouldDecodeParallelism", "$gy$j75..$saltsaltsaltsalt$YwpqnCfQbLTMDqkbEfRSw8TePlSDjE0FN/QXW8Gwfu5", YescryptFlagsDefault, 10, 8, 2, 0},
+		{"ShouldDecodeWORM", "$gy$/75$saltsaltsaltsalt$zTygx2vG5SiydZPpuMeD5SVZXo8AHAiJO6DbbQP4Xs6", YescryptFlagsWORM, 10, 8, 1, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			d, ok := digest.(*Digest)
+			require.True(t, ok)
+
+			assert.Equal(t, VariantGostYescrypt, d.variant)
+			assert.Equal(t, tc.flags, d.flags)
+			assert.Equal(t, tc.ln, d.ln)
+			assert.Equal(t, tc.r, d.r)
+			assert.Equal(t, tc.p, d.p)
+			assert.Equal(t, tc.tc, d.t)
+			assert.Equal(t, tc.have, d.Encode())
+
+			assert.True(t, d.Match("password"))
+			assert.False(t, d.Match("wrong"))
+		})
+	}
+}
+
+func TestScryptCryptAndGostYescryptDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldFailScryptCryptBadSetting", "$7$A/...$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4", "scrypt decode error: provided encoded hash has an invalid option: scrypt: bad setting"},
+		{"ShouldFailScryptCryptZeroLN", "$7$./..../....saltsalt$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4", "scrypt decode error: provided encoded hash has an invalid option: scrypt: bad setting"},
+		{"ShouldFailScryptCryptZeroR", "$7$A...../....saltsalt$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4", "scrypt decode error: provided encoded hash has an invalid option: scrypt: bad setting"},
+		{"ShouldFailScryptCryptBadKey", "$7$A/..../....saltsalt$!!", "scrypt decode error: provided encoded hash has a key value that can't be decoded: key is not valid yescrypt base64"},
+		{"ShouldFailScryptCryptFormat", "$7$A/..../....saltsalt$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4$extra", "scrypt decode error: provided encoded hash has an invalid format"},
+		{"ShouldFailGostYescryptBadSetting", "$gy$j7$saltsaltsaltsalt$ay0RnPzeHnpJWVwfMGx3wS3yP/c5bVfWY9qMfl56DD8", "scrypt decode error: provided encoded hash has an invalid option: yescrypt: bad setting"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.have)
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestHashScryptCryptAndGostYescrypt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hasher   func(opts ...Opt) (*Hasher, error)
+		opts     []Opt
+		expected string
+	}{
+		{"ShouldHashScryptCrypt", NewScryptCrypt, []Opt{WithLN(12), WithR(1), WithP(1)}, "$7$A/..../....saltsaltsaltsalt$YK05bE6ZOQnC11ZZ9EPRLmQ8gw0G66WlQkFYJm5AB1A"},
+		{"ShouldHashGostYescrypt", NewGostYescrypt, []Opt{WithLN(10), WithR(8), WithP(1)}, "$gy$j75$saltsaltsaltsalt$ay0RnPzeHnpJWVwfMGx3wS3yP/c5bVfWY9qMfl56DD8"},
+		{"ShouldHashGostYescryptWORM", NewGostYescrypt, []Opt{WithLN(10), WithR(8), WithP(1), WithFlags(YescryptFlagsWORM)}, "$gy$/75$saltsaltsaltsalt$zTygx2vG5SiydZPpuMeD5SVZXo8AHAiJO6DbbQP4Xs6"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := tc.hasher(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.HashWithSalt("password", yescrypt.Decode64([]byte("saltsaltsaltsalt")))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, digest.Encode())
+
+			decoded, err := Decode(digest.Encode())
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match("password"))
+		})
+	}
+}
+
+func TestFirebase(t *testing.T) {
+	decode := func(s string) []byte {
+		value, err := base64.StdEncoding.DecodeString(s)
+		require.NoError(t, err)
+
+		return value
+	}
+
+	digest, err := NewFirebaseDigest(
+		decode("lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ=="),
+		decode("42xEC+ixf3L2lw=="),
+		decode("jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA=="),
+		decode("Bw=="),
+		8, 14,
+	)
+	require.NoError(t, err)
+
+	encoded := "$firebase-scrypt$ln=14,r=8,p=1,ss=Bw,sk=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA$42xEC+ixf3L2lw$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ"
+
+	assert.Equal(t, encoded, digest.Encode())
+	assert.True(t, digest.Match("user1password"))
+	assert.False(t, digest.Match("user2password"))
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, digest, decoded)
+	assert.True(t, decoded.Match("user1password"))
+	assert.False(t, decoded.Match("user2password"))
+}
+
+func TestFirebaseErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldFailNoSignerKey", "$firebase-scrypt$ln=14,r=8,p=1,ss=Bw$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option: option 'sk' is required"},
+		{"ShouldFailKeyLength", "$firebase-scrypt$ln=14,r=8,p=1,ss=Bw,sk=jxspr8Ki0RYy$42xEC+ixf3L2lw$lSrfV15cpx95/sZS", "scrypt decode error: provided encoded hash has a key value that can't be decoded: key has 12 bytes but must have the same length as the signer key which has 9 bytes"},
+		{"ShouldFailMemCost", "$firebase-scrypt$ln=30,r=8,p=1,ss=Bw,sk=jxspr8Ki0RYy$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option value: parameter 'ln' must be between 1 and 14 but is set to '30'"},
+		{"ShouldFailRounds", "$firebase-scrypt$ln=14,r=9,p=1,ss=Bw,sk=jxspr8Ki0RYy$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option value: parameter 'r' must be between 1 and 8 but is set to '9'"},
+		{"ShouldFailParallelism", "$firebase-scrypt$ln=14,r=8,p=2,ss=Bw,sk=jxspr8Ki0RYy$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option value: parameter 'p' must be between 1 and 1 but is set to '2'"},
+		{"ShouldFailSignerKeyEncoding", "$firebase-scrypt$ln=14,r=8,p=1,sk=!!$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option value: option 'sk' has invalid value '!!': illegal base64 data at input byte 0"},
+		{"ShouldFailScryptSignerKey", "$scrypt$ln=14,r=8,p=1,sk=jxspr8Ki0RYy$42xEC+ixf3L2lw$lSrfV15cpx95", "scrypt decode error: provided encoded hash has an invalid option key: option 'sk' with value 'jxspr8Ki0RYy' is unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.have)
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestNewFirebaseDigestErrors(t *testing.T) {
+	key := make([]byte, 64)
+
+	testCases := []struct {
+		name            string
+		hash, signerKey []byte
+		rounds, memCost int
+		err             string
+	}{
+		{"ShouldFailNoKey", nil, key, 8, 14, "scrypt decode error: provided encoded hash has a key value that can't be decoded: key has 0 bytes"},
+		{"ShouldFailNoSignerKey", key, nil, 8, 14, "scrypt decode error: provided encoded hash has an invalid option value: signer key has 0 bytes"},
+		{"ShouldFailKeyLength", key[:32], key, 8, 14, "scrypt decode error: provided encoded hash has a key value that can't be decoded: key has 32 bytes but must have the same length as the signer key which has 64 bytes"},
+		{"ShouldFailRounds", key, key, 9, 14, "scrypt decode error: provided encoded hash has an invalid option value: parameter 'rounds' must be between 1 and 8 but is set to '9'"},
+		{"ShouldFailMemCost", key, key, 8, 15, "scrypt decode error: provided encoded hash has an invalid option value: parameter 'mem_cost' must be between 1 and 14 but is set to '15'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := NewFirebaseDigest(tc.hash, []byte("salt"), tc.signerKey, []byte{0x07}, tc.rounds, tc.memCost)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestNewDigest(t *testing.T) {
+	hasher, err := New(WithLN(10), WithR(8), WithP(1))
+	require.NoError(t, err)
+
+	hashed, err := hasher.Hash("password")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantScrypt, 10, 8, 1, hashed.Salt(), hashed.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, hashed.Encode(), digest.Encode())
+	assert.True(t, digest.Match("password"))
+
+	testCases := []struct {
+		name     string
+		variant  Variant
+		ln, r, p int
+		key      []byte
+		err      string
+	}{
+		{"ShouldErrVariant", VariantYescrypt, 10, 8, 1, []byte("key"), "scrypt decode error: parameter is invalid: variant must be either the 'scrypt' or '7' variant"},
+		{"ShouldErrLN", VariantScrypt, 0, 8, 1, []byte("key"), "scrypt decode error: parameter is invalid: parameter 'ln' must be between 1 and 58 but is set to '0'"},
+		{"ShouldErrR", VariantScrypt, 10, 0, 1, []byte("key"), "scrypt decode error: parameter is invalid: parameter 'r' must be between 1 and 36028797018963967 but is set to '0'"},
+		{"ShouldErrP", VariantScrypt, 10, 8, 0, []byte("key"), "scrypt decode error: parameter is invalid: parameter 'p' must be between 1 and 1073741823 but is set to '0'"},
+		{"ShouldErrRP", VariantScrypt, 10, 1 << 15, 1 << 15, []byte("key"), "scrypt decode error: parameter is invalid: parameters 'r' and 'p' must be less than 1073741824 when multiplied but they are '1073741824'"},
+		{"ShouldErrKey", VariantScrypt, 10, 8, 1, nil, "scrypt decode error: parameter is invalid: key has 0 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := NewDigest(tc.variant, tc.ln, tc.r, tc.p, []byte("saltsalt"), tc.key)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package scrypt
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
 	"encoding/base64"
 	"fmt"
+	"math/bits"
 
 	"github.com/go-crypt/x/scrypt"
-	"github.com/go-crypt/x/yescrypt"
+
+	"github.com/go-crypt/crypt/internal/streebog"
+	"github.com/go-crypt/crypt/internal/yescrypt"
 )
 
 // NewVariant converts an identifier string to a scrypt.Variant.
@@ -15,6 +21,12 @@ func NewVariant(identifier string) (variant Variant) {
 		return VariantScrypt
 	case AlgNameYescrypt, AlgIdentifierYescrypt:
 		return VariantYescrypt
+	case AlgNameScryptCrypt, AlgIdentifierScryptCrypt:
+		return VariantScryptCrypt
+	case AlgNameGostYescrypt, AlgIdentifierGostYescrypt:
+		return VariantGostYescrypt
+	case AlgIdentifierFirebase:
+		return VariantFirebase
 	default:
 		return VariantNone
 	}
@@ -30,6 +42,17 @@ const (
 	VariantScrypt
 
 	VariantYescrypt
+
+	// VariantScryptCrypt is the libxcrypt '$7$' encoding of scrypt.
+	VariantScryptCrypt
+
+	// VariantGostYescrypt is the libxcrypt '$gy$' variant of yescrypt which wraps the yescrypt key using the
+	// GOST R 34.11-2012 (Streebog) HMAC.
+	VariantGostYescrypt
+
+	// VariantFirebase is the Firebase Authentication modified scrypt which encrypts the project signer key with
+	// AES-256-CTR using the scrypt key. This variant can only be decoded and verified.
+	VariantFirebase
 )
 
 // String implements the fmt.Stringer returning a string representation of the scrypt.Variant.
@@ -39,6 +62,12 @@ func (v Variant) String() (variant string) {
 		return AlgIdentifier
 	case VariantYescrypt:
 		return AlgIdentifierYescrypt
+	case VariantScryptCrypt:
+		return AlgIdentifierScryptCrypt
+	case VariantGostYescrypt:
+		return AlgIdentifierGostYescrypt
+	case VariantFirebase:
+		return AlgIdentifierFirebase
 	default:
 		return
 	}
@@ -49,25 +78,39 @@ func (v Variant) Prefix() (prefix string) {
 	return v.String()
 }
 
-// KeyFunc returns the internal HMAC algorithm.HashFunc.
+// KeyFunc returns the internal HMAC algorithm.HashFunc. The scrypt.VariantFirebase variant returns the scrypt KeyFunc
+// used to derive the key which encrypts the signer key.
 func (v Variant) KeyFunc() KeyFunc {
 	switch v {
-	case VariantScrypt:
+	case VariantScrypt, VariantScryptCrypt, VariantFirebase:
 		return scrypt.Key
 	case VariantYescrypt:
-		return yescrypt.Key
+		return yescryptKey
+	case VariantGostYescrypt:
+		return gostYescryptKey
 	default:
 		return nil
 	}
 }
 
-// Encode formats the variant encoded bcrypt.Digest.
+// Encode formats the variant encoded scrypt.Digest. The scrypt.VariantYescrypt and scrypt.VariantGostYescrypt variants
+// are encoded with the scrypt.YescryptFlagsDefault flags. The scrypt.VariantScryptCrypt variant uses the salt as is
+// as the salt is used in its encoded form. The scrypt.VariantFirebase variant can't be encoded this way as it requires
+// the salt separator and signer key.
 func (v Variant) Encode(ln, r, p int, salt, key []byte) (f string) {
+	return v.encode(yescrypt.Setting{Flags: YescryptFlagsDefault, LN: ln, R: r, P: p}, salt, key)
+}
+
+func (v Variant) encode(setting yescrypt.Setting, salt, key []byte) (f string) {
+	ln, r, p := setting.LN, setting.R, setting.P
+
 	switch v {
 	case VariantScrypt:
 		return fmt.Sprintf(EncodingFmt, v.Prefix(), ln, r, p, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
-	case VariantYescrypt:
-		return fmt.Sprintf(EncodingFmtYescrypt, v.Prefix(), yescrypt.EncodeSetting(0, ln, r), yescrypt.Encode64(salt), yescrypt.Encode64(key))
+	case VariantYescrypt, VariantGostYescrypt:
+		return fmt.Sprintf(EncodingFmtYescrypt, v.Prefix(), setting.Encode(), yescrypt.Encode64(salt), yescrypt.Encode64(key))
+	case VariantScryptCrypt:
+		return fmt.Sprintf(EncodingFmtScryptCrypt, v.Prefix(), yescrypt.EncodeScryptSetting(setting), salt, yescrypt.Encode64(key))
 	default:
 		return
 	}
@@ -75,3 +118,64 @@ func (v Variant) Encode(ln, r, p int, salt, key []byte) (f string) {
 
 // KeyFunc represents the KeyFunc used by scrypt implementations.
 type KeyFunc func(password []byte, salt []byte, N int, r int, p int, keyLen int) (key []byte, err error)
+
+// yescryptKey is the KeyFunc for the scrypt.VariantYescrypt which uses the scrypt.YescryptFlagsDefault flags.
+func yescryptKey(password []byte, salt []byte, N int, r int, p int, keyLen int) (key []byte, err error) {
+	return yescrypt.Key(password, salt, YescryptFlagsDefault, N, r, p, TimeDefault, 0, 0, keyLen)
+}
+
+// gostYescryptKey is the KeyFunc for the scrypt.VariantGostYescrypt which uses the scrypt.YescryptFlagsDefault flags.
+// The key length is ignored as the key is always 32 bytes.
+func gostYescryptKey(password []byte, salt []byte, N int, r int, p int, keyLen int) (key []byte, err error) {
+	setting := yescrypt.Setting{Flags: YescryptFlagsDefault, LN: bits.Len(uint(N)) - 1, R: r, P: p}
+
+	return gostYescrypt(password, salt, setting)
+}
+
+// gostYescrypt computes the gost-yescrypt key. The yescrypt key is computed from the password and then used as the
+// message for a HMAC-Streebog-256 keyed with a HMAC-Streebog-256 of the setting string which is itself keyed with the
+// Streebog-256 hash of the password.
+func gostYescrypt(password []byte, salt []byte, setting yescrypt.Setting) (key []byte, err error) {
+	var nrom int
+
+	if setting.LNROM != 0 {
+		nrom = 1 << setting.LNROM
+	}
+
+	if key, err = yescrypt.Key(password, salt, setting.Flags, 1<<setting.LN, setting.R, setting.P, setting.T, nrom, setting.G, gostYescryptKeyLength); err != nil {
+		return nil, err
+	}
+
+	hk := streebog.Sum256(password)
+
+	h := hmac.New(streebog.New256, hk[:])
+	h.Write([]byte(fmt.Sprintf("$%s$%s$%s", AlgIdentifierGostYescrypt, setting.Encode(), yescrypt.Encode64(salt))))
+
+	h = hmac.New(streebog.New256, h.Sum(nil))
+	h.Write(key)
+
+	return h.Sum(nil), nil
+}
+
+// firebaseKey computes the Firebase modified scrypt key. The scrypt key is computed from the password and the salt
+// followed by the salt separator and then used as the AES-256 key to encrypt the signer key in CTR mode with a zero IV.
+func firebaseKey(password, salt, saltSeparator, signerKey []byte, ln, r, p int) (key []byte, err error) {
+	s := make([]byte, 0, len(salt)+len(saltSeparator))
+	s = append(append(s, salt...), saltSeparator...)
+
+	if key, err = scrypt.Key(password, s, 1<<ln, r, p, firebaseKeyLength); err != nil {
+		return nil, err
+	}
+
+	var block cipher.Block
+
+	if block, err = aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+
+	key = make([]byte, len(signerKey))
+
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(key, signerKey)
+
+	return key, nil
+}
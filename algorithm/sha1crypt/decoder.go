@@ -3,9 +3,11 @@ package sha1crypt
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -43,8 +45,26 @@ func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	return digest, nil
 }
 
+// NewDigest returns a sha1crypt.Digest from the raw parameters, salt, and key which is useful when the values of a
+// digest are not stored in the encoded format. The salt and key are in the same form as returned by the Salt and Key
+// methods.
+func NewDigest(iterations uint32, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case len(salt) > SaltLengthMax:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt has %d bytes but must have at most %d bytes", algorithm.ErrParameterInvalid, len(salt), SaltLengthMax))
+	case strings.ContainsRune(string(salt), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	case strings.ContainsRune(string(key), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	}
+
+	return &Digest{iterations: iterations, salt: salt, key: key}, nil
+}
+
 func decoderParts(encodedDigest string) (parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+	parts = phc.Split(encodedDigest, -1)
 
 	if len(parts) != 5 {
 		return nil, algorithm.ErrEncodedHashInvalidFormat
@@ -16,6 +16,8 @@ type Digest struct {
 	i bool
 
 	salt, key []byte
+
+	algorithm.Prefix
 }
 
 // Match returns true if the string password matches the current sha1crypt.Digest.
@@ -46,6 +48,11 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode returns the encoded form of this sha1crypt.Digest.
 func (d *Digest) Encode() string {
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this sha1crypt.Digest without the storage prefix.
+func (d *Digest) encode() string {
 	return fmt.Sprintf(EncodingFmt,
 		d.iterations, d.salt, d.key,
 	)
@@ -66,6 +73,15 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Canonical returns a copy of this sha1crypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
 func (d *Digest) defaults() {
 	if !d.i {
 		d.iterations = IterationsDefault
@@ -227,3 +227,21 @@ func TestDigestEncode(t *testing.T) {
 	assert.NotEmpty(t, encoded)
 	assert.Equal(t, encoded, digest.String())
 }
+
+func TestNewDigest(t *testing.T) {
+	digest, err := NewDigest(480000, []byte("salt"), []byte("key"))
+	require.NoError(t, err)
+
+	decoded, err := Decode(digest.Encode())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(480000, make([]byte, SaltLengthMax+1), []byte("key"))
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "sha1crypt decode error: parameter is invalid: salt has 65 bytes but must have at most 64 bytes")
+
+	digest, err = NewDigest(480000, []byte("salt"), nil)
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "sha1crypt decode error: parameter is invalid: key has 0 bytes")
+}
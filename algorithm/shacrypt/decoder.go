@@ -2,10 +2,13 @@ package shacrypt
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
 // RegisterDecoder the decoder with the algorithm.DecoderRegister.
@@ -69,8 +72,28 @@ func DecodeVariant(v Variant) func(encodedDigest string) (digest algorithm.Diges
 	}
 }
 
+// NewDigest returns a shacrypt.Digest from the raw parameters, salt, and key which is useful when the values of a
+// digest are not stored in the encoded format. The salt and key are in the same form as returned by the Salt and Key
+// methods.
+func NewDigest(variant Variant, iterations int, salt, key []byte) (digest *Digest, err error) {
+	switch {
+	case variant != VariantSHA256 && variant != VariantSHA512:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: variant must be either the '%s' or '%s' variant", algorithm.ErrParameterInvalid, VariantSHA256, VariantSHA512))
+	case iterations < 0 || int64(iterations) > math.MaxUint32:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", 0, "", uint32(math.MaxUint32), iterations))
+	case strings.ContainsRune(string(salt), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: salt must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	case len(key) == 0:
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrParameterInvalid))
+	case strings.ContainsRune(string(key), encoding.Delimiter):
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: key must not contain the '%c' delimiter", algorithm.ErrParameterInvalid, encoding.Delimiter))
+	}
+
+	return &Digest{variant: variant, iterations: iterations, salt: salt, key: key}, nil
+}
+
 func decoderParts(encodedDigest string) (variant Variant, parts []string, err error) {
-	parts = encoding.Split(encodedDigest, -1)
+	parts = phc.Split(encodedDigest, -1)
 
 	if n := len(parts); n != 4 && n != 5 {
 		return VariantNone, nil, algorithm.ErrEncodedHashInvalidFormat
@@ -107,10 +130,10 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 
 	decoded.iterations = IterationsDefaultOmitted
 
-	var params []encoding.Parameter
+	var params []phc.Parameter
 
 	if ip >= 0 {
-		if params, err = encoding.DecodeParameterStr(parts[ip]); err != nil {
+		if params, err = phc.DecodeParameters(parts[ip]); err != nil {
 			return nil, err
 		}
 	}
@@ -124,7 +147,7 @@ func decode(variant Variant, parts []string) (digest algorithm.Digest, err error
 				return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, param.Value, err)
 			}
 
-			decoded.iterations = int(rounds)
+			decoded.iterations, decoded.rounds = int(rounds), true
 		default:
 			return nil, fmt.Errorf("%w: option '%s' with value '%s' is unknown", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, param.Value)
 		}
@@ -16,6 +16,11 @@ type Digest struct {
 
 	iterations int
 	salt, key  []byte
+
+	// rounds is true when the rounds were explicitly present in the decoded digest.
+	rounds bool
+
+	algorithm.Prefix
 }
 
 // Match returns true if the string password matches the current shacrypt.Digest.
@@ -50,8 +55,13 @@ func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error
 
 // Encode this Digest as a string for storage.
 func (d *Digest) Encode() (hash string) {
-	switch d.iterations {
-	case IterationsDefaultOmitted:
+	return d.StoragePrefix() + d.encode()
+}
+
+// encode returns the encoded form of this shacrypt.Digest without the storage prefix.
+func (d *Digest) encode() (hash string) {
+	switch {
+	case d.iterations == IterationsDefaultOmitted && !d.rounds:
 		return strings.ReplaceAll(fmt.Sprintf(EncodingFmtRoundsOmitted,
 			d.variant.Prefix(),
 			d.salt, d.key,
@@ -79,6 +89,25 @@ func (d *Digest) Salt() (salt []byte) {
 	return d.salt
 }
 
+// Variant returns the shacrypt.Variant of this shacrypt.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+// Iterations returns the number of rounds used to generate this digest.
+func (d *Digest) Iterations() (iterations int) {
+	return d.iterations
+}
+
+// Canonical returns a copy of this shacrypt.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.Prefix = algorithm.Prefix{}
+
+	return &c
+}
+
 func (d *Digest) defaults() {
 	switch d.variant {
 	case VariantSHA256, VariantSHA512:
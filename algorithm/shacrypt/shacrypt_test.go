@@ -386,6 +386,28 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeRoundsRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldRoundTripOmitted", "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"ShouldRoundTripExplicitDefault", "$6$rounds=5000$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"ShouldRoundTripExplicit", "$5$rounds=10000$saltstringsaltst$3xv.VbSHBb41AL9AvLeujZkZRBAwqFMz2.opqey6IcA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.have, digest.Encode())
+			assert.Equal(t, tc.have, digest.(*Digest).Canonical().Encode())
+			assert.True(t, digest.Match("Hello world!"))
+		})
+	}
+}
+
 func TestDigestKeySalt(t *testing.T) {
 	hasher, err := New(WithIterations(1000))
 	require.NoError(t, err)
@@ -422,3 +444,29 @@ func TestDigestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDigest(t *testing.T) {
+	decoded, err := Decode("$5$saltsalt$gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.")
+	require.NoError(t, err)
+
+	digest, err := NewDigest(VariantSHA256, IterationsDefaultOmitted, decoded.Salt(), decoded.Key())
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.Encode(), digest.Encode())
+
+	digest, err = NewDigest(VariantNone, IterationsDefaultOmitted, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "shacrypt decode error: parameter is invalid: variant must be either the 'sha256' or 'sha512' variant")
+
+	digest, err = NewDigest(VariantSHA256, -1, decoded.Salt(), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "shacrypt decode error: parameter is invalid: parameter 'iterations' must be between 0 and 4294967295 but is set to '-1'")
+
+	digest, err = NewDigest(VariantSHA256, IterationsDefaultOmitted, []byte("salt$"), decoded.Key())
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "shacrypt decode error: parameter is invalid: salt must not contain the '$' delimiter")
+
+	digest, err = NewDigest(VariantSHA256, IterationsDefaultOmitted, decoded.Salt(), nil)
+	assert.Nil(t, digest)
+	assert.EqualError(t, err, "shacrypt decode error: parameter is invalid: key has 0 bytes")
+}
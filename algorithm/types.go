@@ -31,6 +31,23 @@ type Matcher interface {
 	MatchBytesAdvanced(passwordBytes []byte) (match bool, err error)
 }
 
+// MatchContext is the additional context such as the username or realm which is mixed into the digest by some
+// credential formats and is required to match a password.
+type MatchContext struct {
+	Username string
+	Realm    string
+}
+
+// ContextualMatcher is an interface used to match passwords against a Digest which requires a MatchContext. Values
+// which are not empty in the MatchContext take precedence over the values the Digest was decoded or hashed with, except
+// where a value changes how the Digest is derived rather than being an input to it. For example the scram.Digest only
+// uses the Username when it's a MongoDB SCRAM-SHA-1 credential, as using it for a PostgreSQL SCRAM-SHA-1 secret would
+// derive a different key.
+type ContextualMatcher interface {
+	MatchWithContext(password string, ctx MatchContext) (match bool)
+	MatchAdvancedWithContext(password string, ctx MatchContext) (match bool, err error)
+}
+
 // Digest represents a hashed password. It's implemented by all hashed password results so that when we pass a
 // stored hash into its relevant type we can verify the password against the hash.
 type Digest interface {
@@ -43,9 +60,33 @@ type Digest interface {
 	Salt() (salt []byte)
 }
 
+// CanonicalDigest describes a Digest which is encoded losslessly, retaining the identifier and storage prefix it was
+// decoded with, and which is also able to produce the canonical encoded form. The canonical encoded form is the result
+// of the Encode function of the Digest returned by Canonical, the Digest is encoded in the canonical form if both are
+// equal.
+type CanonicalDigest interface {
+	Digest
+
+	// Canonical returns a copy of the Digest which is encoded in the canonical form.
+	Canonical() (digest Digest)
+}
+
+// StoragePrefixDigest describes a Digest which retains the storage prefix such as the OpenLDAP {CRYPT} prefix it was
+// decoded with.
+type StoragePrefixDigest interface {
+	Digest
+
+	StoragePrefix() (prefix string)
+	WithStoragePrefix(prefix string)
+}
+
 // DecodeFunc describes a function to decode an encoded digest into a algorithm.Digest.
 type DecodeFunc func(encodedDigest string) (digest Digest, err error)
 
+// DecodeMatchFunc describes a function which returns true if an encoded digest which does not begin with the delimiter
+// is in a format understood by a specific DecodeFunc.
+type DecodeMatchFunc func(encodedDigest string) (match bool)
+
 // DecoderRegister describes an implementation that allows registering DecodeFunc's.
 type DecoderRegister interface {
 	RegisterDecodeFunc(prefix string, decoder DecodeFunc) (err error)
@@ -54,6 +95,14 @@ type DecoderRegister interface {
 	Decoder
 }
 
+// DecoderMatchRegister describes an implementation that allows registering DecodeFunc's as well as DecodeMatchFunc's
+// for encoded digests which are not delimited and can't be identified by their identifier or a prefix.
+type DecoderMatchRegister interface {
+	RegisterDecodeMatchFunc(identifier string, matcher DecodeMatchFunc) (err error)
+
+	DecoderRegister
+}
+
 // Decoder is a representation of a implementation that performs generic decoding. Currently this is just intended for
 // use by implementers.
 type Decoder interface {
@@ -0,0 +1,202 @@
+package aspnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+const (
+	password = "password"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		canonical string
+		v2        bool
+	}{
+		{
+			"ShouldDecodeV2",
+			"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==",
+			"$pbkdf2$1000$AAECAwQFBgcICQoLDA0ODw$Awni/k4L3.fQ/kgo1BwjRBbi2b.2HN2PZDoRz7/fwRk",
+			true,
+		},
+		{
+			"ShouldDecodeV3SHA1",
+			"AQAAAAAAACcQAAAAEAABAgMEBQYHCAkKCwwNDg+OPi9zw+tjkKgau8gQHANDsBenr//7WrZeE08JCdzKLA==",
+			"$pbkdf2$10000$AAECAwQFBgcICQoLDA0ODw$jj4vc8PrY5CoGrvIEBwDQ7AXp6//.1q2XhNPCQncyiw",
+			false,
+		},
+		{
+			"ShouldDecodeV3SHA256",
+			"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg/rbIFTVZIgPAkrFY+NOQlnI2Km9dvQDZgoBEy6qLJS6Q==",
+			"$pbkdf2-sha256$10000$AAECAwQFBgcICQoLDA0ODw$62yBU1WSIDwJKxWPjTkJZyNipvXb0A2YKARMuqiyUuk",
+			false,
+		},
+		{
+			"ShouldDecodeV3SHA512",
+			"AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w==",
+			"$pbkdf2-sha512$100000$AAECAwQFBgcICQoLDA0ODw$.94U0zjMb4IQV/P0p4rCC8cBsR43qTs3kMNRDgGUc/M",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, MatchEncoded(tc.have))
+
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.IsType(t, &pbkdf2.Digest{}, digest)
+			assert.True(t, digest.Match(password))
+			assert.False(t, digest.Match("notpassword"))
+			assert.Equal(t, tc.canonical, digest.Encode())
+
+			var value string
+
+			if tc.v2 {
+				value, err = EncodeV2(digest)
+			} else {
+				value, err = Encode(digest)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, value)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldErrBase64", "$pbkdf2$", "aspnet decode error: provided encoded hash has a key value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrEmpty", "", "aspnet decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrMarker", "AgAAAA==", "aspnet decode error: provided encoded hash has an invalid identifier: format marker '2' is unknown"},
+		{"ShouldErrV2Length", "AAABAgM=", "aspnet decode error: provided encoded hash has an invalid format: the v2 format has 5 bytes but must have 49 bytes"},
+		{"ShouldErrV3Length", "AQAAAAE=", "aspnet decode error: provided encoded hash has an invalid format: the v3 format has 5 bytes but must have at least 13 bytes"},
+		{"ShouldErrV3PRF", "AQAAAAMAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8A", "aspnet decode error: provided encoded hash has an invalid option value: the v3 format prf '3' is unknown"},
+		{"ShouldErrV3Iterations", "AQAAAAEAAAAAAAAAEAABAgMEBQYHCAkKCwwNDg8A", "aspnet decode error: provided encoded hash has an invalid option value: the v3 format iterations '0' is invalid"},
+		{"ShouldErrV3SaltLength", "AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8=", "aspnet decode error: provided encoded hash has an invalid option value: the v3 format salt size '16' is invalid for a blob with 29 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.False(t, MatchEncoded(tc.have))
+
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	hasher, err := pbkdf2.NewSHA384()
+	require.NoError(t, err)
+
+	sha384, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	md5, err := md5crypt.Decode("$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/")
+	require.NoError(t, err)
+
+	_, err = Encode(nil)
+	assert.EqualError(t, err, "can't encode a nil digest")
+
+	_, err = Encode(md5)
+	assert.EqualError(t, err, "aspnet encode error: digests of type '*md5crypt.Digest' are not supported")
+
+	_, err = Encode(sha384)
+	assert.EqualError(t, err, "aspnet encode error: pbkdf2 digests must use the sha1, sha256, or sha512 variant")
+
+	_, err = EncodeV2(sha384)
+	assert.EqualError(t, err, "aspnet encode error: pbkdf2 digests must use the sha1 variant with 1000 iterations, a 16 byte salt, and a 32 byte key for the v2 format")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+	require.NoError(t, pbkdf2.RegisterDecoder(d))
+
+	for _, have := range []string{
+		"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==",
+		"AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w==",
+		"$pbkdf2-sha512$100000$AAECAwQFBgcICQoLDA0ODw$.94U0zjMb4IQV/P0p4rCC8cBsR43qTs3kMNRDgGUc/M",
+	} {
+		digest, err := d.Decode(have)
+
+		require.NoError(t, err)
+		assert.True(t, digest.Match(password))
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var _ crypt.Format = NewFormat()
+
+	d := crypt.NewDecoderStrict()
+
+	require.NoError(t, d.RegisterFormat(NewFormat()))
+	require.NoError(t, pbkdf2.RegisterDecoder(d))
+
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldNormalizeV3SHA1", "AQAAAAAAACcQAAAAEAABAgMEBQYHCAkKCwwNDg+OPi9zw+tjkKgau8gQHANDsBenr//7WrZeE08JCdzKLA=="},
+		{"ShouldNormalizeV3SHA256", "AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg/rbIFTVZIgPAkrFY+NOQlnI2Km9dvQDZgoBEy6qLJS6Q=="},
+		{"ShouldNormalizeV3SHA512", "AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w=="},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := d.Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.IsType(t, &pbkdf2.Digest{}, digest)
+			assert.True(t, digest.Match(password))
+
+			value, err := d.EncodeAs(digest, FormatName)
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, value)
+		})
+	}
+
+	t.Run("ShouldNormalizeV2AndEncodeV3", func(t *testing.T) {
+		digest, err := d.Decode("AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==")
+		require.NoError(t, err)
+
+		value, err := d.EncodeAs(digest, FormatName)
+		require.NoError(t, err)
+
+		v3, err := Encode(digest)
+		require.NoError(t, err)
+		assert.Equal(t, v3, value)
+
+		decoded, err := d.Decode(value)
+		require.NoError(t, err)
+		assert.True(t, decoded.Match(password))
+	})
+
+	encodedDigest, ok := NewFormat().Normalize("$pbkdf2$1000$c2FsdA$AAECAwQFBgcICQoLDA0ODxAREhM")
+	assert.False(t, ok)
+	assert.Equal(t, "$pbkdf2$1000$c2FsdA$AAECAwQFBgcICQoLDA0ODxAREhM", encodedDigest)
+
+	_, err := d.EncodeAs(nil, FormatName)
+	assert.EqualError(t, err, "can't encode a nil digest")
+
+	_, err = NewFormat().Encode(nil)
+	assert.EqualError(t, err, "can't encode a nil digest")
+}
@@ -0,0 +1,40 @@
+package aspnet
+
+const (
+	// AlgName is the name for this package.
+	AlgName = "aspnet"
+
+	// AlgIdentifier is the identifier the decoder for this package is registered with.
+	AlgIdentifier = AlgName
+
+	// FormatName is the name of the aspnet.Format.
+	FormatName = AlgName
+
+	// FormatMarkerV2 is the format marker of the Identity v2 format.
+	FormatMarkerV2 byte = 0x00
+
+	// FormatMarkerV3 is the format marker of the Identity v3 format.
+	FormatMarkerV3 byte = 0x01
+
+	// PRFSHA1 is the Identity v3 PRF value for HMAC-SHA-1.
+	PRFSHA1 uint32 = 0
+
+	// PRFSHA256 is the Identity v3 PRF value for HMAC-SHA-256.
+	PRFSHA256 uint32 = 1
+
+	// PRFSHA512 is the Identity v3 PRF value for HMAC-SHA-512.
+	PRFSHA512 uint32 = 2
+
+	// IterationsV2 is the number of iterations used by the Identity v2 format.
+	IterationsV2 = 1000
+
+	// SaltLengthV2 is the salt size used by the Identity v2 format.
+	SaltLengthV2 = 16
+
+	// KeyLengthV2 is the key size used by the Identity v2 format.
+	KeyLengthV2 = 32
+
+	// HeaderLengthV3 is the size of the Identity v3 header which is the format marker, PRF, iteration count, and salt
+	// size.
+	HeaderLengthV3 = 13
+)
@@ -0,0 +1,111 @@
+package aspnet
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/internal/encoding"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which are a base64 encoded Identity v2 or Identity v3 blob.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifier, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest is a base64 encoded Identity v2 or Identity v3 blob.
+func MatchEncoded(encodedDigest string) (match bool) {
+	_, err := decode(encodedDigest)
+
+	return err == nil
+}
+
+// Decode the encoded digest into a pbkdf2.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	if encodedDigest, err = decode(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return pbkdf2.Decode(encodedDigest)
+}
+
+// decode converts the Identity v2 or Identity v3 blob into the encoded form used by the pbkdf2.Digest.
+func decode(encodedDigest string) (encodedPBKDF2 string, err error) {
+	var raw []byte
+
+	if raw, err = base64.StdEncoding.DecodeString(encodedDigest); err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(raw) == 0 {
+		return "", algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	var (
+		variant    pbkdf2.Variant
+		iterations int
+		salt, key  []byte
+	)
+
+	switch raw[0] {
+	case FormatMarkerV2:
+		if len(raw) != 1+SaltLengthV2+KeyLengthV2 {
+			return "", fmt.Errorf("%w: the v2 format has %d bytes but must have %d bytes", algorithm.ErrEncodedHashInvalidFormat, len(raw), 1+SaltLengthV2+KeyLengthV2)
+		}
+
+		variant, iterations, salt, key = pbkdf2.VariantSHA1, IterationsV2, raw[1:1+SaltLengthV2], raw[1+SaltLengthV2:]
+	case FormatMarkerV3:
+		if len(raw) < HeaderLengthV3 {
+			return "", fmt.Errorf("%w: the v3 format has %d bytes but must have at least %d bytes", algorithm.ErrEncodedHashInvalidFormat, len(raw), HeaderLengthV3)
+		}
+
+		prf := binary.BigEndian.Uint32(raw[1:5])
+
+		if variant = newVariant(prf); variant == pbkdf2.VariantNone {
+			return "", fmt.Errorf("%w: the v3 format prf '%d' is unknown", algorithm.ErrEncodedHashInvalidOptionValue, prf)
+		}
+
+		i, s := binary.BigEndian.Uint32(raw[5:9]), binary.BigEndian.Uint32(raw[9:13])
+
+		if i == 0 || i > pbkdf2.IterationsMax {
+			return "", fmt.Errorf("%w: the v3 format iterations '%d' is invalid", algorithm.ErrEncodedHashInvalidOptionValue, i)
+		}
+
+		if s == 0 || uint64(s) >= uint64(len(raw)-HeaderLengthV3) {
+			return "", fmt.Errorf("%w: the v3 format salt size '%d' is invalid for a blob with %d bytes", algorithm.ErrEncodedHashInvalidOptionValue, s, len(raw))
+		}
+
+		iterations, salt, key = int(i), raw[HeaderLengthV3:HeaderLengthV3+int(s)], raw[HeaderLengthV3+int(s):]
+	default:
+		return "", fmt.Errorf("%w: format marker '%d' is unknown", algorithm.ErrEncodedHashInvalidIdentifier, raw[0])
+	}
+
+	return fmt.Sprintf(pbkdf2.EncodingFmt, variant.Prefix(), iterations,
+		encoding.Base64RawAdaptedEncoding.EncodeToString(salt),
+		encoding.Base64RawAdaptedEncoding.EncodeToString(key),
+	), nil
+}
+
+func newVariant(prf uint32) (variant pbkdf2.Variant) {
+	switch prf {
+	case PRFSHA1:
+		return pbkdf2.VariantSHA1
+	case PRFSHA256:
+		return pbkdf2.VariantSHA256
+	case PRFSHA512:
+		return pbkdf2.VariantSHA512
+	default:
+		return pbkdf2.VariantNone
+	}
+}
@@ -0,0 +1,10 @@
+// Package aspnet provides compatibility with the ASP.NET Identity PasswordHasher storage formats and implements
+// github.com/go-crypt/crypt interfaces.
+//
+// The Identity v2 and Identity v3 formats are a single base64 encoded blob which begins with a format marker. Both
+// formats are PBKDF2 and are decoded into a pbkdf2.Digest. The aspnet.Encode and aspnet.EncodeV2 functions encode a
+// pbkdf2.Digest in the Identity v3 and Identity v2 formats respectively. The aspnet.Format implements the crypt.Format
+// interface and can be registered with a crypt.Decoder, its output side encodes the Identity v3 format.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package aspnet
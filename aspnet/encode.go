@@ -0,0 +1,97 @@
+package aspnet
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// Encode returns the algorithm.Digest encoded in the Identity v3 format. The supported digests are the HMAC-SHA-1,
+// HMAC-SHA-256, and HMAC-SHA-512 variants of the pbkdf2.Digest.
+func Encode(digest algorithm.Digest) (value string, err error) {
+	var (
+		d          *pbkdf2.Digest
+		variant    pbkdf2.Variant
+		iterations int
+	)
+
+	if d, variant, iterations, err = encoderParts(digest); err != nil {
+		return "", err
+	}
+
+	var prf uint32
+
+	switch variant {
+	case pbkdf2.VariantSHA1:
+		prf = PRFSHA1
+	case pbkdf2.VariantSHA256:
+		prf = PRFSHA256
+	case pbkdf2.VariantSHA512:
+		prf = PRFSHA512
+	default:
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must use the %s, %s, or %s variant", AlgName, pbkdf2.VariantSHA1, pbkdf2.VariantSHA256, pbkdf2.VariantSHA512)
+	}
+
+	salt, key := d.Salt(), d.Key()
+
+	raw := make([]byte, HeaderLengthV3, HeaderLengthV3+len(salt)+len(key))
+
+	raw[0] = FormatMarkerV3
+
+	binary.BigEndian.PutUint32(raw[1:5], prf)
+	binary.BigEndian.PutUint32(raw[5:9], uint32(iterations))
+	binary.BigEndian.PutUint32(raw[9:13], uint32(len(salt)))
+
+	return base64.StdEncoding.EncodeToString(append(append(raw, salt...), key...)), nil
+}
+
+// EncodeV2 returns the algorithm.Digest encoded in the Identity v2 format. The supported digests are the HMAC-SHA-1
+// variant of the pbkdf2.Digest with 1000 iterations, a 16 byte salt, and a 32 byte key.
+func EncodeV2(digest algorithm.Digest) (value string, err error) {
+	var (
+		d          *pbkdf2.Digest
+		variant    pbkdf2.Variant
+		iterations int
+	)
+
+	if d, variant, iterations, err = encoderParts(digest); err != nil {
+		return "", err
+	}
+
+	salt, key := d.Salt(), d.Key()
+
+	if variant != pbkdf2.VariantSHA1 || iterations != IterationsV2 || len(salt) != SaltLengthV2 || len(key) != KeyLengthV2 {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must use the %s variant with %d iterations, a %d byte salt, and a %d byte key for the v2 format", AlgName, pbkdf2.VariantSHA1, IterationsV2, SaltLengthV2, KeyLengthV2)
+	}
+
+	return base64.StdEncoding.EncodeToString(append(append([]byte{FormatMarkerV2}, salt...), key...)), nil
+}
+
+func encoderParts(digest algorithm.Digest) (d *pbkdf2.Digest, variant pbkdf2.Variant, iterations int, err error) {
+	if digest == nil {
+		return nil, pbkdf2.VariantNone, 0, fmt.Errorf("can't encode a nil digest")
+	}
+
+	var ok bool
+
+	if d, ok = digest.(*pbkdf2.Digest); !ok {
+		return nil, pbkdf2.VariantNone, 0, fmt.Errorf("%s encode error: digests of type '%T' are not supported", AlgName, digest)
+	}
+
+	parts := phc.Split(d.Canonical().Encode(), -1)
+
+	if len(parts) != 5 {
+		return nil, pbkdf2.VariantNone, 0, fmt.Errorf("%s encode error: pbkdf2 digest is not valid", AlgName)
+	}
+
+	if iterations, err = strconv.Atoi(parts[2]); err != nil {
+		return nil, pbkdf2.VariantNone, 0, fmt.Errorf("%s encode error: %w", AlgName, err)
+	}
+
+	return d, pbkdf2.NewVariant(parts[1]), iterations, nil
+}
@@ -0,0 +1,36 @@
+package aspnet
+
+import (
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// NewFormat returns a *aspnet.Format which can be registered with a crypt.Decoder via its RegisterFormat method.
+func NewFormat() *Format {
+	return &Format{}
+}
+
+// Format is the crypt.Format for the ASP.NET Identity formats. The input side converts the Identity v2 and Identity v3
+// formats into the encoded form used by the pbkdf2.Digest, and the output side is the same as the aspnet.Encode
+// function which encodes the Identity v3 format.
+type Format struct{}
+
+// Name returns the name of this aspnet.Format which is used with the EncodeAs method of a crypt.Decoder.
+func (f *Format) Name() (name string) {
+	return FormatName
+}
+
+// Normalize converts a value in the Identity v2 or Identity v3 format into the encoded form used by the pbkdf2.Digest.
+func (f *Format) Normalize(value string) (encodedDigest string, ok bool) {
+	var err error
+
+	if encodedDigest, err = decode(value); err != nil {
+		return value, false
+	}
+
+	return encodedDigest, true
+}
+
+// Encode returns the algorithm.Digest encoded in the Identity v3 format. See aspnet.Encode for the supported digests.
+func (f *Format) Encode(digest algorithm.Digest) (value string, err error) {
+	return Encode(digest)
+}
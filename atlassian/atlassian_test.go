@@ -0,0 +1,115 @@
+package atlassian
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+const (
+	password = "password"
+)
+
+func TestDecode(t *testing.T) {
+	have := "{PKCS5S2}AAECAwQFBgcICQoLDA0OD44+L3PD62OQqBq7yBAcA0OwF6ev//tatl4TTwkJ3Mos"
+
+	assert.True(t, MatchEncoded(have))
+
+	digest, err := Decode(have)
+	require.NoError(t, err)
+
+	assert.IsType(t, &pbkdf2.Digest{}, digest)
+	assert.True(t, digest.Match(password))
+	assert.False(t, digest.Match("notpassword"))
+	assert.Equal(t, "$pbkdf2$10000$AAECAwQFBgcICQoLDA0ODw$jj4vc8PrY5CoGrvIEBwDQ7AXp6//.1q2XhNPCQncyiw", digest.Encode())
+
+	value, err := Encode(digest)
+
+	require.NoError(t, err)
+	assert.Equal(t, have, value)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldErrPrefix", "{SSHA}AAECAwQF", "atlassian decode error: provided encoded hash has an invalid format: the digest doesn't begin with the {PKCS5S2} prefix"},
+		{"ShouldErrBase64", "{PKCS5S2}$$$$", "atlassian decode error: provided encoded hash has a key value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrLength", "{PKCS5S2}AAECAwQF", "atlassian decode error: provided encoded hash has a key value that can't be decoded: salt and key have 6 bytes but must have 48 bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	hasher, err := pbkdf2.NewSHA1()
+	require.NoError(t, err)
+
+	sha1, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	md5, err := md5crypt.Decode("$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/")
+	require.NoError(t, err)
+
+	_, err = Encode(nil)
+	assert.EqualError(t, err, "can't encode a nil digest")
+
+	_, err = Encode(md5)
+	assert.EqualError(t, err, "atlassian encode error: digests of type '*md5crypt.Digest' are not supported")
+
+	_, err = Encode(sha1)
+	assert.EqualError(t, err, "atlassian encode error: pbkdf2 digests must use the sha1 variant with 10000 iterations, a 16 byte salt, and a 32 byte key")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	digest, err := d.Decode("{PKCS5S2}AAECAwQFBgcICQoLDA0OD44+L3PD62OQqBq7yBAcA0OwF6ev//tatl4TTwkJ3Mos")
+
+	require.NoError(t, err)
+	assert.True(t, digest.Match(password))
+}
+
+func TestFormat(t *testing.T) {
+	var _ crypt.Format = NewFormat()
+
+	d := crypt.NewDecoderStrict()
+
+	require.NoError(t, d.RegisterFormat(NewFormat()))
+	require.NoError(t, pbkdf2.RegisterDecoder(d))
+
+	have := "{PKCS5S2}AAECAwQFBgcICQoLDA0OD44+L3PD62OQqBq7yBAcA0OwF6ev//tatl4TTwkJ3Mos"
+
+	digest, err := d.Decode(have)
+	require.NoError(t, err)
+
+	assert.IsType(t, &pbkdf2.Digest{}, digest)
+	assert.True(t, digest.Match(password))
+
+	value, err := d.EncodeAs(digest, FormatName)
+	require.NoError(t, err)
+	assert.Equal(t, have, value)
+
+	encodedDigest, ok := NewFormat().Normalize("{PKCS5S2}AAECAwQF")
+	assert.False(t, ok)
+	assert.Equal(t, "{PKCS5S2}AAECAwQF", encodedDigest)
+
+	_, err = NewFormat().Encode(nil)
+	assert.EqualError(t, err, "can't encode a nil digest")
+}
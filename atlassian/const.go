@@ -0,0 +1,24 @@
+package atlassian
+
+const (
+	// AlgName is the name for this package.
+	AlgName = "atlassian"
+
+	// AlgIdentifier is the identifier the decoder for this package is registered with.
+	AlgIdentifier = AlgName
+
+	// FormatName is the name of the atlassian.Format.
+	FormatName = AlgName
+
+	// StoragePrefix is the prefix of the {PKCS5S2} format.
+	StoragePrefix = "{PKCS5S2}"
+
+	// Iterations is the number of iterations used by the {PKCS5S2} format.
+	Iterations = 10000
+
+	// SaltLength is the salt size used by the {PKCS5S2} format.
+	SaltLength = 16
+
+	// KeyLength is the key size used by the {PKCS5S2} format.
+	KeyLength = 32
+)
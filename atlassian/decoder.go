@@ -0,0 +1,61 @@
+package atlassian
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/internal/encoding"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which begin with the {PKCS5S2} prefix.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifier, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest begins with the {PKCS5S2} prefix.
+func MatchEncoded(encodedDigest string) (match bool) {
+	return strings.HasPrefix(encodedDigest, StoragePrefix)
+}
+
+// Decode the encoded digest into a pbkdf2.Digest.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	if encodedDigest, err = decode(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	return pbkdf2.DecodeVariant(pbkdf2.VariantSHA1)(encodedDigest)
+}
+
+// decode converts the {PKCS5S2} format into the encoded form used by the pbkdf2.Digest.
+func decode(encodedDigest string) (encodedPBKDF2 string, err error) {
+	if !MatchEncoded(encodedDigest) {
+		return "", fmt.Errorf("%w: the digest doesn't begin with the %s prefix", algorithm.ErrEncodedHashInvalidFormat, StoragePrefix)
+	}
+
+	var raw []byte
+
+	if raw, err = base64.StdEncoding.DecodeString(encodedDigest[len(StoragePrefix):]); err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(raw) != SaltLength+KeyLength {
+		return "", fmt.Errorf("%w: salt and key have %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), SaltLength+KeyLength)
+	}
+
+	return fmt.Sprintf(pbkdf2.EncodingFmt, pbkdf2.AlgIdentifier, Iterations,
+		encoding.Base64RawAdaptedEncoding.EncodeToString(raw[:SaltLength]),
+		encoding.Base64RawAdaptedEncoding.EncodeToString(raw[SaltLength:]),
+	), nil
+}
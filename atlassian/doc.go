@@ -0,0 +1,10 @@
+// Package atlassian provides compatibility with the Atlassian Crowd and Jira {PKCS5S2} storage format and implements
+// github.com/go-crypt/crypt interfaces.
+//
+// The {PKCS5S2} format is a base64 encoded salt and key derived using PBKDF2 with HMAC-SHA-1 and 10000 iterations and
+// is decoded into a pbkdf2.Digest. The atlassian.Encode function encodes a pbkdf2.Digest with matching parameters in
+// the {PKCS5S2} format. The atlassian.Format implements the crypt.Format interface and can be registered with a
+// crypt.Decoder so the same digests can be encoded via its EncodeAs method.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package atlassian
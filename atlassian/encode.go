@@ -0,0 +1,34 @@
+package atlassian
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// Encode returns the algorithm.Digest encoded in the {PKCS5S2} format. The supported digests are the HMAC-SHA-1
+// variant of the pbkdf2.Digest with 10000 iterations, a 16 byte salt, and a 32 byte key.
+func Encode(digest algorithm.Digest) (value string, err error) {
+	if digest == nil {
+		return "", fmt.Errorf("can't encode a nil digest")
+	}
+
+	d, ok := digest.(*pbkdf2.Digest)
+
+	if !ok {
+		return "", fmt.Errorf("%s encode error: digests of type '%T' are not supported", AlgName, digest)
+	}
+
+	parts := phc.Split(d.Canonical().Encode(), -1)
+
+	salt, key := d.Salt(), d.Key()
+
+	if len(parts) != 5 || parts[1] != pbkdf2.AlgIdentifier || parts[2] != fmt.Sprint(Iterations) || len(salt) != SaltLength || len(key) != KeyLength {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must use the %s variant with %d iterations, a %d byte salt, and a %d byte key", AlgName, pbkdf2.VariantSHA1, Iterations, SaltLength, KeyLength)
+	}
+
+	return StoragePrefix + base64.StdEncoding.EncodeToString(append(append([]byte{}, salt...), key...)), nil
+}
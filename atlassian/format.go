@@ -0,0 +1,35 @@
+package atlassian
+
+import (
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// NewFormat returns a *atlassian.Format which can be registered with a crypt.Decoder via its RegisterFormat method.
+func NewFormat() *Format {
+	return &Format{}
+}
+
+// Format is the crypt.Format for the {PKCS5S2} format. The input side converts the {PKCS5S2} format into the encoded
+// form used by the pbkdf2.Digest, and the output side is the same as the atlassian.Encode function.
+type Format struct{}
+
+// Name returns the name of this atlassian.Format which is used with the EncodeAs method of a crypt.Decoder.
+func (f *Format) Name() (name string) {
+	return FormatName
+}
+
+// Normalize converts a value in the {PKCS5S2} format into the encoded form used by the pbkdf2.Digest.
+func (f *Format) Normalize(value string) (encodedDigest string, ok bool) {
+	var err error
+
+	if encodedDigest, err = decode(value); err != nil {
+		return value, false
+	}
+
+	return encodedDigest, true
+}
+
+// Encode returns the algorithm.Digest encoded in the {PKCS5S2} format. See atlassian.Encode for the supported digests.
+func (f *Format) Encode(digest algorithm.Digest) (value string, err error) {
+	return Encode(digest)
+}
@@ -16,3 +16,14 @@ const (
 	// StorageFormatPrefixLDAPArgon2 is a prefix used by OpenLDAP for argon2 format encoded digests.
 	StorageFormatPrefixLDAPArgon2 = "{ARGON2}"
 )
+
+const (
+	// FormatNameLDAPCrypt is the name of the Format for the OpenLDAP {CRYPT} storage format.
+	FormatNameLDAPCrypt = "ldap-crypt"
+
+	// FormatNameLDAPArgon2 is the name of the Format for the OpenLDAP {ARGON2} storage format.
+	FormatNameLDAPArgon2 = "ldap-argon2"
+
+	// FormatNameLDAPPBKDF2 is the name of the Format for the {PBKDF2-SHA256} style storage formats.
+	FormatNameLDAPPBKDF2 = "ldap-pbkdf2"
+)
@@ -1,12 +1,17 @@
 package crypt
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/descrypt"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pgmd5"
 )
 
 func TestNormalize(t *testing.T) {
@@ -80,6 +85,26 @@ func TestNewDecoderAll(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, digest)
 	assert.True(t, digest.Match("password"))
+
+	digest, err = d.Decode("$3$$8846f7eaee8fb117ad06bdd830b7586c")
+	require.NoError(t, err)
+	assert.True(t, digest.Match("password"))
+
+	_, err = d.Decode("8846f7eaee8fb117ad06bdd830b7586c")
+	assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+
+	require.NoError(t, nthash.RegisterDecoderRaw(d))
+
+	digest, err = d.Decode("8846f7eaee8fb117ad06bdd830b7586c")
+	require.NoError(t, err)
+	assert.True(t, digest.Match("password"))
+	assert.Equal(t, "$3$$8846f7eaee8fb117ad06bdd830b7586c", EncodeCanonical(digest))
+
+	d, err = NewDefaultDecoder()
+	require.NoError(t, err)
+
+	_, err = d.Decode("$3$$8846f7eaee8fb117ad06bdd830b7586c")
+	assert.EqualError(t, err, "provided encoded hash has an invalid identifier: the identifier '3' is unknown to the decoder")
 }
 
 func TestDecoderRegisterDecodeFunc(t *testing.T) {
@@ -173,6 +198,112 @@ func TestDecoderRegisterDecodePrefix(t *testing.T) {
 	}
 }
 
+func TestDecoderRegisterDecodeMatchFunc(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func(d *Decoder)
+		identifier string
+		matcher    algorithm.DecodeMatchFunc
+		err        string
+	}{
+		{
+			"ShouldRegisterNew",
+			nil,
+			"test",
+			func(encodedDigest string) bool { return true },
+			"",
+		},
+		{
+			"ShouldFailNoDecoders",
+			func(d *Decoder) {
+				d.decoders = nil
+			},
+			"test",
+			func(encodedDigest string) bool { return true },
+			"no decoders are registered",
+		},
+		{
+			"ShouldFailNilMatcher",
+			nil,
+			"test",
+			nil,
+			"can't register a nil match func for identifier 'test'",
+		},
+		{
+			"ShouldFailUnregisteredIdentifier",
+			nil,
+			"missing",
+			func(encodedDigest string) bool { return true },
+			"decoder isn't registered for identifier 'missing'",
+		},
+		{
+			"ShouldFailDuplicate",
+			func(d *Decoder) {
+				_ = d.RegisterDecodeMatchFunc("test", func(encodedDigest string) bool { return true })
+			},
+			"test",
+			func(encodedDigest string) bool { return true },
+			"match func already registered for identifier 'test'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDecoder()
+
+			require.NoError(t, d.RegisterDecodeFunc("test", func(encodedDigest string) (algorithm.Digest, error) {
+				return nil, nil
+			}))
+
+			if tc.setup != nil {
+				tc.setup(d)
+			}
+
+			err := d.RegisterDecodeMatchFunc(tc.identifier, tc.matcher)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestDecoderDecodeDESCrypt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		password string
+	}{
+		{"ShouldDecodeStandard", "abJnggxhB/yWI", "password"},
+		{"ShouldDecodeStandardWithLDAPPrefix", "{CRYPT}abJnggxhB/yWI", "password"},
+		{"ShouldDecodeExtended", "_J9..saltJW8FtKdEkNM", "password"},
+	}
+
+	d := NewDecoder()
+
+	require.NoError(t, descrypt.RegisterDecoder(d))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := d.Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.True(t, digest.Match(tc.password))
+			assert.False(t, digest.Match(wrongPassword))
+		})
+	}
+
+	t.Run("ShouldNotDecodeWithDefaultDecoder", func(t *testing.T) {
+		decoder, err := NewDefaultDecoder()
+		require.NoError(t, err)
+
+		_, err = decoder.Decode("abJnggxhB/yWI")
+		assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+	})
+}
+
 func TestDecoderDecode(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -335,6 +466,114 @@ func TestCheckPasswordWithPlainText(t *testing.T) {
 	}
 }
 
+func TestCheckPasswordWithContext(t *testing.T) {
+	valid, err := CheckPasswordWithContext(password, encodedArgon2id, algorithm.MatchContext{Username: "john"})
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = CheckPasswordWithContext(password, "invalid", algorithm.MatchContext{Username: "john"})
+
+	assert.False(t, valid)
+	assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+}
+
+func TestMatchWithContext(t *testing.T) {
+	pg, err := pgmd5.Decode("md532e12f215ba27cb750c9e093ce4b5127")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		digest   algorithm.Digest
+		password string
+		ctx      algorithm.MatchContext
+		expected bool
+		err      string
+	}{
+		{
+			"ShouldMatchContextualDigest",
+			pg,
+			password,
+			algorithm.MatchContext{Username: "postgres"},
+			true,
+			"",
+		},
+		{
+			"ShouldNotMatchContextualDigestWrongUsername",
+			pg,
+			password,
+			algorithm.MatchContext{Username: "john"},
+			false,
+			"",
+		},
+		{
+			"ShouldFailContextualDigestMissingUsername",
+			pg,
+			password,
+			algorithm.MatchContext{},
+			false,
+			"pgmd5 match error: match context is missing a required value: username",
+		},
+		{
+			"ShouldIgnoreContextForOtherDigests",
+			nil,
+			password,
+			algorithm.MatchContext{Username: "john"},
+			true,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.digest == nil {
+				var err error
+
+				tc.digest, err = Decode(encodedArgon2id)
+				require.NoError(t, err)
+			}
+
+			match, err := MatchWithContext(tc.digest, tc.password, tc.ctx)
+
+			assert.Equal(t, tc.expected, match)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			digest, err := NewDigest(tc.digest)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, digest.MatchWithContext(tc.password, tc.ctx))
+			assert.Equal(t, tc.expected, NewNullDigest(tc.digest).MatchWithContext(tc.password, tc.ctx))
+
+			match, err = NewNullDigest(tc.digest).MatchAdvancedWithContext(tc.password, tc.ctx)
+
+			assert.Equal(t, tc.expected, match)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+
+	match, err := MatchWithContext(nil, password, algorithm.MatchContext{Username: "john"})
+
+	assert.False(t, match)
+	assert.EqualError(t, err, "can't match a nil digest")
+
+	match, err = NewNullDigest(nil).MatchAdvancedWithContext(password, algorithm.MatchContext{})
+
+	assert.False(t, match)
+	assert.NoError(t, err)
+
+	var _ algorithm.ContextualMatcher = &Digest{}
+}
+
 func TestNewDigest(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -745,3 +984,326 @@ func TestNullDigestMarshalUnmarshalBinary(t *testing.T) {
 }
 
 var encodedArgon2id = "$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU"
+
+func TestDecodeLosslessAndCanonical(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		canonical string
+	}{
+		{
+			"ShouldRetainBcryptVersionA",
+			"$2a$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+		{
+			"ShouldRetainBcryptVersionAWithLDAPCryptPrefix",
+			"{CRYPT}$2y$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+		{
+			"ShouldRetainPBKDF2SHA1Identifier",
+			"$pbkdf2-sha1$100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+			"$pbkdf2$100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+		},
+		{
+			"ShouldRetainPBKDF2LDAPPrefix",
+			"{PBKDF2-SHA256}100000$aoWHXwyz0im1Hqg93.N.tA$bO5LsjmnnPle2Xm9RE6W1PMWdJTy1TnEia1TLzynuIQ",
+			"$pbkdf2-sha256$100000$aoWHXwyz0im1Hqg93.N.tA$bO5LsjmnnPle2Xm9RE6W1PMWdJTy1TnEia1TLzynuIQ",
+		},
+		{
+			"ShouldRetainPBKDF2LDAPPrefixSHA1",
+			"{PBKDF2}100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+			"$pbkdf2$100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+		},
+		{
+			"ShouldRetainArgon2LDAPPrefix",
+			"{ARGON2}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			"$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+		},
+		{
+			"ShouldRetainSHACryptLDAPCryptPrefix",
+			"{CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+			"$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+		},
+		{
+			"ShouldRetainCanonical",
+			"$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+			"$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.have, digest.Encode())
+			assert.Equal(t, tc.canonical, EncodeCanonical(digest))
+			assert.Equal(t, tc.have == tc.canonical, IsCanonical(digest))
+
+			canonical := Canonical(digest)
+
+			assert.Equal(t, tc.canonical, canonical.Encode())
+			assert.True(t, IsCanonical(canonical))
+			assert.True(t, canonical.Match(password))
+
+			d, err := NewDigestDecode(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.have, d.Encode())
+			assert.Equal(t, tc.canonical, d.EncodeCanonical())
+			assert.Equal(t, tc.canonical, d.Canonical().Encode())
+			assert.Equal(t, tc.have == tc.canonical, d.IsCanonical())
+
+			nd, err := NewNullDigestDecode(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.canonical, nd.EncodeCanonical())
+			assert.Equal(t, tc.canonical, nd.Canonical().Encode())
+			assert.Equal(t, tc.have == tc.canonical, nd.IsCanonical())
+		})
+	}
+
+	nd := NewNullDigest(nil)
+
+	assert.Equal(t, "", nd.EncodeCanonical())
+	assert.Equal(t, "", nd.Canonical().Encode())
+	assert.True(t, nd.IsCanonical())
+}
+
+func TestDecoderRegisterFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format Format
+		err    string
+	}{
+		{
+			"ShouldRegisterNew",
+			NewFormatPrefix("test", "{TEST}"),
+			"",
+		},
+		{
+			"ShouldFailDuplicate",
+			NewFormatPrefix(FormatNameLDAPCrypt, "{TEST}"),
+			"format already registered with name 'ldap-crypt'",
+		},
+		{
+			"ShouldFailNil",
+			nil,
+			"can't register a nil format",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDecoderStrict()
+
+			require.NoError(t, d.RegisterFormat(NewFormatLDAPCrypt()))
+
+			err := d.RegisterFormat(tc.format)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestEncodeAs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		format   string
+		expected string
+		err      string
+	}{
+		{
+			"ShouldEncodeLDAPCrypt",
+			"$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+			FormatNameLDAPCrypt,
+			"{CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+			"",
+		},
+		{
+			"ShouldEncodeLDAPCryptCanonical",
+			"{CRYPT}$2a$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			FormatNameLDAPCrypt,
+			"{CRYPT}$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"",
+		},
+		{
+			"ShouldEncodeLDAPArgon2",
+			"$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			FormatNameLDAPArgon2,
+			"{ARGON2}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			"",
+		},
+		{
+			"ShouldEncodeLDAPPBKDF2",
+			"$pbkdf2-sha256$100000$aoWHXwyz0im1Hqg93.N.tA$bO5LsjmnnPle2Xm9RE6W1PMWdJTy1TnEia1TLzynuIQ",
+			FormatNameLDAPPBKDF2,
+			"{PBKDF2-SHA256}100000$aoWHXwyz0im1Hqg93.N.tA$bO5LsjmnnPle2Xm9RE6W1PMWdJTy1TnEia1TLzynuIQ",
+			"",
+		},
+		{
+			"ShouldEncodeLDAPPBKDF2SHA1",
+			"$pbkdf2-sha1$100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+			FormatNameLDAPPBKDF2,
+			"{PBKDF2}100000$atrXFCWdBlpmzIi/nXwJOw$20Lsx44nZwmh09bjGHFJ//oRZh8",
+			"",
+		},
+		{
+			"ShouldFailLDAPArgon2WithBcrypt",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			FormatNameLDAPArgon2,
+			"",
+			"format 'ldap-argon2' does not support digests with the identifier '2b'",
+		},
+		{
+			"ShouldFailLDAPPBKDF2WithBcrypt",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			FormatNameLDAPPBKDF2,
+			"",
+			"format 'ldap-pbkdf2' does not support digests with the identifier '2b'",
+		},
+		{
+			"ShouldFailUnknownFormat",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			"unknown",
+			"",
+			"format isn't registered with name 'unknown'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+			require.NoError(t, err)
+
+			value, err := EncodeAs(digest, tc.format)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, "", value)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+
+			decoded, err := Decode(value)
+			require.NoError(t, err)
+
+			assert.Equal(t, value, decoded.Encode())
+			assert.True(t, decoded.Match(password))
+		})
+	}
+
+	_, err := EncodeAs(nil, FormatNameLDAPCrypt)
+	assert.EqualError(t, err, "can't encode a nil digest")
+}
+
+func TestDecoderCustomFormat(t *testing.T) {
+	d, err := NewDefaultDecoder()
+	require.NoError(t, err)
+
+	require.NoError(t, d.RegisterFormat(NewFormatPrefix("vendor", "{VENDOR}", bcrypt.AlgIdentifier)))
+
+	digest, err := d.Decode("{VENDOR}$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e")
+	require.NoError(t, err)
+
+	assert.Equal(t, "{VENDOR}$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e", digest.Encode())
+	assert.Equal(t, "$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e", EncodeCanonical(digest))
+	assert.True(t, digest.Match(password))
+
+	value, err := d.EncodeAs(digest, FormatNameLDAPCrypt)
+	require.NoError(t, err)
+	assert.Equal(t, "{CRYPT}$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e", value)
+
+	value, err = d.EncodeAs(digest, "vendor")
+	require.NoError(t, err)
+	assert.Equal(t, "{VENDOR}$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e", value)
+}
+
+func TestDecoderNormalizers(t *testing.T) {
+	const (
+		encodedDigest = "$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e"
+	)
+
+	t.Run("ShouldRefuseLDAPPrefixWithStrictDecoder", func(t *testing.T) {
+		d := NewDecoderStrict()
+
+		require.NoError(t, bcrypt.RegisterDecoder(d))
+
+		_, err := d.Decode("{CRYPT}" + encodedDigest)
+		assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+
+		digest, err := d.Decode(encodedDigest)
+		require.NoError(t, err)
+		assert.True(t, digest.Match(password))
+	})
+
+	t.Run("ShouldDecodeLDAPPrefixWithDecoder", func(t *testing.T) {
+		d := NewDecoder()
+
+		require.NoError(t, bcrypt.RegisterDecoder(d))
+
+		digest, err := d.Decode("{CRYPT}" + encodedDigest)
+		require.NoError(t, err)
+
+		assert.True(t, digest.Match(password))
+		assert.Equal(t, "{CRYPT}"+encodedDigest, digest.Encode())
+	})
+
+	t.Run("ShouldApplyNormalizersInOrder", func(t *testing.T) {
+		d := NewDecoderStrict()
+
+		require.NoError(t, bcrypt.RegisterDecoder(d))
+		require.NoError(t, d.RegisterNormalizer(func(encodedDigest string) (normalized string, ok bool) {
+			if !strings.HasPrefix(encodedDigest, "vendor:") {
+				return encodedDigest, false
+			}
+
+			return encodedDigest[7:], true
+		}))
+		require.NoError(t, d.RegisterFormat(NewFormatLDAPCrypt()))
+
+		digest, err := d.Decode("vendor:{CRYPT}" + encodedDigest)
+		require.NoError(t, err)
+
+		assert.Equal(t, "vendor:{CRYPT}"+encodedDigest, digest.Encode())
+		assert.Equal(t, encodedDigest, EncodeCanonical(digest))
+
+		_, err = d.Decode("{CRYPT}vendor:" + encodedDigest)
+		assert.EqualError(t, err, "provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats")
+	})
+
+	t.Run("ShouldNotRetainPrefixWhenModified", func(t *testing.T) {
+		d := NewDecoder()
+
+		require.NoError(t, bcrypt.RegisterDecoder(d))
+		require.NoError(t, d.RegisterNormalizer(func(encodedDigest string) (normalized string, ok bool) {
+			if !strings.HasPrefix(encodedDigest, "$2B$") {
+				return encodedDigest, false
+			}
+
+			return "$2b$" + encodedDigest[4:], true
+		}))
+
+		digest, err := d.Decode("$2B$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e")
+		require.NoError(t, err)
+
+		assert.Equal(t, encodedDigest, digest.Encode())
+		assert.True(t, digest.Match(password))
+	})
+
+	t.Run("ShouldFailNilNormalizer", func(t *testing.T) {
+		d := NewDecoder()
+
+		assert.EqualError(t, d.RegisterNormalizer(nil), "can't register a nil normalizer")
+	})
+}
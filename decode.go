@@ -30,3 +30,15 @@ func decode(encodedDigest string) (digest algorithm.Digest, err error) {
 
 	return gdecoder.Decode(encodedDigest)
 }
+
+// EncodeAs is a convenience function which encodes the algorithm.Digest using the output side of the built-in Format
+// with the provided name. See Decoder.EncodeAs.
+func EncodeAs(digest algorithm.Digest, format string) (value string, err error) {
+	if gdecoder == nil {
+		if gdecoder, err = NewDefaultDecoder(); err != nil {
+			return "", err
+		}
+	}
+
+	return gdecoder.EncodeAs(digest, format)
+}
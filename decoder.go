@@ -9,18 +9,36 @@ import (
 	"github.com/go-crypt/crypt/algorithm/argon2"
 	"github.com/go-crypt/crypt/algorithm/bcrypt"
 	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/nthash"
 	"github.com/go-crypt/crypt/algorithm/pbkdf2"
 	"github.com/go-crypt/crypt/algorithm/plaintext"
 	"github.com/go-crypt/crypt/algorithm/scrypt"
 	"github.com/go-crypt/crypt/algorithm/sha1crypt"
 	"github.com/go-crypt/crypt/algorithm/shacrypt"
 	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
 )
 
-// NewDecoder returns a new empty *Decoder.
+// NewDecoder returns a new *Decoder without any decoders registered. Only the built-in Format's are registered.
 //
-// See Also: NewDefaultDecoder and NewDecoderAll.
+// See Also: NewDecoderStrict, NewDefaultDecoder, and NewDecoderAll.
 func NewDecoder() *Decoder {
+	d := NewDecoderStrict()
+
+	for _, format := range formatsDefault() {
+		d.formats = append(d.formats, format)
+		d.normalizers = append(d.normalizers, newDecodeNormalizer(format))
+	}
+
+	return d
+}
+
+// NewDecoderStrict returns a new empty *Decoder. No normalizers or Format's are registered so encoded digests are
+// decoded strictly, for example encoded digests with the OpenLDAP {CRYPT} prefix are refused. The built-in Format's can
+// be individually registered via RegisterFormat.
+//
+// See Also: NewDecoder.
+func NewDecoderStrict() *Decoder {
 	return &Decoder{
 		decoders: map[string]algorithm.DecodeFunc{},
 		prefixes: map[string]string{},
@@ -31,11 +49,14 @@ func NewDecoder() *Decoder {
 //
 // Loaded Decoders: argon2, bcrypt, pbkdf2, scrypt, shacrypt.
 //
+// Loaded Formats: ldap-crypt, ldap-argon2, ldap-pbkdf2.
+//
 // CRITICAL STABILITY NOTE: the decoders loaded via this function are not guaranteed to remain the same. It is strongly
-// recommended that users implementing this library use this or NewDecodersAll only as an example for building their own
-// decoder via NewDecoder instead which returns an empty decoder. It is much safer for security and stability to be
-// explicit in harmony with your specific use case. It is the responsibility of the implementer to determine which
-// password algorithms are sufficiently safe for their particular use case.
+// recommended that users implementing this library use this or NewDecoderAll only as an example for building their own
+// decoder via NewDecoderStrict instead which returns an empty decoder, or NewDecoder which only registers the built-in
+// Format's. It is much safer for security and stability to be explicit in harmony with your specific use case. It is
+// the responsibility of the implementer to determine which password algorithms are sufficiently safe for their
+// particular use case.
 func NewDefaultDecoder() (d *Decoder, err error) {
 	d = &Decoder{
 		decoders: map[string]algorithm.DecodeFunc{},
@@ -51,13 +72,14 @@ func NewDefaultDecoder() (d *Decoder, err error) {
 
 // NewDecoderAll is the same as NewDefaultDecoder but it also adds legacy and/or insecure decoders.
 //
-// Loaded Decoders (in addition to NewDefaultDecoder): plaintext, md5crypt, sha1crypt.
+// Loaded Decoders (in addition to NewDefaultDecoder): plaintext, md5crypt, sha1crypt, nthash.
 //
 // CRITICAL STABILITY NOTE: the decoders loaded via this function are not guaranteed to remain the same. It is strongly
-// recommended that users implementing this library use this or NewDecodersAll only as an example for building their own
-// decoder via NewDecoder instead which returns an empty decoder. It is much safer for security and stability to be
-// explicit in harmony with your specific use case. It is the responsibility of the implementer to determine which
-// password algorithms are sufficiently safe for their particular use case.
+// recommended that users implementing this library use this or NewDefaultDecoder only as an example for building their
+// own decoder via NewDecoderStrict instead which returns an empty decoder, or NewDecoder which only registers the
+// built-in Format's. It is much safer for security and stability to be explicit in harmony with your specific use case.
+// It is the responsibility of the implementer to determine which password algorithms are sufficiently safe for their
+// particular use case.
 func NewDecoderAll() (d *Decoder, err error) {
 	d = &Decoder{
 		decoders: map[string]algorithm.DecodeFunc{},
@@ -80,6 +102,10 @@ func NewDecoderAll() (d *Decoder, err error) {
 		return nil, fmt.Errorf("could not register the sha1crypt decoder: %w", err)
 	}
 
+	if err = nthash.RegisterDecoder(d); err != nil {
+		return nil, fmt.Errorf("could not register the nthash decoder: %w", err)
+	}
+
 	return d, nil
 }
 
@@ -88,6 +114,19 @@ func NewDecoderAll() (d *Decoder, err error) {
 type Decoder struct {
 	decoders map[string]algorithm.DecodeFunc
 	prefixes map[string]string
+	matchers []decodeMatcher
+
+	formats     []Format
+	normalizers []decodeNormalizer
+}
+
+// NormalizeFunc describes a function which normalizes an encoded digest before it's decoded, returning false if the
+// encoded digest was not modified.
+type NormalizeFunc func(encodedDigest string) (normalized string, ok bool)
+
+type decodeMatcher struct {
+	identifier string
+	match      algorithm.DecodeMatchFunc
 }
 
 // RegisterDecodeFunc registers a new algorithm.DecodeFunc with this Decoder against a specific identifier.
@@ -124,6 +163,83 @@ func (d *Decoder) RegisterDecodePrefix(prefix, identifier string) (err error) {
 	return nil
 }
 
+// RegisterDecodeMatchFunc registers an algorithm.DecodeMatchFunc which is used to select the algorithm.DecodeFunc
+// registered against a specific identifier for encoded digests which do not begin with the delimiter. The
+// algorithm.DecodeMatchFunc's are checked in the order they were registered.
+func (d *Decoder) RegisterDecodeMatchFunc(identifier string, matcher algorithm.DecodeMatchFunc) (err error) {
+	if d.decoders == nil {
+		return fmt.Errorf("no decoders are registered")
+	}
+
+	if matcher == nil {
+		return fmt.Errorf("can't register a nil match func for identifier '%s'", identifier)
+	}
+
+	if _, ok := d.decoders[identifier]; !ok {
+		return fmt.Errorf("decoder isn't registered for identifier '%s'", identifier)
+	}
+
+	for _, m := range d.matchers {
+		if m.identifier == identifier {
+			return fmt.Errorf("match func already registered for identifier '%s'", identifier)
+		}
+	}
+
+	d.matchers = append(d.matchers, decodeMatcher{identifier: identifier, match: matcher})
+
+	return nil
+}
+
+// RegisterNormalizer registers a NormalizeFunc with this Decoder. Each NormalizeFunc and the input side of each Format
+// is applied to encoded digests before they are decoded in the order they were registered.
+func (d *Decoder) RegisterNormalizer(normalizer NormalizeFunc) (err error) {
+	if normalizer == nil {
+		return fmt.Errorf("can't register a nil normalizer")
+	}
+
+	d.normalizers = append(d.normalizers, decodeNormalizer{normalize: normalizer})
+
+	return nil
+}
+
+// RegisterFormat registers a Format with this Decoder. The input side of each Format and each NormalizeFunc is applied
+// to encoded digests before they are decoded in the order they were registered, and the output side is used by
+// EncodeAs. The built-in formats are registered by NewDecoder, NewDefaultDecoder, and NewDecoderAll.
+func (d *Decoder) RegisterFormat(format Format) (err error) {
+	if format == nil {
+		return fmt.Errorf("can't register a nil format")
+	}
+
+	name := format.Name()
+
+	for _, f := range d.formats {
+		if f.Name() == name {
+			return fmt.Errorf("format already registered with name '%s'", name)
+		}
+	}
+
+	d.formats = append(d.formats, format)
+	d.normalizers = append(d.normalizers, newDecodeNormalizer(format))
+
+	return nil
+}
+
+// EncodeAs encodes the algorithm.Digest using the output side of the Format registered with this Decoder with the
+// provided name.
+func (d *Decoder) EncodeAs(digest algorithm.Digest, format string) (value string, err error) {
+	if digest == nil {
+		return "", fmt.Errorf("can't encode a nil digest")
+	}
+
+	for _, f := range d.formats {
+		if f.Name() == format {
+			return f.Encode(digest)
+		}
+	}
+
+	return "", fmt.Errorf("format isn't registered with name '%s'", format)
+}
+
 // Decode an encoded digest into a algorithm.Digest.
 func (d *Decoder) Decode(encodedDigest string) (digest algorithm.Digest, err error) {
 	if digest, err = d.decode(encodedDigest); err != nil {
@@ -140,13 +256,35 @@ func (d *Decoder) decode(encodedDigest string) (digest algorithm.Digest, err err
 		}
 	}
 
-	encodedDigest = Normalize(encodedDigest)
+	normalized, prefix := normalize(d.normalizers, encodedDigest)
+
+	if digest, err = d.decodeNormalized(normalized); err != nil {
+		return nil, err
+	}
+
+	if prefix != "" {
+		if p, ok := digest.(algorithm.StoragePrefixDigest); ok {
+			p.WithStoragePrefix(prefix)
+		}
+	}
+
+	return digest, nil
+}
+
+func (d *Decoder) decodeNormalized(encodedDigest string) (digest algorithm.Digest, err error) {
+	if len(encodedDigest) != 0 && rune(encodedDigest[0]) != encoding.Delimiter {
+		for _, matcher := range d.matchers {
+			if matcher.match(encodedDigest) {
+				return d.decoders[matcher.identifier](encodedDigest)
+			}
+		}
+	}
 
 	if len(encodedDigest) == 0 || rune(encodedDigest[0]) != encoding.Delimiter {
 		return nil, fmt.Errorf("%w: the digest doesn't begin with the delimiter %s and is not one of the other understood formats", algorithm.ErrEncodedHashInvalidFormat, strconv.QuoteRune(encoding.Delimiter))
 	}
 
-	parts := encoding.Split(encodedDigest, 3)
+	parts := phc.Split(encodedDigest, 3)
 
 	if len(parts) != 3 {
 		return nil, fmt.Errorf("%w: the digest doesn't have the minimum number of parts for it to be considered an encoded digest", algorithm.ErrEncodedHashInvalidFormat)
@@ -165,6 +303,12 @@ func (d *Decoder) decode(encodedDigest string) (digest algorithm.Digest, err err
 }
 
 func decoderProfileDefault(decoder *Decoder) (err error) {
+	for _, format := range formatsDefault() {
+		if err = decoder.RegisterFormat(format); err != nil {
+			return fmt.Errorf("could not register the %s format: %w", format.Name(), err)
+		}
+	}
+
 	if err = argon2.RegisterDecoder(decoder); err != nil {
 		return fmt.Errorf("could not register the argon2 decoder: %w", err)
 	}
@@ -0,0 +1,81 @@
+package dovecot
+
+const (
+	// AlgName is the name for this package.
+	AlgName = "dovecot"
+
+	// AlgIdentifier is the identifier the decoder for this package is registered with.
+	AlgIdentifier = AlgName
+
+	// FormatName is the name of the dovecot.Format.
+	FormatName = AlgName
+
+	// SchemeNameMD5Crypt is the name of the Dovecot MD5-CRYPT scheme.
+	SchemeNameMD5Crypt = "MD5-CRYPT"
+
+	// SchemeNameSHA256Crypt is the name of the Dovecot SHA256-CRYPT scheme.
+	SchemeNameSHA256Crypt = "SHA256-CRYPT"
+
+	// SchemeNameSHA512Crypt is the name of the Dovecot SHA512-CRYPT scheme.
+	SchemeNameSHA512Crypt = "SHA512-CRYPT"
+
+	// SchemeNameBLFCrypt is the name of the Dovecot BLF-CRYPT scheme.
+	SchemeNameBLFCrypt = "BLF-CRYPT"
+
+	// SchemeNameArgon2I is the name of the Dovecot ARGON2I scheme.
+	SchemeNameArgon2I = "ARGON2I"
+
+	// SchemeNameArgon2ID is the name of the Dovecot ARGON2ID scheme.
+	SchemeNameArgon2ID = "ARGON2ID"
+
+	// SchemeNamePBKDF2 is the name of the Dovecot PBKDF2 scheme.
+	SchemeNamePBKDF2 = "PBKDF2"
+
+	// SchemeNameSHA is the name of the Dovecot SHA scheme.
+	SchemeNameSHA = "SHA"
+
+	// SchemeNameSSHA is the name of the Dovecot SSHA scheme.
+	SchemeNameSSHA = "SSHA"
+
+	// SchemeNameSHA256 is the name of the Dovecot SHA256 scheme.
+	SchemeNameSHA256 = "SHA256"
+
+	// SchemeNameSSHA256 is the name of the Dovecot SSHA256 scheme.
+	SchemeNameSSHA256 = "SSHA256"
+
+	// SchemeNameSHA512 is the name of the Dovecot SHA512 scheme.
+	SchemeNameSHA512 = "SHA512"
+
+	// SchemeNameSSHA512 is the name of the Dovecot SSHA512 scheme.
+	SchemeNameSSHA512 = "SSHA512"
+
+	// SchemeNameCRAMMD5 is the name of the Dovecot CRAM-MD5 scheme.
+	SchemeNameCRAMMD5 = "CRAM-MD5"
+
+	// EncodingSuffixBase64 is the scheme suffix which forces the base64 encoding.
+	EncodingSuffixBase64 = "b64"
+
+	// EncodingSuffixBase64Long is the alternative scheme suffix which forces the base64 encoding.
+	EncodingSuffixBase64Long = "base64"
+
+	// EncodingSuffixHex is the scheme suffix which forces the hex encoding.
+	EncodingSuffixHex = "hex"
+
+	// PBKDF2EncodingFmt is the encoding format of the Dovecot PBKDF2 scheme.
+	PBKDF2EncodingFmt = "{%s}$1$%s$%d$%x"
+
+	// PBKDF2SaltCharSet are the characters Dovecot uses for the salt of the PBKDF2 scheme.
+	PBKDF2SaltCharSet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// PBKDF2SaltLength is the salt size Dovecot uses for the PBKDF2 scheme.
+	PBKDF2SaltLength = 16
+
+	// PBKDF2KeyLength is the key size of the PBKDF2 scheme.
+	PBKDF2KeyLength = 20
+
+	// SaltLengthMin is the minimum salt size accepted for the salted schemes.
+	SaltLengthMin = 1
+
+	// SaltLengthMax is the maximum salt size accepted for the salted schemes.
+	SaltLengthMax = 1024
+)
@@ -0,0 +1,222 @@
+package dovecot
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+	"github.com/go-crypt/crypt/internal/encoding"
+)
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which begin with the prefix of a known dovecot.Scheme such as {SHA512-CRYPT} or {SSHA512.HEX}.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifier, MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest begins with the prefix of a known dovecot.Scheme.
+func MatchEncoded(encodedDigest string) (match bool) {
+	scheme, _, _, _, _ := decoderParts(encodedDigest)
+
+	return scheme != SchemeNone
+}
+
+// Decode the encoded digest into a algorithm.Digest. The crypt based schemes are decoded into the algorithm.Digest of
+// the relevant package with the scheme prefix retained as the storage prefix, the PBKDF2 scheme is decoded into a
+// pbkdf2.Digest, and all other schemes are decoded into a dovecot.Digest.
+//
+// The Dovecot PBKDF2 layout is not retained by the pbkdf2.Digest so its Encode method returns the pbkdf2 encoded form
+// such as $pbkdf2$<iterations>$<salt>$<key>. The dovecot.Encode function must be used to encode it in the Dovecot
+// layout again.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	var (
+		scheme       Scheme
+		enc          Encoding
+		prefix, data string
+	)
+
+	if scheme, enc, prefix, data, err = decoderParts(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	switch {
+	case scheme.binary():
+		if digest, err = decodeBinary(scheme, enc, prefix, data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	case scheme == SchemePBKDF2:
+		var encodedPBKDF2 string
+
+		if encodedPBKDF2, err = decodePBKDF2(data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		if digest, err = pbkdf2.DecodeVariant(pbkdf2.VariantSHA1)(encodedPBKDF2); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	default:
+		if digest, err = decodeCrypt(scheme, prefix, data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+}
+
+func decoderParts(encodedDigest string) (scheme Scheme, enc Encoding, prefix, data string, err error) {
+	end := strings.IndexRune(encodedDigest, '}')
+
+	if !strings.HasPrefix(encodedDigest, "{") || end == -1 {
+		return SchemeNone, EncodingNone, "", "", fmt.Errorf("%w: the digest doesn't begin with a scheme prefix", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	prefix, data = encodedDigest[:end+1], encodedDigest[end+1:]
+
+	name, suffix, found := strings.Cut(prefix[1:end], ".")
+
+	if scheme = NewScheme(name); scheme == SchemeNone {
+		return SchemeNone, EncodingNone, "", "", fmt.Errorf("%w: scheme '%s' is not a supported %s scheme", algorithm.ErrEncodedHashInvalidIdentifier, name, AlgName)
+	}
+
+	if !found {
+		return scheme, EncodingNone, prefix, data, nil
+	}
+
+	if !scheme.binary() {
+		return scheme, EncodingNone, "", "", fmt.Errorf("%w: scheme '%s' does not support encoding suffixes", algorithm.ErrEncodedHashInvalidIdentifier, scheme)
+	}
+
+	if enc = NewEncoding(suffix); enc == EncodingNone {
+		return scheme, EncodingNone, "", "", fmt.Errorf("%w: encoding suffix '%s' is unknown", algorithm.ErrEncodedHashInvalidIdentifier, suffix)
+	}
+
+	return scheme, enc, prefix, data, nil
+}
+
+func decodeCrypt(scheme Scheme, prefix, data string) (digest algorithm.Digest, err error) {
+	var decode algorithm.DecodeFunc
+
+	switch scheme {
+	case SchemeMD5Crypt:
+		decode = md5crypt.DecodeVariant(md5crypt.VariantStandard)
+	case SchemeSHA256Crypt:
+		decode = shacrypt.DecodeVariant(shacrypt.VariantSHA256)
+	case SchemeSHA512Crypt:
+		decode = shacrypt.DecodeVariant(shacrypt.VariantSHA512)
+	case SchemeBLFCrypt:
+		decode = bcrypt.DecodeVariant(bcrypt.VariantStandard)
+	case SchemeArgon2I:
+		decode = argon2.DecodeVariant(argon2.VariantI)
+	case SchemeArgon2ID:
+		decode = argon2.DecodeVariant(argon2.VariantID)
+	}
+
+	if digest, err = decode(data); err != nil {
+		return nil, err
+	}
+
+	if p, ok := digest.(algorithm.StoragePrefixDigest); ok {
+		p.WithStoragePrefix(prefix)
+	}
+
+	return digest, nil
+}
+
+func decodeBinary(scheme Scheme, enc Encoding, prefix, data string) (digest algorithm.Digest, err error) {
+	decoded := &Digest{
+		scheme:   scheme,
+		encoding: enc,
+		prefix:   prefix,
+	}
+
+	size := scheme.size()
+
+	if enc == EncodingNone {
+		enc = scheme.encoding()
+
+		// Dovecot also accepts the hex encoding for the unsalted schemes which are base64 encoded by default.
+		if enc == EncodingBase64 && !scheme.salted() && len(data) == size*2 {
+			if _, err = hex.DecodeString(data); err == nil {
+				enc, decoded.encoding = EncodingHex, EncodingHex
+			}
+		}
+	}
+
+	var raw []byte
+
+	if raw, err = enc.Decode(data); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	switch {
+	case scheme.salted():
+		if len(raw) <= size {
+			return nil, fmt.Errorf("%w: key and salt have %d bytes but must have more than %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), size)
+		}
+
+		decoded.key, decoded.salt = raw[:size], raw[size:]
+	default:
+		if len(raw) != size {
+			return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), size)
+		}
+
+		decoded.key = raw
+	}
+
+	return decoded, nil
+}
+
+// decodePBKDF2 converts the Dovecot PBKDF2 layout which is $1$<salt>$<rounds>$<hex key> into the encoded form used by
+// the pbkdf2.Digest.
+func decodePBKDF2(data string) (encodedDigest string, err error) {
+	if !strings.HasPrefix(data, "$1$") {
+		return "", fmt.Errorf("%w: the %s scheme digest doesn't begin with '$1$'", algorithm.ErrEncodedHashInvalidFormat, SchemePBKDF2)
+	}
+
+	parts := strings.Split(data[3:], encoding.DelimiterStr)
+
+	if len(parts) != 3 {
+		return "", algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	var (
+		iterations int
+		key        []byte
+	)
+
+	if iterations, err = strconv.Atoi(parts[1]); err != nil {
+		return "", fmt.Errorf("%w: iterations could not be parsed: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+	}
+
+	if key, err = hex.DecodeString(parts[2]); err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(key) != PBKDF2KeyLength {
+		return "", fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(key), PBKDF2KeyLength)
+	}
+
+	return fmt.Sprintf(pbkdf2.EncodingFmt, pbkdf2.AlgIdentifier, iterations,
+		encoding.Base64RawAdaptedEncoding.EncodeToString([]byte(parts[0])),
+		encoding.Base64RawAdaptedEncoding.EncodeToString(key),
+	), nil
+}
@@ -0,0 +1,156 @@
+package dovecot
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/subtle"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is an algorithm.Digest which handles the Dovecot SHA, SSHA, SHA256, SSHA256, SHA512, SSHA512, and CRAM-MD5
+// schemes.
+type Digest struct {
+	scheme   Scheme
+	encoding Encoding
+
+	salt, key []byte
+
+	prefix string
+}
+
+// Match returns true if the string password matches the current dovecot.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current dovecot.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.derive(passwordBytes)) == 1, nil
+}
+
+// Encode returns the encoded form of this dovecot.Digest.
+func (d *Digest) Encode() string {
+	prefix := d.prefix
+
+	if prefix == "" {
+		prefix = d.scheme.Prefix(d.encoding)
+	}
+
+	return prefix + d.encodeRaw()
+}
+
+// encodeRaw returns the encoded key and salt of this dovecot.Digest without the scheme prefix.
+func (d *Digest) encodeRaw() string {
+	encoding := d.encoding
+
+	if encoding == EncodingNone {
+		encoding = d.scheme.encoding()
+	}
+
+	return encoding.Encode(append(append([]byte{}, d.key...), d.salt...))
+}
+
+// String returns the storable format of the dovecot.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw unencoded key which is the final result of this digest.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns the raw unencoded salt used to generate this digest.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Scheme returns the dovecot.Scheme of this dovecot.Digest.
+func (d *Digest) Scheme() (scheme Scheme) {
+	return d.scheme
+}
+
+// Canonical returns a copy of this dovecot.Digest which is encoded in the canonical form.
+func (d *Digest) Canonical() (digest algorithm.Digest) {
+	c := *d
+
+	c.encoding = EncodingNone
+	c.prefix = ""
+
+	return &c
+}
+
+func (d *Digest) derive(passwordBytes []byte) (key []byte) {
+	if d.scheme == SchemeCRAMMD5 {
+		return cramMD5(passwordBytes)
+	}
+
+	h := d.scheme.hashFunc()()
+
+	h.Write(passwordBytes)
+	h.Write(d.salt)
+
+	return h.Sum(nil)
+}
+
+// cramMD5 returns the HMAC-MD5 context of the password in the layout used by Dovecot which is the outer and inner MD5
+// states after the padded key block has been processed, each as four little-endian words.
+func cramMD5(passwordBytes []byte) (key []byte) {
+	if len(passwordBytes) > md5.BlockSize {
+		sum := md5.Sum(passwordBytes)
+
+		passwordBytes = sum[:]
+	}
+
+	ipad, opad := make([]byte, md5.BlockSize), make([]byte, md5.BlockSize)
+
+	copy(ipad, passwordBytes)
+	copy(opad, passwordBytes)
+
+	for i := range ipad {
+		ipad[i] ^= 0x36
+		opad[i] ^= 0x5c
+	}
+
+	key = make([]byte, 0, md5.Size*2)
+
+	key = appendMD5State(key, opad)
+	key = appendMD5State(key, ipad)
+
+	return key
+}
+
+// appendMD5State appends the MD5 state after processing the block. The marshaled state of crypto/md5 begins with a
+// four byte magic value followed by the four big-endian state words.
+func appendMD5State(dst, block []byte) []byte {
+	h := md5.New()
+
+	h.Write(block)
+
+	state, _ := h.(encoding.BinaryMarshaler).MarshalBinary()
+
+	for i := 4; i < 20; i += 4 {
+		dst = binary.LittleEndian.AppendUint32(dst, binary.BigEndian.Uint32(state[i:]))
+	}
+
+	return dst
+}
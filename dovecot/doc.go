@@ -0,0 +1,14 @@
+// Package dovecot provides compatibility with the Dovecot password schemes and implements
+// github.com/go-crypt/crypt interfaces.
+//
+// The SHA256-CRYPT, SHA512-CRYPT, MD5-CRYPT, BLF-CRYPT, ARGON2I, and ARGON2ID schemes are decoded into the
+// shacrypt.Digest, md5crypt.Digest, bcrypt.Digest, and argon2.Digest respectively and retain the scheme as their
+// storage prefix. The Dovecot specific PBKDF2 scheme is decoded into a pbkdf2.Digest, and the SHA, SSHA, SHA256,
+// SSHA256, SHA512, SSHA512, and CRAM-MD5 schemes are decoded into a dovecot.Digest. The dovecot.Encode function
+// encodes any of these digests in the form Dovecot expects. The pbkdf2.Digest does not retain the PBKDF2 scheme layout
+// so its Encode method returns the pbkdf2 encoded form and only dovecot.Encode returns the original layout. The
+// dovecot.Format implements the crypt.Format interface and can be registered with a crypt.Decoder so the same digests
+// can be encoded via its EncodeAs method.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package dovecot
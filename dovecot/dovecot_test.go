@@ -0,0 +1,446 @@
+package dovecot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+const (
+	password = "password"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		have      string
+		expected  algorithm.Digest
+		canonical string
+	}{
+		{
+			"ShouldDecodeSHA512Crypt",
+			"{SHA512-CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+			&shacrypt.Digest{},
+			"$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90",
+		},
+		{
+			"ShouldDecodeSHA256Crypt",
+			"{SHA256-CRYPT}$5$saltsalt$gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.",
+			&shacrypt.Digest{},
+			"$5$saltsalt$gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.",
+		},
+		{
+			"ShouldDecodeMD5Crypt",
+			"{MD5-CRYPT}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+			&md5crypt.Digest{},
+			"$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		},
+		{
+			"ShouldDecodeBLFCrypt",
+			"{BLF-CRYPT}$2y$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			&bcrypt.Digest{},
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+		},
+		{
+			"ShouldDecodeArgon2ID",
+			"{ARGON2ID}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			&argon2.Digest{},
+			"$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+		},
+		{
+			"ShouldDecodeArgon2I",
+			"{ARGON2I}$argon2i$v=19$m=65536,t=3,p=4$ScGiEq8Low5K7B7/IwYxgA$q6Zo0u/aDtZk404ZNmBi33WXkC5g0y60QdOQQ3oziyU",
+			&argon2.Digest{},
+			"$argon2i$v=19$m=65536,t=3,p=4$ScGiEq8Low5K7B7/IwYxgA$q6Zo0u/aDtZk404ZNmBi33WXkC5g0y60QdOQQ3oziyU",
+		},
+		{
+			"ShouldDecodePBKDF2",
+			"{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000$4b2ce245e5c349959c42937aabfee113a633b237",
+			&pbkdf2.Digest{},
+			"$pbkdf2$5000$RnRXd3l5VjJteGFPdHpCOA$SyziReXDSZWcQpN6q/7hE6Yzsjc",
+		},
+		{
+			"ShouldDecodeSSHA512",
+			"{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI",
+			&Digest{},
+			"{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI",
+		},
+		{
+			"ShouldDecodeSSHA512Hex",
+			"{SSHA512.HEX}73035584eb80000d683e7f822fec12d5fd2d3d9ada71eccdd32614d31a98a2b9d740143da8110bb38e14268535d3f4e4ef11e3503035afdce576b70f52a1c1340102030405060708",
+			&Digest{},
+			"{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI",
+		},
+		{
+			"ShouldDecodeSSHA256",
+			"{SSHA256}JDUXfxQQU2uq0qzBVcD5R4PVg4RXPLD3IVdENgYoXT8BAgMEBQYHCA==",
+			&Digest{},
+			"{SSHA256}JDUXfxQQU2uq0qzBVcD5R4PVg4RXPLD3IVdENgYoXT8BAgMEBQYHCA==",
+		},
+		{
+			"ShouldDecodeSSHALowerCaseWithBase64Suffix",
+			"{ssha.b64}N+vXsN2ny8mTqd6ZYuHcJVHvE00BAgMEBQYHCA==",
+			&Digest{},
+			"{SSHA}N+vXsN2ny8mTqd6ZYuHcJVHvE00BAgMEBQYHCA==",
+		},
+		{
+			"ShouldDecodeSHA",
+			"{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+			&Digest{},
+			"{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+		},
+		{
+			"ShouldDecodeSHAHexWithoutSuffix",
+			"{SHA}5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8",
+			&Digest{},
+			"{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+		},
+		{
+			"ShouldDecodeSHA256",
+			"{SHA256}XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=",
+			&Digest{},
+			"{SHA256}XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=",
+		},
+		{
+			"ShouldDecodeSHA512",
+			"{SHA512}sQnzu7wkTrgkQZF+0G1hi5AI3Qmzvv0bXgc5THBqi7mAsdd4Xll27ASbRt9fEyavWi6m0QP9B8lThf+rDKy8hg==",
+			&Digest{},
+			"{SHA512}sQnzu7wkTrgkQZF+0G1hi5AI3Qmzvv0bXgc5THBqi7mAsdd4Xll27ASbRt9fEyavWi6m0QP9B8lThf+rDKy8hg==",
+		},
+		{
+			"ShouldDecodeCRAMMD5",
+			"{CRAM-MD5}9186d855e11eba527a7a52ca82b313e180d62234f0acc9051b527243d41e2740",
+			&Digest{},
+			"{CRAM-MD5}9186d855e11eba527a7a52ca82b313e180d62234f0acc9051b527243d41e2740",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, digest)
+
+			assert.True(t, digest.Match(password))
+			assert.False(t, digest.Match("wrong"))
+
+			assert.Equal(t, tc.canonical, crypt.EncodeCanonical(digest))
+
+			value, err := Encode(digest)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, value)
+
+			if _, ok := digest.(*pbkdf2.Digest); ok {
+				assert.Equal(t, tc.canonical, digest.Encode())
+			} else {
+				assert.Equal(t, tc.have, digest.Encode())
+			}
+		})
+	}
+}
+
+func TestDecodePBKDF2RoundTrip(t *testing.T) {
+	encoded := "{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000$4b2ce245e5c349959c42937aabfee113a633b237"
+
+	digest, err := Decode(encoded)
+	require.NoError(t, err)
+
+	// The Dovecot layout is lost when the pbkdf2.Digest is encoded by itself.
+	assert.Equal(t, "$pbkdf2$5000$RnRXd3l5VjJteGFPdHpCOA$SyziReXDSZWcQpN6q/7hE6Yzsjc", digest.Encode())
+
+	value, err := Encode(digest)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, value)
+
+	decoded, err := pbkdf2.Decode(digest.Encode())
+	require.NoError(t, err)
+	assert.True(t, decoded.Match(password))
+	assert.Equal(t, encoded, encodeOrFail(t, decoded))
+}
+
+func TestFormat(t *testing.T) {
+	var _ crypt.Format = NewFormat()
+
+	decoder := crypt.NewDecoderStrict()
+
+	require.NoError(t, decoder.RegisterFormat(NewFormat()))
+	require.NoError(t, shacrypt.RegisterDecoder(decoder))
+	require.NoError(t, bcrypt.RegisterDecoder(decoder))
+	require.NoError(t, pbkdf2.RegisterDecoder(decoder))
+	require.NoError(t, RegisterDecoder(decoder))
+
+	testCases := []struct {
+		name     string
+		have     string
+		expected algorithm.Digest
+	}{
+		{"ShouldNormalizeSHA512Crypt", "{SHA512-CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90", &shacrypt.Digest{}},
+		{"ShouldNormalizeBLFCrypt", "{BLF-CRYPT}$2y$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e", &bcrypt.Digest{}},
+		{"ShouldNormalizePBKDF2", "{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000$4b2ce245e5c349959c42937aabfee113a633b237", &pbkdf2.Digest{}},
+		{"ShouldNotNormalizeSSHA512", "{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI", &Digest{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := decoder.Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.IsType(t, tc.expected, digest)
+			assert.True(t, digest.Match(password))
+
+			value, err := decoder.EncodeAs(digest, FormatName)
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, value)
+
+			value, err = Encode(digest)
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, value)
+		})
+	}
+
+	encodedDigest, ok := NewFormat().Normalize("{CRYPT}$6$abc")
+	assert.False(t, ok)
+	assert.Equal(t, "{CRYPT}$6$abc", encodedDigest)
+
+	encodedDigest, ok = NewFormat().Normalize("{PBKDF2}$1$salt$abc$def")
+	assert.False(t, ok)
+	assert.Equal(t, "{PBKDF2}$1$salt$abc$def", encodedDigest)
+
+	_, err := NewFormat().Encode(nil)
+	assert.EqualError(t, err, "can't encode a nil digest")
+}
+
+func TestDecodeCRAMMD5LongPassword(t *testing.T) {
+	digest, err := Decode("{CRAM-MD5}52197db948d0932512a61b183e7d05b04e94d60a3bb0d00f92d3df52a5d275ca")
+
+	require.NoError(t, err)
+	assert.True(t, digest.Match(strings.Repeat("x", 100)))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrNoPrefix", "$6$abc$abc", "dovecot decode error: provided encoded hash has an invalid format: the digest doesn't begin with a scheme prefix"},
+		{"ShouldErrUnknownScheme", "{CLEARTEXT}password", "dovecot decode error: provided encoded hash has an invalid identifier: scheme 'CLEARTEXT' is not a supported dovecot scheme"},
+		{"ShouldErrUnknownSuffix", "{SSHA.B32}abc", "dovecot decode error: provided encoded hash has an invalid identifier: encoding suffix 'B32' is unknown"},
+		{"ShouldErrSuffixOnCryptScheme", "{SHA512-CRYPT.HEX}abc", "dovecot decode error: provided encoded hash has an invalid identifier: scheme 'SHA512-CRYPT' does not support encoding suffixes"},
+		{"ShouldErrBadBase64", "{SSHA}!!!!", "dovecot decode error: provided encoded hash has a key value that can't be decoded: illegal base64 data at input byte 0"},
+		{"ShouldErrSaltedTooShort", "{SSHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=", "dovecot decode error: provided encoded hash has a key value that can't be decoded: key and salt have 20 bytes but must have more than 20 bytes"},
+		{"ShouldErrUnsaltedWrongLength", "{SHA}N+vXsN2ny8mTqd6ZYuHcJVHvE00BAgMEBQYHCA==", "dovecot decode error: provided encoded hash has a key value that can't be decoded: key has 28 bytes but must have 20 bytes"},
+		{"ShouldErrPBKDF2Prefix", "{PBKDF2}$2$FtWwyyV2mxaOtzB8$5000$4b2ce245e5c349959c42937aabfee113a633b237", "dovecot decode error: provided encoded hash has an invalid format: the PBKDF2 scheme digest doesn't begin with '$1$'"},
+		{"ShouldErrPBKDF2Parts", "{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000", "dovecot decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrPBKDF2Rounds", "{PBKDF2}$1$FtWwyyV2mxaOtzB8$abc$4b2ce245e5c349959c42937aabfee113a633b237", "dovecot decode error: provided encoded hash has an invalid option value: iterations could not be parsed: strconv.Atoi: parsing \"abc\": invalid syntax"},
+		{"ShouldErrPBKDF2KeyLength", "{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000$4b2ce245", "dovecot decode error: provided encoded hash has a key value that can't be decoded: key has 4 bytes but must have 20 bytes"},
+		{"ShouldErrCryptSchemeMismatch", "{SHA512-CRYPT}$5$saltsalt$gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.", "dovecot decode error: shacrypt decode error: the 'sha256' variant cannot be decoded only the 'sha512' variant can be"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	hashSHA512, err := shacrypt.NewSHA512()
+	require.NoError(t, err)
+
+	hashBcrypt, err := bcrypt.New()
+	require.NoError(t, err)
+
+	hashArgon2, err := argon2.New(argon2.WithProfileRFC9106LowMemory())
+	require.NoError(t, err)
+
+	hashArgon2D, err := argon2.New(argon2.WithVariant(argon2.VariantD))
+	require.NoError(t, err)
+
+	hashPBKDF2, err := pbkdf2.NewSHA256()
+	require.NoError(t, err)
+
+	hashScrypt, err := scrypt.New()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		have   algorithm.Digest
+		prefix string
+		err    string
+	}{
+		{"ShouldEncodeSHA512Crypt", hashSHA512.MustHash(password), "{SHA512-CRYPT}$6$", ""},
+		{"ShouldEncodeBLFCrypt", hashBcrypt.MustHash(password), "{BLF-CRYPT}$2b$", ""},
+		{"ShouldEncodeArgon2ID", hashArgon2.MustHash(password), "{ARGON2ID}$argon2id$v=19$", ""},
+		{"ShouldErrArgon2D", hashArgon2D.MustHash(password), "", "dovecot encode error: digest '$argon2d$"},
+		{"ShouldErrPBKDF2SHA256", hashPBKDF2.MustHash(password), "", "dovecot encode error: pbkdf2 digests must use the sha1 variant"},
+		{"ShouldErrScrypt", hashScrypt.MustHash(password), "", "dovecot encode error: digests of type '*scrypt.Digest' are not supported"},
+		{"ShouldErrNil", nil, "", "can't encode a nil digest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := Encode(tc.have)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(value, tc.prefix))
+
+				digest, err := Decode(value)
+
+				require.NoError(t, err)
+				assert.True(t, digest.Match(password))
+				assert.Equal(t, value, digest.Encode())
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+				assert.Equal(t, "", value)
+			}
+		})
+	}
+}
+
+func TestHasher(t *testing.T) {
+	testCases := []struct {
+		name   string
+		opts   []Opt
+		prefix string
+	}{
+		{"ShouldHashPBKDF2", nil, "{PBKDF2}$1$"},
+		{"ShouldHashSSHA512", []Opt{WithScheme(SchemeSSHA512)}, "{SSHA512}"},
+		{"ShouldHashSSHA256Hex", []Opt{WithSchemeName("ssha256"), WithEncoding(EncodingHex), WithSaltLength(4)}, "{SSHA256.hex}"},
+		{"ShouldHashSHA", []Opt{WithScheme(SchemeSHA)}, "{SHA}"},
+		{"ShouldHashCRAMMD5", []Opt{WithScheme(SchemeCRAMMD5)}, "{CRAM-MD5}"},
+		{"ShouldHashCRAMMD5Base64", []Opt{WithScheme(SchemeCRAMMD5), WithEncoding(EncodingBase64)}, "{CRAM-MD5.b64}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := hasher.Hash(password)
+			require.NoError(t, err)
+
+			value, err := Encode(digest)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(value, tc.prefix))
+
+			decoded, err := Decode(value)
+			require.NoError(t, err)
+
+			assert.True(t, decoded.Match(password))
+			assert.False(t, decoded.Match("wrong"))
+			assert.Equal(t, value, encodeOrFail(t, decoded))
+		})
+	}
+}
+
+func TestHasherHashWithSalt(t *testing.T) {
+	hasher, err := New(WithIterations(pbkdf2.IterationsMin))
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt(password, []byte("FtWwyyV2mxaOtzB8"))
+	require.NoError(t, err)
+
+	value, err := Encode(digest)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\{PBKDF2}\$1\$FtWwyyV2mxaOtzB8\$100000\$[0-9a-f]{40}$`, value)
+
+	_, err = hasher.HashWithSalt(password, []byte("FtWwyyV2$xaOtzB8"))
+	assert.EqualError(t, err, "dovecot hashing error: salt is invalid: the 'PBKDF2' scheme salt must only contain printable characters other than '$'")
+
+	hasher, err = New(WithScheme(SchemeSSHA512))
+	require.NoError(t, err)
+
+	digest, err = hasher.HashWithSalt(password, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	require.NoError(t, err)
+	assert.Equal(t, "{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI", digest.Encode())
+
+	_, err = hasher.HashWithSalt(password, nil)
+	assert.EqualError(t, err, "dovecot hashing error: salt is invalid: salt bytes must have a length of between 1 and 1024 but has a length of 0")
+
+	hasher, err = New(WithScheme(SchemeSHA))
+	require.NoError(t, err)
+
+	_, err = hasher.HashWithSalt(password, []byte{1})
+	assert.EqualError(t, err, "dovecot hashing error: salt is invalid: the 'SHA' scheme does not use a salt")
+}
+
+func TestHasherOptionErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Opt
+		err  string
+	}{
+		{"ShouldErrCryptScheme", []Opt{WithScheme(SchemeSHA512Crypt)}, "dovecot validation error: parameter is invalid: scheme 'SHA512-CRYPT' can't be used to generate digests"},
+		{"ShouldErrSchemeName", []Opt{WithSchemeName("bad")}, "dovecot validation error: parameter is invalid: scheme name 'bad' is invalid"},
+		{"ShouldErrEncoding", []Opt{WithEncoding(Encoding(9))}, "dovecot validation error: parameter is invalid: encoding '9' is invalid"},
+		{"ShouldErrIterations", []Opt{WithIterations(5000)}, "dovecot validation error: parameter is invalid: parameter 'iterations' must be between 100000 and 2147483647 but is set to '5000'"},
+		{"ShouldErrSaltLength", []Opt{WithSaltLength(0)}, "dovecot validation error: parameter is invalid: parameter 'salt length' must be between 1 and 1024 but is set to '0'"},
+		{"ShouldErrPBKDF2Encoding", []Opt{WithEncoding(EncodingHex)}, "dovecot validation error: parameter is invalid: the 'PBKDF2' scheme does not support encodings"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.opts...)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	require.NoError(t, RegisterDecoder(d))
+
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldDecodeSHA512Crypt", "{SHA512-CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90"},
+		{"ShouldDecodeSSHA512", "{SSHA512}cwNVhOuAAA1oPn+CL+wS1f0tPZracezN0yYU0xqYornXQBQ9qBELs44UJoU10/Tk7xHjUDA1r9zldrcPUqHBNAECAwQFBgcI"},
+		{"ShouldDecodePBKDF2", "{PBKDF2}$1$FtWwyyV2mxaOtzB8$5000$4b2ce245e5c349959c42937aabfee113a633b237"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := d.Decode(tc.have)
+
+			require.NoError(t, err)
+			assert.True(t, digest.Match(password))
+		})
+	}
+
+	_, err := d.Decode("{CRYPT}$6$rB2PL49BuajVczWm$sA.XUPEt/j6k4kFnO58EDKsEU8rXau47.eSH6lpqc/tgC9Y0BbYcG7H3.KmMMpthWMcip/xmDn83nTUXK5Vp90")
+	assert.EqualError(t, err, "provided encoded hash has an invalid identifier: the identifier '6' is unknown to the decoder")
+
+	assert.False(t, MatchEncoded("{CRYPT}$6$abc"))
+	assert.True(t, MatchEncoded("{sha512-crypt}$6$abc"))
+}
+
+func encodeOrFail(t *testing.T, digest algorithm.Digest) string {
+	value, err := Encode(digest)
+
+	require.NoError(t, err)
+
+	return value
+}
@@ -0,0 +1,80 @@
+package dovecot
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// Encode returns the algorithm.Digest encoded in the form Dovecot expects including the scheme prefix. The supported
+// digests are the dovecot.Digest, the standard variants of the md5crypt.Digest and bcrypt.Digest, the
+// shacrypt.Digest, the argon2i and argon2id variants of the argon2.Digest, and the HMAC-SHA-1 variant of the
+// pbkdf2.Digest. This is the same as the Encode method of the dovecot.Format.
+func Encode(digest algorithm.Digest) (value string, err error) {
+	return NewFormat().Encode(digest)
+}
+
+func encodePBKDF2(digest *pbkdf2.Digest) (value string, err error) {
+	parts := phc.Split(digest.Canonical().Encode(), -1)
+
+	if len(parts) != 5 || parts[1] != pbkdf2.AlgIdentifier {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must use the %s variant", AlgName, pbkdf2.VariantSHA1)
+	}
+
+	salt, key := digest.Salt(), digest.Key()
+
+	if len(key) != PBKDF2KeyLength {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must have a key length of %d bytes but has %d bytes", AlgName, PBKDF2KeyLength, len(key))
+	}
+
+	if !validSaltPBKDF2(salt) {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digests must have a salt of printable characters other than '%c'", AlgName, encoding.Delimiter)
+	}
+
+	var iterations int
+
+	if iterations, err = strconv.Atoi(parts[2]); err != nil {
+		return "", fmt.Errorf("%s encode error: %w", AlgName, err)
+	}
+
+	return fmt.Sprintf(PBKDF2EncodingFmt, SchemePBKDF2, salt, iterations, key), nil
+}
+
+// validSaltPBKDF2 returns true if the salt can be used with the PBKDF2 scheme which requires the salt is printable and
+// does not contain the delimiter.
+func validSaltPBKDF2(salt []byte) bool {
+	for _, c := range salt {
+		if c <= ' ' || c > '~' || rune(c) == encoding.Delimiter {
+			return false
+		}
+	}
+
+	return true
+}
+
+func schemeCrypt(identifier string) (scheme Scheme) {
+	switch identifier {
+	case md5crypt.AlgIdentifier:
+		return SchemeMD5Crypt
+	case shacrypt.AlgIdentifierSHA256:
+		return SchemeSHA256Crypt
+	case shacrypt.AlgIdentifierSHA512:
+		return SchemeSHA512Crypt
+	case bcrypt.AlgIdentifier, bcrypt.AlgIdentifierVerA, bcrypt.AlgIdentifierVerX, bcrypt.AlgIdentifierVerY:
+		return SchemeBLFCrypt
+	case argon2.AlgIdentifierVariantI:
+		return SchemeArgon2I
+	case argon2.AlgIdentifierVariantID:
+		return SchemeArgon2ID
+	default:
+		return SchemeNone
+	}
+}
@@ -0,0 +1,92 @@
+package dovecot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// NewFormat returns a *dovecot.Format which can be registered with a crypt.Decoder via its RegisterFormat method.
+func NewFormat() *Format {
+	return &Format{}
+}
+
+// Format is the crypt.Format for the Dovecot password schemes. The input side converts the crypt based schemes and the
+// PBKDF2 scheme into the encoded form of the relevant algorithm.Digest, and the output side is the same as the
+// dovecot.Encode function.
+type Format struct{}
+
+// Name returns the name of this dovecot.Format which is used with the EncodeAs method of a crypt.Decoder.
+func (f *Format) Name() (name string) {
+	return FormatName
+}
+
+// Normalize converts a value with the prefix of a known dovecot.Scheme into an encoded digest. The crypt based schemes
+// have the scheme prefix removed and the PBKDF2 scheme is converted into the encoded form used by the pbkdf2.Digest.
+// All other schemes are returned as is and are only decoded if the dovecot decoder is also registered.
+func (f *Format) Normalize(value string) (encodedDigest string, ok bool) {
+	scheme, _, _, data, err := decoderParts(value)
+
+	switch {
+	case err != nil:
+		return value, false
+	case scheme.binary():
+		return value, true
+	case scheme == SchemePBKDF2:
+		if encodedDigest, err = decodePBKDF2(data); err != nil {
+			return value, false
+		}
+
+		return encodedDigest, true
+	default:
+		return data, true
+	}
+}
+
+// Encode returns the algorithm.Digest encoded in the form Dovecot expects including the scheme prefix. See
+// dovecot.Encode for the supported digests.
+func (f *Format) Encode(digest algorithm.Digest) (value string, err error) {
+	if digest == nil {
+		return "", fmt.Errorf("can't encode a nil digest")
+	}
+
+	var scheme Scheme
+
+	switch d := digest.(type) {
+	case *Digest:
+		return d.Encode(), nil
+	case *pbkdf2.Digest:
+		return encodePBKDF2(d)
+	case *argon2.Digest:
+		if d.Version() != argon2.Version13 || len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return "", fmt.Errorf("%s encode error: argon2 digests must be version 1.3 without a secret or associated data", AlgName)
+		}
+	case *md5crypt.Digest, *shacrypt.Digest, *bcrypt.Digest:
+		break
+	default:
+		return "", fmt.Errorf("%s encode error: digests of type '%T' are not supported", AlgName, digest)
+	}
+
+	encodedDigest := digest.Encode()
+
+	if p, ok := digest.(algorithm.StoragePrefixDigest); ok {
+		encodedDigest = strings.TrimPrefix(encodedDigest, p.StoragePrefix())
+	}
+
+	if parts := phc.Split(encodedDigest, 3); len(parts) == 3 {
+		scheme = schemeCrypt(parts[1])
+	}
+
+	if scheme == SchemeNone {
+		return "", fmt.Errorf("%s encode error: digest '%s' does not have a supported identifier", AlgName, encodedDigest)
+	}
+
+	return scheme.Prefix(EncodingNone) + encodedDigest, nil
+}
@@ -0,0 +1,168 @@
+package dovecot
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/internal/random"
+)
+
+// New returns a *dovecot.Hasher with the additional opts applied if any.
+func New(opts ...Opt) (hasher *Hasher, err error) {
+	hasher = &Hasher{}
+
+	if err = hasher.WithOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	if err = hasher.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// Hasher is a crypt.Hash for the Dovecot schemes which can be initialized via dovecot.New using a functional options
+// pattern. The dovecot.SchemePBKDF2 scheme results in a pbkdf2.Digest which must be encoded with dovecot.Encode, all
+// other schemes result in a dovecot.Digest.
+type Hasher struct {
+	scheme   Scheme
+	encoding Encoding
+
+	iterations, bytesSalt int
+
+	d bool
+}
+
+// WithOptions applies the provided functional options provided as a dovecot.Opt to the dovecot.Hasher.
+func (h *Hasher) WithOptions(opts ...Opt) (err error) {
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash performs the hashing operation and returns either a algorithm.Digest or an error.
+func (h *Hasher) Hash(password string) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hash(password); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hash(password string) (digest algorithm.Digest, err error) {
+	var salt []byte
+
+	switch {
+	case h.scheme == SchemePBKDF2:
+		if salt, err = random.CharSetBytes(PBKDF2SaltLength, PBKDF2SaltCharSet); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrSaltReadRandomBytes, err)
+		}
+	case h.scheme.salted():
+		if salt, err = random.Bytes(h.bytesSalt); err != nil {
+			return nil, fmt.Errorf("%w: %v", algorithm.ErrSaltReadRandomBytes, err)
+		}
+	}
+
+	return h.hashWithSalt(password, salt)
+}
+
+// HashWithSalt overloads the Hash method allowing the user to provide a salt. It's recommended instead to configure the
+// salt size and let this be a random value generated using crypto/rand.
+func (h *Hasher) HashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	h.defaults()
+
+	if digest, err = h.hashWithSalt(password, salt); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtHasherHash, AlgName, err)
+	}
+
+	return digest, nil
+}
+
+func (h *Hasher) hashWithSalt(password string, salt []byte) (digest algorithm.Digest, err error) {
+	switch {
+	case h.scheme == SchemePBKDF2:
+		return h.hashPBKDF2(password, salt)
+	case h.scheme.salted():
+		if s := len(salt); s > SaltLengthMax || s < SaltLengthMin {
+			return nil, fmt.Errorf("%w: salt bytes must have a length of between %d and %d but has a length of %d", algorithm.ErrSaltInvalid, SaltLengthMin, SaltLengthMax, len(salt))
+		}
+	case len(salt) != 0:
+		return nil, fmt.Errorf("%w: the '%s' scheme does not use a salt", algorithm.ErrSaltInvalid, h.scheme)
+	}
+
+	d := &Digest{
+		scheme:   h.scheme,
+		encoding: h.encoding,
+		salt:     salt,
+	}
+
+	d.key = d.derive([]byte(password))
+
+	return d, nil
+}
+
+func (h *Hasher) hashPBKDF2(password string, salt []byte) (digest algorithm.Digest, err error) {
+	if !validSaltPBKDF2(salt) {
+		return nil, fmt.Errorf("%w: the '%s' scheme salt must only contain printable characters other than '%c'", algorithm.ErrSaltInvalid, h.scheme, encoding.Delimiter)
+	}
+
+	var hasher *pbkdf2.Hasher
+
+	if hasher, err = pbkdf2.NewSHA1(pbkdf2.WithIterations(h.iterations)); err != nil {
+		return nil, err
+	}
+
+	return hasher.HashWithSalt(password, salt)
+}
+
+// MustHash overloads the Hash method and panics if the error is not nil. It's recommended if you use this option to
+// utilize the Validate method first or handle the panic appropriately.
+func (h *Hasher) MustHash(password string) (digest algorithm.Digest) {
+	var err error
+
+	if digest, err = h.Hash(password); err != nil {
+		panic(err)
+	}
+
+	return digest
+}
+
+// Validate checks the settings/parameters for this Hash and returns an error.
+func (h *Hasher) Validate() (err error) {
+	h.defaults()
+
+	if h.scheme == SchemePBKDF2 && h.encoding != EncodingNone {
+		return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: the '%s' scheme does not support encodings", algorithm.ErrParameterInvalid, h.scheme))
+	}
+
+	return nil
+}
+
+func (h *Hasher) defaults() {
+	if h.d {
+		return
+	}
+
+	h.d = true
+
+	if h.scheme == SchemeNone {
+		h.scheme = SchemePBKDF2
+	}
+
+	if h.iterations == 0 {
+		h.iterations = pbkdf2.IterationsDefaultSHA1
+	}
+
+	if h.bytesSalt < SaltLengthMin {
+		h.bytesSalt = algorithm.SaltLengthDefault
+	}
+}
@@ -0,0 +1,82 @@
+package dovecot
+
+import (
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+// Opt describes the functional option pattern for the dovecot.Hasher.
+type Opt func(h *Hasher) (err error)
+
+// WithScheme sets the dovecot.Scheme of the resulting algorithm.Digest. Only the dovecot.SchemePBKDF2 scheme and the
+// schemes which result in a dovecot.Digest can be used, digests for the crypt based schemes should be generated with
+// the relevant algorithm package and encoded with dovecot.Encode. Default is dovecot.SchemePBKDF2.
+func WithScheme(scheme Scheme) Opt {
+	return func(h *Hasher) (err error) {
+		if scheme != SchemePBKDF2 && !scheme.binary() {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: scheme '%s' can't be used to generate digests", algorithm.ErrParameterInvalid, scheme))
+		}
+
+		h.scheme = scheme
+
+		return nil
+	}
+}
+
+// WithSchemeName uses the dovecot.Scheme with the provided name. See dovecot.WithScheme.
+func WithSchemeName(name string) Opt {
+	return func(h *Hasher) (err error) {
+		scheme := NewScheme(name)
+
+		if scheme == SchemeNone {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: scheme name '%s' is invalid", algorithm.ErrParameterInvalid, name))
+		}
+
+		return WithScheme(scheme)(h)
+	}
+}
+
+// WithEncoding sets the dovecot.Encoding of the resulting dovecot.Digest. Default is the default encoding of the
+// scheme which is base64 for all schemes other than dovecot.SchemeCRAMMD5.
+func WithEncoding(encoding Encoding) Opt {
+	return func(h *Hasher) (err error) {
+		switch encoding {
+		case EncodingNone, EncodingBase64, EncodingHex:
+			h.encoding = encoding
+
+			return nil
+		default:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: encoding '%d' is invalid", algorithm.ErrParameterInvalid, encoding))
+		}
+	}
+}
+
+// WithIterations sets the iterations parameter of the resulting pbkdf2.Digest when using dovecot.SchemePBKDF2.
+// Minimum is 100000, Maximum is 2147483647. Default is 720000.
+func WithIterations(iterations int) Opt {
+	return func(h *Hasher) (err error) {
+		if iterations < pbkdf2.IterationsMin || iterations > pbkdf2.IterationsMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "iterations", pbkdf2.IterationsMin, "", pbkdf2.IterationsMax, iterations))
+		}
+
+		h.iterations = iterations
+
+		return nil
+	}
+}
+
+// WithSaltLength adjusts the salt size (in bytes) of the resulting dovecot.Digest when using a salted scheme.
+// Minimum is 1, Maximum is 1024. Default is 16.
+func WithSaltLength(bytes int) Opt {
+	return func(h *Hasher) (err error) {
+		if bytes < SaltLengthMin || bytes > SaltLengthMax {
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf(algorithm.ErrFmtInvalidIntParameter, algorithm.ErrParameterInvalid, "salt length", SaltLengthMin, "", SaltLengthMax, bytes))
+		}
+
+		h.bytesSalt = bytes
+
+		return nil
+	}
+}
@@ -0,0 +1,259 @@
+package dovecot
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// NewScheme converts a Dovecot scheme name to a dovecot.Scheme. The name is not case-sensitive.
+func NewScheme(name string) (scheme Scheme) {
+	switch strings.ToUpper(name) {
+	case SchemeNameMD5Crypt:
+		return SchemeMD5Crypt
+	case SchemeNameSHA256Crypt:
+		return SchemeSHA256Crypt
+	case SchemeNameSHA512Crypt:
+		return SchemeSHA512Crypt
+	case SchemeNameBLFCrypt:
+		return SchemeBLFCrypt
+	case SchemeNameArgon2I:
+		return SchemeArgon2I
+	case SchemeNameArgon2ID:
+		return SchemeArgon2ID
+	case SchemeNamePBKDF2:
+		return SchemePBKDF2
+	case SchemeNameSHA:
+		return SchemeSHA
+	case SchemeNameSSHA:
+		return SchemeSSHA
+	case SchemeNameSHA256:
+		return SchemeSHA256
+	case SchemeNameSSHA256:
+		return SchemeSSHA256
+	case SchemeNameSHA512:
+		return SchemeSHA512
+	case SchemeNameSSHA512:
+		return SchemeSSHA512
+	case SchemeNameCRAMMD5:
+		return SchemeCRAMMD5
+	default:
+		return SchemeNone
+	}
+}
+
+// Scheme is a Dovecot password scheme.
+type Scheme int
+
+const (
+	// SchemeNone is a Dovecot scheme which is unknown.
+	SchemeNone Scheme = iota
+
+	// SchemeMD5Crypt is the Dovecot MD5-CRYPT scheme which is a md5crypt.Digest.
+	SchemeMD5Crypt
+
+	// SchemeSHA256Crypt is the Dovecot SHA256-CRYPT scheme which is a SHA-256 shacrypt.Digest.
+	SchemeSHA256Crypt
+
+	// SchemeSHA512Crypt is the Dovecot SHA512-CRYPT scheme which is a SHA-512 shacrypt.Digest.
+	SchemeSHA512Crypt
+
+	// SchemeBLFCrypt is the Dovecot BLF-CRYPT scheme which is a bcrypt.Digest.
+	SchemeBLFCrypt
+
+	// SchemeArgon2I is the Dovecot ARGON2I scheme which is an argon2i argon2.Digest.
+	SchemeArgon2I
+
+	// SchemeArgon2ID is the Dovecot ARGON2ID scheme which is an argon2id argon2.Digest.
+	SchemeArgon2ID
+
+	// SchemePBKDF2 is the Dovecot PBKDF2 scheme which is a HMAC-SHA-1 pbkdf2.Digest with a Dovecot specific layout.
+	SchemePBKDF2
+
+	// SchemeSHA is the Dovecot SHA scheme which is an unsalted SHA-1 dovecot.Digest.
+	SchemeSHA
+
+	// SchemeSSHA is the Dovecot SSHA scheme which is a salted SHA-1 dovecot.Digest.
+	SchemeSSHA
+
+	// SchemeSHA256 is the Dovecot SHA256 scheme which is an unsalted SHA-256 dovecot.Digest.
+	SchemeSHA256
+
+	// SchemeSSHA256 is the Dovecot SSHA256 scheme which is a salted SHA-256 dovecot.Digest.
+	SchemeSSHA256
+
+	// SchemeSHA512 is the Dovecot SHA512 scheme which is an unsalted SHA-512 dovecot.Digest.
+	SchemeSHA512
+
+	// SchemeSSHA512 is the Dovecot SSHA512 scheme which is a salted SHA-512 dovecot.Digest.
+	SchemeSSHA512
+
+	// SchemeCRAMMD5 is the Dovecot CRAM-MD5 scheme which is a dovecot.Digest of the HMAC-MD5 context.
+	SchemeCRAMMD5
+)
+
+// String implements the fmt.Stringer returning the Dovecot name of the dovecot.Scheme.
+func (s Scheme) String() (name string) {
+	switch s {
+	case SchemeMD5Crypt:
+		return SchemeNameMD5Crypt
+	case SchemeSHA256Crypt:
+		return SchemeNameSHA256Crypt
+	case SchemeSHA512Crypt:
+		return SchemeNameSHA512Crypt
+	case SchemeBLFCrypt:
+		return SchemeNameBLFCrypt
+	case SchemeArgon2I:
+		return SchemeNameArgon2I
+	case SchemeArgon2ID:
+		return SchemeNameArgon2ID
+	case SchemePBKDF2:
+		return SchemeNamePBKDF2
+	case SchemeSHA:
+		return SchemeNameSHA
+	case SchemeSSHA:
+		return SchemeNameSSHA
+	case SchemeSHA256:
+		return SchemeNameSHA256
+	case SchemeSSHA256:
+		return SchemeNameSSHA256
+	case SchemeSHA512:
+		return SchemeNameSHA512
+	case SchemeSSHA512:
+		return SchemeNameSSHA512
+	case SchemeCRAMMD5:
+		return SchemeNameCRAMMD5
+	default:
+		return
+	}
+}
+
+// Prefix returns the dovecot.Scheme prefix such as {SSHA512} using the provided dovecot.Encoding. The encoding suffix
+// is omitted if the dovecot.Encoding is the default for the dovecot.Scheme.
+func (s Scheme) Prefix(encoding Encoding) (prefix string) {
+	if encoding == EncodingNone || encoding == s.encoding() {
+		return "{" + s.String() + "}"
+	}
+
+	return "{" + s.String() + "." + encoding.Suffix() + "}"
+}
+
+// binary returns true if the dovecot.Scheme is decoded into a dovecot.Digest.
+func (s Scheme) binary() bool {
+	return s >= SchemeSHA && s <= SchemeCRAMMD5
+}
+
+// salted returns true if the dovecot.Scheme is a binary scheme which includes a salt.
+func (s Scheme) salted() bool {
+	switch s {
+	case SchemeSSHA, SchemeSSHA256, SchemeSSHA512:
+		return true
+	default:
+		return false
+	}
+}
+
+// encoding returns the default dovecot.Encoding of a binary dovecot.Scheme.
+func (s Scheme) encoding() (encoding Encoding) {
+	switch {
+	case s == SchemeCRAMMD5:
+		return EncodingHex
+	case s.binary():
+		return EncodingBase64
+	default:
+		return EncodingNone
+	}
+}
+
+// hashFunc returns the algorithm.HashFunc of a binary dovecot.Scheme.
+func (s Scheme) hashFunc() algorithm.HashFunc {
+	switch s {
+	case SchemeSHA, SchemeSSHA:
+		return sha1.New
+	case SchemeSHA256, SchemeSSHA256:
+		return sha256.New
+	case SchemeSHA512, SchemeSSHA512:
+		return sha512.New
+	case SchemeCRAMMD5:
+		return md5.New
+	default:
+		return nil
+	}
+}
+
+// size returns the size of the key of a binary dovecot.Scheme.
+func (s Scheme) size() int {
+	if s == SchemeCRAMMD5 {
+		return md5.Size * 2
+	}
+
+	if h := s.hashFunc(); h != nil {
+		return h().Size()
+	}
+
+	return 0
+}
+
+// NewEncoding converts a Dovecot scheme encoding suffix to a dovecot.Encoding. The suffix is not case-sensitive.
+func NewEncoding(suffix string) (encoding Encoding) {
+	switch strings.ToLower(suffix) {
+	case EncodingSuffixBase64, EncodingSuffixBase64Long:
+		return EncodingBase64
+	case EncodingSuffixHex:
+		return EncodingHex
+	default:
+		return EncodingNone
+	}
+}
+
+// Encoding is the encoding of the binary Dovecot schemes.
+type Encoding int
+
+const (
+	// EncodingNone is the default encoding of a dovecot.Scheme.
+	EncodingNone Encoding = iota
+
+	// EncodingBase64 is the standard base64 encoding.
+	EncodingBase64
+
+	// EncodingHex is the hex encoding.
+	EncodingHex
+)
+
+// Suffix returns the scheme suffix of the dovecot.Encoding.
+func (e Encoding) Suffix() (suffix string) {
+	switch e {
+	case EncodingBase64:
+		return EncodingSuffixBase64
+	case EncodingHex:
+		return EncodingSuffixHex
+	default:
+		return
+	}
+}
+
+// Encode the raw bytes with the dovecot.Encoding.
+func (e Encoding) Encode(raw []byte) (encoded string) {
+	switch e {
+	case EncodingHex:
+		return hex.EncodeToString(raw)
+	default:
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+}
+
+// Decode the encoded string with the dovecot.Encoding.
+func (e Encoding) Decode(encoded string) (raw []byte, err error) {
+	switch e {
+	case EncodingHex:
+		return hex.DecodeString(encoded)
+	default:
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+}
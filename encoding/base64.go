@@ -0,0 +1,16 @@
+package encoding
+
+import (
+	"encoding/base64"
+)
+
+var (
+	// Base64RawAdaptedEncoding is the adapted base64 encoding without padding.
+	Base64RawAdaptedEncoding = base64.NewEncoding(AlphabetBase64Adapted).WithPadding(base64.NoPadding)
+
+	// BcryptEncoding is the bcrypt base64 encoding without padding as used for the salt and key of a bcrypt digest.
+	BcryptEncoding = base64.NewEncoding(AlphabetBcrypt).WithPadding(base64.NoPadding)
+
+	// Hash64Encoding is the crypt(3) hash64 encoding.
+	Hash64Encoding = NewHash64Encoding(AlphabetHash64)
+)
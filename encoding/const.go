@@ -0,0 +1,13 @@
+package encoding
+
+const (
+	// AlphabetHash64 is the alphabet of the crypt(3) hash64 encoding.
+	AlphabetHash64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// AlphabetBcrypt is the alphabet of the bcrypt base64 encoding.
+	AlphabetBcrypt = "./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+	// AlphabetBase64Adapted is the alphabet of the adapted base64 encoding which is the standard base64 alphabet with
+	// the '+' character replaced by the '.' character.
+	AlphabetBase64Adapted = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./"
+)
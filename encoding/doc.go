@@ -0,0 +1,10 @@
+// Package encoding provides the alphabets and encodings used by the crypt(3) style password storage formats so third
+// party algorithms can produce encoded digests which are compatible with the algorithms in this module. This includes
+// the crypt(3) little-endian base64 encoding commonly known as hash64 which is used by md5crypt, sha1crypt, shacrypt,
+// and yescrypt, the transposition tables md5crypt, sha1crypt, and shacrypt apply to the raw checksum before encoding
+// it, the bcrypt base64 encoding, and the adapted base64 encoding used by formats such as pbkdf2.
+//
+// Each of the encodings is lossless in that decoding an encoded value always returns the original bytes. The base64
+// encodings are *base64.Encoding values and are as lenient as the standard library when decoding, whereas the hash64
+// encoding rejects any non-canonical input so encoding a decoded value always returns the original encoded value.
+package encoding
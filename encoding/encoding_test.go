@@ -0,0 +1,192 @@
+package encoding
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/go-crypt/x/bcrypt"
+	xcrypt "github.com/go-crypt/x/crypt"
+	"github.com/go-crypt/x/yescrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash64(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []byte
+		expected string
+	}{
+		{"ShouldEncodeEmpty", []byte{}, ""},
+		{"ShouldEncodeOneByte", []byte{0x01}, "/."},
+		{"ShouldEncodeTwoBytes", []byte{0xff, 0xff}, "zzD"},
+		{"ShouldEncodeThreeBytes", []byte{0x00, 0x00, 0x00}, "...."},
+		{"ShouldEncodeString", []byte("abc"), "V7qM"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Hash64Encoding.EncodeToString(tc.have))
+			assert.Equal(t, len(tc.expected), Hash64Encoding.EncodedLen(len(tc.have)))
+
+			decoded, err := Hash64Encoding.DecodeString(tc.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tc.have, decoded)
+		})
+	}
+}
+
+func TestHash64Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		err  string
+	}{
+		{"ShouldErrLength", "abcde", "illegal base64 data at input byte 4"},
+		{"ShouldErrCharacter", "ab!d", "illegal base64 data at input byte 2"},
+		{"ShouldErrNonCanonical", "zzz", "illegal base64 data at input byte 2"},
+		{"ShouldErrNonCanonicalOneByte", "/z", "illegal base64 data at input byte 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := Hash64Encoding.DecodeString(tc.have)
+
+			assert.Nil(t, decoded)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEncodingsRoundTrip(t *testing.T) {
+	for n := 0; n <= 96; n++ {
+		src := make([]byte, n)
+
+		_, err := rand.Read(src)
+		require.NoError(t, err)
+
+		encoded := Hash64Encoding.Encode(src)
+		assert.Equal(t, yescrypt.Encode64(src), encoded)
+
+		decoded, err := Hash64Encoding.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, src, decoded)
+
+		encodedBcrypt := BcryptEncoding.EncodeToString(src)
+
+		if n != 0 {
+			assert.Equal(t, string(bcrypt.Base64Encode(src)), encodedBcrypt)
+		}
+
+		decoded, err = BcryptEncoding.DecodeString(encodedBcrypt)
+		require.NoError(t, err)
+		assert.Equal(t, src, decoded)
+
+		decoded, err = Base64RawAdaptedEncoding.DecodeString(Base64RawAdaptedEncoding.EncodeToString(src))
+		require.NoError(t, err)
+		assert.Equal(t, src, decoded)
+	}
+}
+
+func TestTransposition(t *testing.T) {
+	password, salt := []byte("password"), []byte("saltsalt")
+
+	// The encoded values are the checksums of the libxcrypt digests of the password 'password' with the salt 'saltsalt'
+	// and the raw values are the checksums computed independently prior to the transposition.
+	testCases := []struct {
+		name    string
+		table   Transposition
+		size    int
+		digest  string
+		encoded string
+		raw     string
+		key     []byte
+	}{
+		{
+			"ShouldHandleMD5Crypt",
+			TranspositionMD5Crypt,
+			16,
+			"$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+			"qjXMvbEw8oaL.CzflDtaK/",
+			"62f15eaf9bf13b096df39356f6fb0a80",
+			xcrypt.KeyMD5Crypt(password, salt),
+		},
+		{
+			"ShouldHandleMD5CryptSun",
+			TranspositionMD5Crypt,
+			16,
+			"$md5,rounds=5000$saltsalt$$3wU.toD3zh78OWZ3FX8Wg0",
+			"3wU.toD3zh78OWZ3FX8Wg0",
+			"",
+			xcrypt.KeyMD5CryptSun(password, salt, 5000),
+		},
+		{
+			"ShouldHandleSHA1Crypt",
+			TranspositionSHA1Crypt,
+			20,
+			"$sha1$480000$saltsalt$/s8xibh8dbU8/drzItjQyekyo12C",
+			"/s8xibh8dbU8/drzItjQyekyo12C",
+			"f4ae012ad9ee2a09e9ff7a4172fe54fb0abe3840",
+			xcrypt.KeySHA1Crypt(password, salt, 480000),
+		},
+		{
+			"ShouldHandleSHA256Crypt",
+			TranspositionSHA256Crypt,
+			sha256.Size,
+			"$5$saltsalt$gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.",
+			"gOjOtoMpVhru2uyjeJSEc/JaLQWOXMNmlOnj6T4AtC.",
+			"6a8d61bfe5686a96b130f639ebee6a992723bf67acd57b84415017c936c8b903",
+			xcrypt.KeySHACrypt(sha256.New, password, salt, 5000),
+		},
+		{
+			"ShouldHandleSHA512Crypt",
+			TranspositionSHA512Crypt,
+			sha512.Size,
+			"$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+			"qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+			"47139c9002e61ba3f3b90c6eabf73fa3802613bd552472b2dfec89f1a9e861bc68992b3c60f7b656c0f976708fbfbea22947c7cd11e90b557b364a7b7a27cc6d",
+			xcrypt.KeySHACrypt(sha512.New, password, salt, 5000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.size, tc.table.Size())
+			assert.True(t, strings.HasSuffix(tc.digest, "$"+tc.encoded))
+			assert.Equal(t, tc.encoded, string(tc.key))
+
+			checksum, err := tc.table.Decode([]byte(tc.encoded))
+			require.NoError(t, err)
+			assert.Len(t, checksum, tc.size)
+
+			if tc.raw != "" {
+				assert.Equal(t, tc.raw, hex.EncodeToString(checksum))
+			}
+
+			encoded, err := tc.table.Encode(checksum)
+			require.NoError(t, err)
+			assert.Equal(t, tc.encoded, string(encoded))
+		})
+	}
+}
+
+func TestTranspositionErrors(t *testing.T) {
+	_, err := TranspositionMD5Crypt.Encode(make([]byte, 15))
+	assert.EqualError(t, err, "checksum is expected to be 16 bytes but it has 15 bytes")
+
+	_, err = TranspositionMD5Crypt.Decode([]byte("!"))
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+
+	_, err = TranspositionMD5Crypt.Decode(Hash64Encoding.Encode(make([]byte, 15)))
+	assert.EqualError(t, err, "checksum is expected to be 16 encoded bytes but it has 15 encoded bytes")
+
+	transposed := make([]byte, 21)
+	transposed[2], transposed[18] = 0x01, 0x02
+
+	_, err = TranspositionSHA1Crypt.Decode(Hash64Encoding.Encode(transposed))
+	assert.EqualError(t, err, "checksum byte 0 is repeated at position 18 with a different value")
+}
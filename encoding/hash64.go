@@ -0,0 +1,124 @@
+package encoding
+
+import (
+	"encoding/base64"
+)
+
+// Hash64 is the crypt(3) little-endian base64 encoding commonly known as hash64. Unlike the standard base64 encoding
+// each group of 3 bytes is read as a little-endian 24-bit integer and each 6-bit group is encoded starting with the
+// least significant bits. The encoding has no padding.
+type Hash64 struct {
+	encode    [64]byte
+	decodeMap [256]byte
+}
+
+// NewHash64Encoding returns a new *Hash64 defined by the given alphabet, which must be a 64-byte string that does not
+// contain any duplicate characters.
+func NewHash64Encoding(alphabet string) *Hash64 {
+	if len(alphabet) != 64 {
+		panic("encoding alphabet is not 64-bytes long")
+	}
+
+	e := &Hash64{}
+
+	copy(e.encode[:], alphabet)
+
+	for i := range e.decodeMap {
+		e.decodeMap[i] = 0xFF
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		if e.decodeMap[alphabet[i]] != 0xFF {
+			panic("encoding alphabet contains duplicate characters")
+		}
+
+		e.decodeMap[alphabet[i]] = byte(i)
+	}
+
+	return e
+}
+
+// EncodedLen returns the length in bytes of the hash64 encoding of an input buffer of length n.
+func (e *Hash64) EncodedLen(n int) int {
+	return (n*8 + 5) / 6
+}
+
+// DecodedLen returns the length in bytes of the decoded data corresponding to n bytes of hash64 encoded data.
+func (e *Hash64) DecodedLen(n int) int {
+	return n * 6 / 8
+}
+
+// Encode encodes src using the encoding returning the encoded bytes.
+func (e *Hash64) Encode(src []byte) (dst []byte) {
+	dst = make([]byte, 0, e.EncodedLen(len(src)))
+
+	for i := 0; i < len(src); i += 3 {
+		var (
+			value uint32
+			bits  int
+		)
+
+		for j := i; j < i+3 && j < len(src); j++ {
+			value |= uint32(src[j]) << bits
+			bits += 8
+		}
+
+		for ; bits > 0; bits -= 6 {
+			dst = append(dst, e.encode[value&0x3f])
+			value >>= 6
+		}
+	}
+
+	return dst
+}
+
+// EncodeToString returns the encoding of src as a string.
+func (e *Hash64) EncodeToString(src []byte) string {
+	return string(e.Encode(src))
+}
+
+// Decode decodes src using the encoding returning the decoded bytes. If src contains invalid or non-canonical hash64
+// data it returns a base64.CorruptInputError with the offset of the offending byte.
+func (e *Hash64) Decode(src []byte) (dst []byte, err error) {
+	if len(src)%4 == 1 {
+		return nil, base64.CorruptInputError(len(src) - 1)
+	}
+
+	dst = make([]byte, 0, e.DecodedLen(len(src)))
+
+	for i := 0; i < len(src); i += 4 {
+		var (
+			value uint32
+			bits  int
+			j     int
+		)
+
+		for j = i; j < i+4 && j < len(src); j++ {
+			c := e.decodeMap[src[j]]
+
+			if c == 0xFF {
+				return nil, base64.CorruptInputError(j)
+			}
+
+			value |= uint32(c) << bits
+			bits += 6
+		}
+
+		for ; bits >= 8; bits -= 8 {
+			dst = append(dst, byte(value))
+			value >>= 8
+		}
+
+		// The 2 or 4 bits remaining after the final partial group must be zero for the encoding to be canonical.
+		if value != 0 {
+			return nil, base64.CorruptInputError(j - 1)
+		}
+	}
+
+	return dst, nil
+}
+
+// DecodeString returns the bytes represented by the hash64 string s.
+func (e *Hash64) DecodeString(s string) ([]byte, error) {
+	return e.Decode([]byte(s))
+}
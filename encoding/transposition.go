@@ -0,0 +1,129 @@
+package encoding
+
+import (
+	"fmt"
+)
+
+// Transposition is a transposition table which describes the order the bytes of a checksum are encoded in by the
+// crypt(3) style algorithms such as md5crypt, sha1crypt, and shacrypt. The value at each index is the index of the
+// byte of the checksum which is encoded at that position, and the same byte may be referenced more than once.
+type Transposition []byte
+
+var (
+	// TranspositionMD5Crypt is the transposition table used by md5crypt including the Sun variant.
+	TranspositionMD5Crypt = Transposition{
+		12, 6, 0,
+		13, 7, 1,
+		14, 8, 2,
+		15, 9, 3,
+		5, 10, 4,
+		11,
+	}
+
+	// TranspositionSHA1Crypt is the transposition table used by sha1crypt. The first byte of the checksum is repeated
+	// to pad the 20 byte checksum to 21 bytes.
+	TranspositionSHA1Crypt = Transposition{
+		2, 1, 0,
+		5, 4, 3,
+		8, 7, 6,
+		11, 10, 9,
+		14, 13, 12,
+		17, 16, 15,
+		0, 19, 18,
+	}
+
+	// TranspositionSHA256Crypt is the transposition table used by the SHA256 variant of shacrypt.
+	TranspositionSHA256Crypt = Transposition{
+		20, 10, 0,
+		11, 1, 21,
+		2, 22, 12,
+		23, 13, 3,
+		14, 4, 24,
+		5, 25, 15,
+		26, 16, 6,
+		17, 7, 27,
+		8, 28, 18,
+		29, 19, 9,
+		30, 31,
+	}
+
+	// TranspositionSHA512Crypt is the transposition table used by the SHA512 variant of shacrypt.
+	TranspositionSHA512Crypt = Transposition{
+		42, 21, 0,
+		1, 43, 22,
+		23, 2, 44,
+		45, 24, 3,
+		4, 46, 25,
+		26, 5, 47,
+		48, 27, 6,
+		7, 49, 28,
+		29, 8, 50,
+		51, 30, 9,
+		10, 52, 31,
+		32, 11, 53,
+		54, 33, 12,
+		13, 55, 34,
+		35, 14, 56,
+		57, 36, 15,
+		16, 58, 37,
+		38, 17, 59,
+		60, 39, 18,
+		19, 61, 40,
+		41, 20, 62,
+		63,
+	}
+)
+
+// Size returns the size of the checksum the transposition table applies to.
+func (t Transposition) Size() (size int) {
+	for _, i := range t {
+		if int(i) >= size {
+			size = int(i) + 1
+		}
+	}
+
+	return size
+}
+
+// Encode transposes the checksum and encodes it with the Hash64Encoding as the crypt(3) style algorithms do. The
+// checksum must be exactly Size bytes.
+func (t Transposition) Encode(checksum []byte) (encoded []byte, err error) {
+	if size := t.Size(); len(checksum) != size {
+		return nil, fmt.Errorf("checksum is expected to be %d bytes but it has %d bytes", size, len(checksum))
+	}
+
+	transposed := make([]byte, len(t))
+
+	for i, j := range t {
+		transposed[i] = checksum[j]
+	}
+
+	return Hash64Encoding.Encode(transposed), nil
+}
+
+// Decode decodes the encoded checksum with the Hash64Encoding and reverses the transposition returning the checksum.
+// This is the inverse of Encode.
+func (t Transposition) Decode(encoded []byte) (checksum []byte, err error) {
+	var transposed []byte
+
+	if transposed, err = Hash64Encoding.Decode(encoded); err != nil {
+		return nil, err
+	}
+
+	if len(transposed) != len(t) {
+		return nil, fmt.Errorf("checksum is expected to be %d encoded bytes but it has %d encoded bytes", len(t), len(transposed))
+	}
+
+	checksum = make([]byte, t.Size())
+	seen := make([]bool, len(checksum))
+
+	for i, j := range t {
+		if seen[j] && checksum[j] != transposed[i] {
+			return nil, fmt.Errorf("checksum byte %d is repeated at position %d with a different value", j, i)
+		}
+
+		checksum[j], seen[j] = transposed[i], true
+	}
+
+	return checksum, nil
+}
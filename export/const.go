@@ -0,0 +1,203 @@
+package export
+
+const (
+	// HashcatModeMD5 is the hashcat hash mode for md5($pass).
+	HashcatModeMD5 = 0
+
+	// HashcatModeMD5PassSalt is the hashcat hash mode for md5($pass.$salt).
+	HashcatModeMD5PassSalt = 10
+
+	// HashcatModePostgreSQL is the hashcat hash mode for the PostgreSQL md5 format.
+	HashcatModePostgreSQL = 12
+
+	// HashcatModeMD5SaltPass is the hashcat hash mode for md5($salt.$pass).
+	HashcatModeMD5SaltPass = 20
+
+	// HashcatModeSHA1 is the hashcat hash mode for sha1($pass).
+	HashcatModeSHA1 = 100
+
+	// HashcatModeSHA1PassSalt is the hashcat hash mode for sha1($pass.$salt).
+	HashcatModeSHA1PassSalt = 110
+
+	// HashcatModeSHA1SaltPass is the hashcat hash mode for sha1($salt.$pass).
+	HashcatModeSHA1SaltPass = 120
+
+	// HashcatModeMySQL323 is the hashcat hash mode for the MySQL OLD_PASSWORD format.
+	HashcatModeMySQL323 = 200
+
+	// HashcatModeMySQL41 is the hashcat hash mode for the MySQL mysql_native_password format.
+	HashcatModeMySQL41 = 300
+
+	// HashcatModeMD5Crypt is the hashcat hash mode for md5crypt.
+	HashcatModeMD5Crypt = 500
+
+	// HashcatModeNTLM is the hashcat hash mode for the NT hash.
+	HashcatModeNTLM = 1000
+
+	// HashcatModeSHA224 is the hashcat hash mode for sha224($pass).
+	HashcatModeSHA224 = 1300
+
+	// HashcatModeSHA256 is the hashcat hash mode for sha256($pass).
+	HashcatModeSHA256 = 1400
+
+	// HashcatModeSHA256PassSalt is the hashcat hash mode for sha256($pass.$salt).
+	HashcatModeSHA256PassSalt = 1410
+
+	// HashcatModeSHA256SaltPass is the hashcat hash mode for sha256($salt.$pass).
+	HashcatModeSHA256SaltPass = 1420
+
+	// HashcatModeDESCrypt is the hashcat hash mode for descrypt.
+	HashcatModeDESCrypt = 1500
+
+	// HashcatModeSHA512 is the hashcat hash mode for sha512($pass).
+	HashcatModeSHA512 = 1700
+
+	// HashcatModeSHA512PassSalt is the hashcat hash mode for sha512($pass.$salt).
+	HashcatModeSHA512PassSalt = 1710
+
+	// HashcatModeSHA512SaltPass is the hashcat hash mode for sha512($salt.$pass).
+	HashcatModeSHA512SaltPass = 1720
+
+	// HashcatModeSHA512Crypt is the hashcat hash mode for sha512crypt.
+	HashcatModeSHA512Crypt = 1800
+
+	// HashcatModeMD5MD5 is the hashcat hash mode for md5(md5($pass)).
+	HashcatModeMD5MD5 = 2600
+
+	// HashcatModeMD5MD5PassSalt is the hashcat hash mode for md5(md5($pass).$salt).
+	HashcatModeMD5MD5PassSalt = 2611
+
+	// HashcatModeBcrypt is the hashcat hash mode for bcrypt.
+	HashcatModeBcrypt = 3200
+
+	// HashcatModeMD5MD5MD5 is the hashcat hash mode for md5(md5(md5($pass))).
+	HashcatModeMD5MD5MD5 = 3500
+
+	// HashcatModeMD5SaltMD5Pass is the hashcat hash mode for md5($salt.md5($pass)).
+	HashcatModeMD5SaltMD5Pass = 3710
+
+	// HashcatModeMD5SaltPassSalt is the hashcat hash mode for md5($salt.$pass.$salt).
+	HashcatModeMD5SaltPassSalt = 3800
+
+	// HashcatModeMD5SHA1 is the hashcat hash mode for md5(sha1($pass)).
+	HashcatModeMD5SHA1 = 4400
+
+	// HashcatModeSHA1SHA1 is the hashcat hash mode for sha1(sha1($pass)).
+	HashcatModeSHA1SHA1 = 4500
+
+	// HashcatModeSHA1MD5 is the hashcat hash mode for sha1(md5($pass)).
+	HashcatModeSHA1MD5 = 4700
+
+	// HashcatModeSHA256Crypt is the hashcat hash mode for sha256crypt.
+	HashcatModeSHA256Crypt = 7400
+
+	// HashcatModeMySQLCachingSHA2 is the hashcat hash mode for the MySQL caching_sha2_password format.
+	HashcatModeMySQLCachingSHA2 = 7401
+
+	// HashcatModeScrypt is the hashcat hash mode for scrypt.
+	HashcatModeScrypt = 8900
+
+	// HashcatModeSHA384 is the hashcat hash mode for sha384($pass).
+	HashcatModeSHA384 = 10800
+
+	// HashcatModePBKDF2SHA256 is the hashcat hash mode for PBKDF2-HMAC-SHA256.
+	HashcatModePBKDF2SHA256 = 10900
+
+	// HashcatModePBKDF2SHA1 is the hashcat hash mode for PBKDF2-HMAC-SHA1.
+	HashcatModePBKDF2SHA1 = 12000
+
+	// HashcatModePBKDF2SHA512 is the hashcat hash mode for PBKDF2-HMAC-SHA512.
+	HashcatModePBKDF2SHA512 = 12100
+
+	// HashcatModeBSDiCrypt is the hashcat hash mode for the BSDi extended DES crypt.
+	HashcatModeBSDiCrypt = 12400
+
+	// HashcatModeSHA1Crypt is the hashcat hash mode for the NetBSD sha1crypt.
+	HashcatModeSHA1Crypt = 15100
+
+	// HashcatModePostgreSQLSCRAMSHA256 is the hashcat hash mode for the PostgreSQL SCRAM-SHA-256 format.
+	HashcatModePostgreSQLSCRAMSHA256 = 28600
+
+	// HashcatModeBcryptSHA256 is the hashcat hash mode for the version 2 bcrypt-sha256 format.
+	HashcatModeBcryptSHA256 = 30600
+
+	// HashcatModeArgon2 is the hashcat hash mode for argon2.
+	HashcatModeArgon2 = 34000
+
+	// HashcatModePlaintext is the hashcat hash mode for plaintext.
+	HashcatModePlaintext = 99999
+)
+
+const (
+	// JohnFormatBcrypt is the John the Ripper format for bcrypt.
+	JohnFormatBcrypt = "bcrypt"
+
+	// JohnFormatArgon2 is the John the Ripper format for argon2.
+	JohnFormatArgon2 = "argon2"
+
+	// JohnFormatPBKDF2SHA1 is the John the Ripper format for PBKDF2-HMAC-SHA1.
+	JohnFormatPBKDF2SHA1 = "PBKDF2-HMAC-SHA1"
+
+	// JohnFormatPBKDF2SHA256 is the John the Ripper format for PBKDF2-HMAC-SHA256.
+	JohnFormatPBKDF2SHA256 = "PBKDF2-HMAC-SHA256"
+
+	// JohnFormatPBKDF2SHA512 is the John the Ripper format for PBKDF2-HMAC-SHA512.
+	JohnFormatPBKDF2SHA512 = "PBKDF2-HMAC-SHA512"
+
+	// JohnFormatScrypt is the John the Ripper format for the scrypt $7$ format.
+	JohnFormatScrypt = "scrypt"
+
+	// JohnFormatCrypt is the John the Ripper format which uses the crypt(3) function of the system.
+	JohnFormatCrypt = "crypt"
+
+	// JohnFormatSHA256Crypt is the John the Ripper format for sha256crypt.
+	JohnFormatSHA256Crypt = "sha256crypt"
+
+	// JohnFormatSHA512Crypt is the John the Ripper format for sha512crypt.
+	JohnFormatSHA512Crypt = "sha512crypt"
+
+	// JohnFormatMD5Crypt is the John the Ripper format for md5crypt.
+	JohnFormatMD5Crypt = "md5crypt"
+
+	// JohnFormatSunMD5 is the John the Ripper format for the Sun md5crypt.
+	JohnFormatSunMD5 = "sunmd5"
+
+	// JohnFormatSHA1Crypt is the John the Ripper format for the NetBSD sha1crypt.
+	JohnFormatSHA1Crypt = "sha1crypt"
+
+	// JohnFormatDESCrypt is the John the Ripper format for descrypt.
+	JohnFormatDESCrypt = "descrypt"
+
+	// JohnFormatBSDiCrypt is the John the Ripper format for the BSDi extended DES crypt.
+	JohnFormatBSDiCrypt = "bsdicrypt"
+
+	// JohnFormatNT is the John the Ripper format for the NT hash.
+	JohnFormatNT = "nt"
+
+	// JohnFormatMySQLSHA1 is the John the Ripper format for the MySQL mysql_native_password format.
+	JohnFormatMySQLSHA1 = "mysql-sha1"
+
+	// JohnFormatMySQL is the John the Ripper format for the MySQL OLD_PASSWORD format.
+	JohnFormatMySQL = "mysql"
+
+	// JohnFormatPlaintext is the John the Ripper format for plaintext.
+	JohnFormatPlaintext = "plaintext"
+
+	// JohnFormatRawMD5 is the John the Ripper format for md5($pass).
+	JohnFormatRawMD5 = "raw-md5"
+
+	// JohnFormatRawSHA1 is the John the Ripper format for sha1($pass).
+	JohnFormatRawSHA1 = "raw-sha1"
+
+	// JohnFormatRawSHA224 is the John the Ripper format for sha224($pass).
+	JohnFormatRawSHA224 = "raw-sha224"
+
+	// JohnFormatRawSHA256 is the John the Ripper format for sha256($pass).
+	JohnFormatRawSHA256 = "raw-sha256"
+
+	// JohnFormatRawSHA384 is the John the Ripper format for sha384($pass).
+	JohnFormatRawSHA384 = "raw-sha384"
+
+	// JohnFormatRawSHA512 is the John the Ripper format for sha512($pass).
+	JohnFormatRawSHA512 = "raw-sha512"
+)
@@ -0,0 +1,7 @@
+// Package export encodes decoded digests in the hash-line formats of the hashcat and John the Ripper password
+// recovery tools so a password store can be audited with them.
+//
+// The export is built on the parameters each algorithm.Digest implementation exposes. Digests which can't be
+// represented in the format of a tool return an error wrapping export.ErrUnsupported, and export.Unsupported reports
+// the digests which neither tool supports.
+package export
@@ -0,0 +1,15 @@
+package export
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnsupported is returned when a digest can't be represented in the hash-line format of a tool.
+	ErrUnsupported = errors.New("the digest is not supported")
+)
+
+const (
+	errFmtHashcat = "hashcat export error: %w: %s"
+	errFmtJohn    = "john export error: %w: %s"
+)
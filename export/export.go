@@ -0,0 +1,114 @@
+package export
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/descrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/mysql"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/scram"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+// Unsupported returns the digests which neither hashcat nor John the Ripper support.
+func Unsupported(digests ...algorithm.Digest) (unsupported []algorithm.Digest) {
+	for _, digest := range digests {
+		_, _, errHashcat := Hashcat(digest)
+		_, _, errJohn := John(digest)
+
+		if errors.Is(errHashcat, ErrUnsupported) && errors.Is(errJohn, ErrUnsupported) {
+			unsupported = append(unsupported, digest)
+		}
+	}
+
+	return unsupported
+}
+
+const johnDynamicPrefix = "dynamic_"
+
+type legacyFormat struct {
+	mode int
+	john string
+	raw  bool
+}
+
+// legacyFormats maps the recipes of legacy digests to the equivalent hashcat hash mode and John the Ripper format. The
+// raw recipes are those created by the importers where the key is the raw digest rather than the hex encoded digest.
+var legacyFormats = map[string]legacyFormat{
+	"md5($pass)":               {HashcatModeMD5, JohnFormatRawMD5, false},
+	"md5($pass.$salt)":         {HashcatModeMD5PassSalt, "dynamic_1", false},
+	"md5($salt.$pass)":         {HashcatModeMD5SaltPass, "dynamic_4", false},
+	"md5($salt.$pass.$salt)":   {HashcatModeMD5SaltPassSalt, "dynamic_5", false},
+	"md5(md5($pass))":          {HashcatModeMD5MD5, "dynamic_2", false},
+	"md5(md5(md5($pass)))":     {HashcatModeMD5MD5MD5, "dynamic_3", false},
+	"md5(md5($pass).$salt)":    {HashcatModeMD5MD5PassSalt, "dynamic_6", false},
+	"md5($salt.md5($pass))":    {HashcatModeMD5SaltMD5Pass, "dynamic_9", false},
+	"md5(sha1($pass))":         {HashcatModeMD5SHA1, "dynamic_22", false},
+	"sha1($pass)":              {HashcatModeSHA1, JohnFormatRawSHA1, false},
+	"sha1($pass.$salt)":        {HashcatModeSHA1PassSalt, "dynamic_24", false},
+	"sha1($salt.$pass)":        {HashcatModeSHA1SaltPass, "dynamic_25", false},
+	"sha1(md5($pass))":         {HashcatModeSHA1MD5, "dynamic_23", false},
+	"sha1(sha1($pass))":        {HashcatModeSHA1SHA1, "", false},
+	"sha224($pass)":            {HashcatModeSHA224, JohnFormatRawSHA224, false},
+	"sha256($pass)":            {HashcatModeSHA256, JohnFormatRawSHA256, false},
+	"sha256($pass.$salt)":      {HashcatModeSHA256PassSalt, "", false},
+	"sha256($salt.$pass)":      {HashcatModeSHA256SaltPass, "", false},
+	"sha384($pass)":            {HashcatModeSHA384, JohnFormatRawSHA384, false},
+	"sha512($pass)":            {HashcatModeSHA512, JohnFormatRawSHA512, false},
+	"sha512($pass.$salt)":      {HashcatModeSHA512PassSalt, "", false},
+	"sha512($salt.$pass)":      {HashcatModeSHA512SaltPass, "", false},
+	"raw(md5($pass))":          {HashcatModeMD5, JohnFormatRawMD5, true},
+	"raw(md5($pass.$salt))":    {HashcatModeMD5PassSalt, "dynamic_1", true},
+	"raw(md5($salt.$pass))":    {HashcatModeMD5SaltPass, "dynamic_4", true},
+	"raw(sha1($pass))":         {HashcatModeSHA1, JohnFormatRawSHA1, true},
+	"raw(sha1($pass.$salt))":   {HashcatModeSHA1PassSalt, "dynamic_24", true},
+	"raw(sha1($salt.$pass))":   {HashcatModeSHA1SaltPass, "dynamic_25", true},
+	"raw(sha256($pass))":       {HashcatModeSHA256, JohnFormatRawSHA256, true},
+	"raw(sha256($pass.$salt))": {HashcatModeSHA256PassSalt, "", true},
+	"raw(sha256($salt.$pass))": {HashcatModeSHA256SaltPass, "", true},
+	"raw(sha512($pass))":       {HashcatModeSHA512, JohnFormatRawSHA512, true},
+	"raw(sha512($pass.$salt))": {HashcatModeSHA512PassSalt, "", true},
+	"raw(sha512($salt.$pass))": {HashcatModeSHA512SaltPass, "", true},
+}
+
+// key returns the lowercase hex encoded digest of the legacy.Digest. The hash functions of a recipe already return
+// the lowercase hex encoded digest so only the key of the raw recipes is encoded.
+func (f legacyFormat) key(d *legacy.Digest) string {
+	if f.raw {
+		return hex.EncodeToString(d.Key())
+	}
+
+	return string(d.Key())
+}
+
+func normalizeRecipe(recipe *legacy.Recipe) string {
+	return strings.NewReplacer(" ", "", "\t", "").Replace(recipe.String())
+}
+
+func printable(value []byte) bool {
+	for _, c := range value {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describe(digest algorithm.Digest) string {
+	switch digest.(type) {
+	case *argon2.Digest, *bcrypt.Digest, *descrypt.Digest, *legacy.Digest, *md5crypt.Digest, *mysql.Digest, *pbkdf2.Digest, *scram.Digest, *scrypt.Digest, *shacrypt.Digest:
+		return fmt.Sprintf("digests of type '%T' with these parameters are not supported", digest)
+	default:
+		return fmt.Sprintf("digests of type '%T' are not supported", digest)
+	}
+}
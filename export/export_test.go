@@ -0,0 +1,282 @@
+package export
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/htdigest"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/mysql"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/pgmd5"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+func mustDecode(t *testing.T, decode algorithm.DecodeFunc, encodedDigest string) algorithm.Digest {
+	digest, err := decode(encodedDigest)
+	require.NoError(t, err)
+
+	return digest
+}
+
+func TestHashcatAndJohn(t *testing.T) {
+	salt, err := hex.DecodeString("F9CC98CE08892924F50A213B6BC571A2C11778C5")
+	require.NoError(t, err)
+
+	md5PassHasher, err := legacy.New(legacy.WithRecipe("md5($pass)"))
+	require.NoError(t, err)
+
+	md5Pass := md5PassHasher.MustHash("password")
+
+	md5PassSaltHasher, err := legacy.New(legacy.WithRecipe("md5($pass . $salt)"))
+	require.NoError(t, err)
+
+	md5PassSalt, err := md5PassSaltHasher.HashWithSalt("password", []byte("salt"))
+	require.NoError(t, err)
+
+	md5PassSaltBinary, err := md5PassSaltHasher.HashWithSalt("password", []byte("s$:"))
+	require.NoError(t, err)
+
+	key, err := hex.DecodeString("5f4dcc3b5aa765d61d8327deb882cf99")
+	require.NoError(t, err)
+
+	md5PassRaw, err := legacy.NewDigest("raw(md5($pass))", nil, key)
+	require.NoError(t, err)
+
+	key, err = hex.DecodeString("59b3e8d637cf97edbe2384cf59cb7453dfe30789")
+	require.NoError(t, err)
+
+	sha1SaltPassRaw, err := legacy.NewDigest("raw(sha1($salt.$pass))", []byte("salt"), key)
+	require.NoError(t, err)
+
+	for _, digest := range []algorithm.Digest{md5Pass, md5PassSalt, md5PassSaltBinary, md5PassRaw, sha1SaltPassRaw} {
+		require.True(t, digest.Match("password"))
+	}
+
+	pg, err := pgmd5.DecodeWithUsername("md532e12f215ba27cb750c9e093ce4b5127", "postgres")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		have       algorithm.Digest
+		hashcat    string
+		mode       int
+		errHashcat string
+		john       string
+		format     string
+		errJohn    string
+	}{
+		{
+			"ShouldExportBcrypt",
+			mustDecode(t, bcrypt.Decode, "$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e"),
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			HashcatModeBcrypt,
+			"",
+			"$2b$10$3o9IF74Phgdz4Q6j7K7s0unovt.v.7YBLKFyV73pGTd2.tfdz/F8e",
+			JohnFormatBcrypt,
+			"",
+		},
+		{
+			"ShouldExportBcryptCostPadded",
+			mustDecode(t, bcrypt.Decode, "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW"),
+			"$2b$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			HashcatModeBcrypt,
+			"",
+			"$2b$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			JohnFormatBcrypt,
+			"",
+		},
+		{
+			"ShouldExportPBKDF2SHA256",
+			mustDecode(t, pbkdf2.Decode, "$pbkdf2-sha256$100000$c2FsdHNhbHRzYWx0c2FsdA$T78tEi/mr8Yageny/jk6s5.Qanjd3ceXdjwOeEhX6bQ"),
+			"sha256:100000:c2FsdHNhbHRzYWx0c2FsdA==:T78tEi/mr8Yageny/jk6s5+Qanjd3ceXdjwOeEhX6bQ=",
+			HashcatModePBKDF2SHA256,
+			"",
+			"$pbkdf2-sha256$100000$c2FsdHNhbHRzYWx0c2FsdA$T78tEi/mr8Yageny/jk6s5.Qanjd3ceXdjwOeEhX6bQ",
+			JohnFormatPBKDF2SHA256,
+			"",
+		},
+		{
+			"ShouldExportPBKDF2SHA1",
+			mustDecode(t, pbkdf2.Decode, "$pbkdf2$1000$c2FsdA$AAECAwQFBgcICQoLDA0ODxAREhM"),
+			"sha1:1000:c2FsdA==:AAECAwQFBgcICQoLDA0ODxAREhM=",
+			HashcatModePBKDF2SHA1,
+			"",
+			"$pbkdf2-hmac-sha1$1000.73616c74.000102030405060708090a0b0c0d0e0f10111213",
+			JohnFormatPBKDF2SHA1,
+			"",
+		},
+		{
+			"ShouldExportSHA512Crypt",
+			mustDecode(t, shacrypt.Decode, "$6$rounds=5000$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"),
+			"$6$rounds=5000$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+			HashcatModeSHA512Crypt,
+			"",
+			"$6$rounds=5000$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+			JohnFormatSHA512Crypt,
+			"",
+		},
+		{
+			"ShouldExportNTHash",
+			mustDecode(t, nthash.Decode, "$3$$8846f7eaee8fb117ad06bdd830b7586c"),
+			"8846f7eaee8fb117ad06bdd830b7586c",
+			HashcatModeNTLM,
+			"",
+			"$NT$8846f7eaee8fb117ad06bdd830b7586c",
+			JohnFormatNT,
+			"",
+		},
+		{
+			"ShouldExportMySQLNative",
+			mustDecode(t, mysql.Decode, "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19"),
+			"2470c0c06dee42fd1618bb99005adca2ec9d1e19",
+			HashcatModeMySQL41,
+			"",
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+			JohnFormatMySQLSHA1,
+			"",
+		},
+		{
+			"ShouldExportMySQLCachingSHA2",
+			mustDecode(t, mysql.Decode, "$A$005$"+string(salt)+"bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/"),
+			"$mysql$A$005*F9CC98CE08892924F50A213B6BC571A2C11778C5*625479393559393965414D45316477456B484F41316E64484742577A2E3162785353526B7554584647562F",
+			HashcatModeMySQLCachingSHA2,
+			"",
+			"",
+			"",
+			"john export error: the digest is not supported: digests of type '*mysql.Digest' with these parameters are not supported",
+		},
+		{
+			"ShouldExportPostgreSQLMD5",
+			pg,
+			"32e12f215ba27cb750c9e093ce4b5127:postgres",
+			HashcatModePostgreSQL,
+			"",
+			"",
+			"",
+			"john export error: the digest is not supported: digests of type '*pgmd5.Digest' are not supported",
+		},
+		{
+			"ShouldExportLegacyUnsalted",
+			md5Pass,
+			"5f4dcc3b5aa765d61d8327deb882cf99",
+			HashcatModeMD5,
+			"",
+			"5f4dcc3b5aa765d61d8327deb882cf99",
+			JohnFormatRawMD5,
+			"",
+		},
+		{
+			"ShouldExportLegacyRawUnsalted",
+			md5PassRaw,
+			"5f4dcc3b5aa765d61d8327deb882cf99",
+			HashcatModeMD5,
+			"",
+			"5f4dcc3b5aa765d61d8327deb882cf99",
+			JohnFormatRawMD5,
+			"",
+		},
+		{
+			"ShouldExportLegacyRawSalted",
+			sha1SaltPassRaw,
+			"59b3e8d637cf97edbe2384cf59cb7453dfe30789:salt",
+			HashcatModeSHA1SaltPass,
+			"",
+			"$dynamic_25$59b3e8d637cf97edbe2384cf59cb7453dfe30789$salt",
+			"dynamic_25",
+			"",
+		},
+		{
+			"ShouldExportLegacySalted",
+			md5PassSalt,
+			"b305cadbb3bce54f3aa59c64fec00dea:salt",
+			HashcatModeMD5PassSalt,
+			"",
+			"$dynamic_1$b305cadbb3bce54f3aa59c64fec00dea$salt",
+			"dynamic_1",
+			"",
+		},
+		{
+			"ShouldExportLegacySaltedHex",
+			md5PassSaltBinary,
+			"",
+			-1,
+			"hashcat export error: the digest is not supported: legacy digests must have a salt which only contains printable characters other than ':'",
+			"$dynamic_1$a922ffaca28c2b3e2cfd6ff18c977683$HEX$73243a",
+			"dynamic_1",
+			"",
+		},
+		{
+			"ShouldNotExportNil",
+			nil,
+			"",
+			-1,
+			"can't export a nil digest",
+			"",
+			"",
+			"can't export a nil digest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, mode, err := Hashcat(tc.have)
+
+			if tc.errHashcat == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.hashcat, line)
+				assert.Equal(t, tc.mode, mode)
+			} else {
+				assert.EqualError(t, err, tc.errHashcat)
+				assert.Equal(t, -1, mode)
+			}
+
+			line, format, err := John(tc.have)
+
+			if tc.errJohn == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.john, line)
+				assert.Equal(t, tc.format, format)
+			} else {
+				assert.EqualError(t, err, tc.errJohn)
+			}
+		})
+	}
+}
+
+func TestHashcatScrypt(t *testing.T) {
+	hasher, err := scrypt.New(scrypt.WithLN(10), scrypt.WithR(1), scrypt.WithP(1))
+	require.NoError(t, err)
+
+	digest, err := hasher.HashWithSalt("password", []byte("saltsaltsaltsalt"))
+	require.NoError(t, err)
+
+	line, mode, err := Hashcat(digest)
+	require.NoError(t, err)
+
+	assert.Equal(t, HashcatModeScrypt, mode)
+	assert.Regexp(t, `^SCRYPT:1024:1:1:c2FsdHNhbHRzYWx0c2FsdA==:[+/0-9A-Za-z]{43}=$`, line)
+}
+
+func TestUnsupported(t *testing.T) {
+	ht, err := htdigest.Decode("Mufasa:testrealm@host.com:939e7578ed9e3c518a452acee763bce9")
+	require.NoError(t, err)
+
+	sha384, err := bcrypt.NewSHA384(bcrypt.WithCost(10))
+	require.NoError(t, err)
+
+	bcryptSHA384, err := sha384.Hash("password")
+	require.NoError(t, err)
+
+	nt := mustDecode(t, nthash.Decode, "$3$$8846f7eaee8fb117ad06bdd830b7586c")
+
+	assert.Equal(t, []algorithm.Digest{ht, bcryptSHA384}, Unsupported(nt, ht, bcryptSHA384))
+	assert.Nil(t, Unsupported(nt))
+}
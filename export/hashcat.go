@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/descrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/mysql"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/pgmd5"
+	"github.com/go-crypt/crypt/algorithm/plaintext"
+	"github.com/go-crypt/crypt/algorithm/scram"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/sha1crypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+// Hashcat returns the algorithm.Digest as a hashcat hash-line and the hashcat hash mode the hash-line must be used
+// with. An error wrapping export.ErrUnsupported is returned if hashcat does not support the algorithm.Digest.
+func Hashcat(digest algorithm.Digest) (line string, mode int, err error) {
+	switch d := digest.(type) {
+	case nil:
+		return "", -1, fmt.Errorf("can't export a nil digest")
+	case *argon2.Digest:
+		if d.Version() != argon2.Version13 || len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, "argon2 digests must use version 19 without the keyid or data parameters")
+		}
+
+		return d.Canonical().Encode(), HashcatModeArgon2, nil
+	case *bcrypt.Digest:
+		switch d.Variant() {
+		case bcrypt.VariantStandard:
+			return d.Canonical().Encode(), HashcatModeBcrypt, nil
+		case bcrypt.VariantSHA256:
+			return d.Canonical().Encode(), HashcatModeBcryptSHA256, nil
+		}
+	case *pbkdf2.Digest:
+		var name string
+
+		switch d.Variant() {
+		case pbkdf2.VariantSHA1:
+			name, mode = "sha1", HashcatModePBKDF2SHA1
+		case pbkdf2.VariantSHA256:
+			name, mode = "sha256", HashcatModePBKDF2SHA256
+		case pbkdf2.VariantSHA512:
+			name, mode = "sha512", HashcatModePBKDF2SHA512
+		default:
+			return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, fmt.Sprintf("pbkdf2 digests using the %s variant are not supported", d.Variant()))
+		}
+
+		return fmt.Sprintf("%s:%d:%s:%s", name, d.Iterations(), base64.StdEncoding.EncodeToString(d.Salt()), base64.StdEncoding.EncodeToString(d.Key())), mode, nil
+	case *scrypt.Digest:
+		if d.Variant() == scrypt.VariantScrypt {
+			return fmt.Sprintf("SCRYPT:%d:%d:%d:%s:%s", 1<<d.LN(), d.R(), d.P(), base64.StdEncoding.EncodeToString(d.Salt()), base64.StdEncoding.EncodeToString(d.Key())), HashcatModeScrypt, nil
+		}
+	case *shacrypt.Digest:
+		switch d.Variant() {
+		case shacrypt.VariantSHA256:
+			return d.Canonical().Encode(), HashcatModeSHA256Crypt, nil
+		case shacrypt.VariantSHA512:
+			return d.Canonical().Encode(), HashcatModeSHA512Crypt, nil
+		}
+	case *md5crypt.Digest:
+		if d.Variant() == md5crypt.VariantStandard {
+			return d.Canonical().Encode(), HashcatModeMD5Crypt, nil
+		}
+	case *sha1crypt.Digest:
+		return d.Canonical().Encode(), HashcatModeSHA1Crypt, nil
+	case *descrypt.Digest:
+		switch d.Variant() {
+		case descrypt.VariantStandard:
+			return d.Canonical().Encode(), HashcatModeDESCrypt, nil
+		case descrypt.VariantExtended:
+			return d.Canonical().Encode(), HashcatModeBSDiCrypt, nil
+		}
+	case *nthash.Digest:
+		return hex.EncodeToString(d.Key()), HashcatModeNTLM, nil
+	case *mysql.Digest:
+		switch d.Variant() {
+		case mysql.VariantNative:
+			return hex.EncodeToString(d.Key()), HashcatModeMySQL41, nil
+		case mysql.VariantOldPassword:
+			return hex.EncodeToString(d.Key()), HashcatModeMySQL323, nil
+		case mysql.VariantCachingSHA2:
+			return fmt.Sprintf("$mysql$%s$%03X*%s*%s", mysql.AlgIdentifierCachingSHA2, d.Iterations()/mysql.IterationsMultiplier, strings.ToUpper(hex.EncodeToString(d.Salt())), strings.ToUpper(hex.EncodeToString(d.Key()))), HashcatModeMySQLCachingSHA2, nil
+		}
+	case *pgmd5.Digest:
+		if len(d.Username()) == 0 {
+			return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, "pgmd5 digests must have a username")
+		}
+
+		return fmt.Sprintf("%x:%s", d.Key(), d.Username()), HashcatModePostgreSQL, nil
+	case *scram.Digest:
+		if d.Variant() == scram.VariantSHA256 {
+			return d.Canonical().Encode(), HashcatModePostgreSQLSCRAMSHA256, nil
+		}
+	case *plaintext.Digest:
+		if !printable(d.Key()) {
+			return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, "plaintext digests must only contain printable characters")
+		}
+
+		return string(d.Key()), HashcatModePlaintext, nil
+	case *legacy.Digest:
+		return hashcatLegacy(d)
+	}
+
+	return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, describe(digest))
+}
+
+func hashcatLegacy(d *legacy.Digest) (line string, mode int, err error) {
+	f, ok := legacyFormats[normalizeRecipe(d.Recipe())]
+
+	if !ok {
+		return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, fmt.Sprintf("legacy digests using the '%s' recipe are not supported", d.Recipe()))
+	}
+
+	if !d.Recipe().Salted() {
+		return f.key(d), f.mode, nil
+	}
+
+	if !printable(d.Salt()) || strings.ContainsRune(string(d.Salt()), ':') {
+		return "", -1, fmt.Errorf(errFmtHashcat, ErrUnsupported, "legacy digests must have a salt which only contains printable characters other than ':'")
+	}
+
+	return fmt.Sprintf("%s:%s", f.key(d), d.Salt()), f.mode, nil
+}
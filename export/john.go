@@ -0,0 +1,121 @@
+package export
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/descrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/mysql"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/plaintext"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/sha1crypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+// John returns the algorithm.Digest as a John the Ripper hash-line and the name of the format the hash-line must be
+// used with. An error wrapping export.ErrUnsupported is returned if John the Ripper does not support the
+// algorithm.Digest.
+func John(digest algorithm.Digest) (line, format string, err error) {
+	switch d := digest.(type) {
+	case nil:
+		return "", "", fmt.Errorf("can't export a nil digest")
+	case *argon2.Digest:
+		if len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return "", "", fmt.Errorf(errFmtJohn, ErrUnsupported, "argon2 digests must not use the keyid or data parameters")
+		}
+
+		return d.Canonical().Encode(), JohnFormatArgon2, nil
+	case *bcrypt.Digest:
+		if d.Variant() == bcrypt.VariantStandard {
+			return d.Canonical().Encode(), JohnFormatBcrypt, nil
+		}
+	case *pbkdf2.Digest:
+		switch d.Variant() {
+		case pbkdf2.VariantSHA1:
+			return fmt.Sprintf("$pbkdf2-hmac-sha1$%d.%x.%x", d.Iterations(), d.Salt(), d.Key()), JohnFormatPBKDF2SHA1, nil
+		case pbkdf2.VariantSHA256:
+			return d.Canonical().Encode(), JohnFormatPBKDF2SHA256, nil
+		case pbkdf2.VariantSHA512:
+			return fmt.Sprintf("$pbkdf2-hmac-sha512$%d.%x.%x", d.Iterations(), d.Salt(), d.Key()), JohnFormatPBKDF2SHA512, nil
+		}
+	case *scrypt.Digest:
+		switch d.Variant() {
+		case scrypt.VariantScryptCrypt:
+			return d.Canonical().Encode(), JohnFormatScrypt, nil
+		case scrypt.VariantYescrypt, scrypt.VariantGostYescrypt:
+			return d.Canonical().Encode(), JohnFormatCrypt, nil
+		}
+	case *shacrypt.Digest:
+		switch d.Variant() {
+		case shacrypt.VariantSHA256:
+			return d.Canonical().Encode(), JohnFormatSHA256Crypt, nil
+		case shacrypt.VariantSHA512:
+			return d.Canonical().Encode(), JohnFormatSHA512Crypt, nil
+		}
+	case *md5crypt.Digest:
+		switch d.Variant() {
+		case md5crypt.VariantStandard:
+			return d.Canonical().Encode(), JohnFormatMD5Crypt, nil
+		case md5crypt.VariantSun:
+			return d.Canonical().Encode(), JohnFormatSunMD5, nil
+		}
+	case *sha1crypt.Digest:
+		return d.Canonical().Encode(), JohnFormatSHA1Crypt, nil
+	case *descrypt.Digest:
+		switch d.Variant() {
+		case descrypt.VariantStandard:
+			return d.Canonical().Encode(), JohnFormatDESCrypt, nil
+		case descrypt.VariantExtended:
+			return d.Canonical().Encode(), JohnFormatBSDiCrypt, nil
+		}
+	case *nthash.Digest:
+		return fmt.Sprintf("$NT$%x", d.Key()), JohnFormatNT, nil
+	case *mysql.Digest:
+		switch d.Variant() {
+		case mysql.VariantNative:
+			return "*" + strings.ToUpper(hex.EncodeToString(d.Key())), JohnFormatMySQLSHA1, nil
+		case mysql.VariantOldPassword:
+			return hex.EncodeToString(d.Key()), JohnFormatMySQL, nil
+		}
+	case *plaintext.Digest:
+		if !printable(d.Key()) {
+			return "", "", fmt.Errorf(errFmtJohn, ErrUnsupported, "plaintext digests must only contain printable characters")
+		}
+
+		return "$0$" + string(d.Key()), JohnFormatPlaintext, nil
+	case *legacy.Digest:
+		return johnLegacy(d)
+	}
+
+	return "", "", fmt.Errorf(errFmtJohn, ErrUnsupported, describe(digest))
+}
+
+func johnLegacy(d *legacy.Digest) (line, format string, err error) {
+	f, ok := legacyFormats[normalizeRecipe(d.Recipe())]
+
+	if !ok || len(f.john) == 0 {
+		return "", "", fmt.Errorf(errFmtJohn, ErrUnsupported, fmt.Sprintf("legacy digests using the '%s' recipe are not supported", d.Recipe()))
+	}
+
+	if !strings.HasPrefix(f.john, johnDynamicPrefix) {
+		return f.key(d), f.john, nil
+	}
+
+	if !d.Recipe().Salted() {
+		return fmt.Sprintf("$%s$%s", f.john, f.key(d)), f.john, nil
+	}
+
+	if !printable(d.Salt()) || strings.ContainsRune(string(d.Salt()), '$') {
+		return fmt.Sprintf("$%s$%s$HEX$%x", f.john, f.key(d), d.Salt()), f.john, nil
+	}
+
+	return fmt.Sprintf("$%s$%s$%s", f.john, f.key(d), d.Salt()), f.john, nil
+}
@@ -0,0 +1,193 @@
+package crypt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/phc"
+)
+
+var (
+	reAlgorithmPrefixPBKDF2 = regexp.MustCompile(`^(?P<prefix>\{(?P<identifier>PBKDF2(-SHA\d+)?)})(?P<remainder>\d+\$.*)$`)
+)
+
+// Format describes a storage format for encoded digests such as the OpenLDAP {CRYPT} storage format. The input side of
+// a Format normalizes a stored value into an encoded digest which can be decoded, and the output side encodes an
+// algorithm.Digest as a stored value. Formats are registered with a Decoder via RegisterFormat.
+type Format interface {
+	// Name returns the unique name of the Format which is used with EncodeAs.
+	Name() (name string)
+
+	// Normalize converts a value stored in this Format into an encoded digest, returning false if the value is not in
+	// this Format.
+	Normalize(value string) (encodedDigest string, ok bool)
+
+	// Encode converts the algorithm.Digest into a value stored in this Format.
+	Encode(digest algorithm.Digest) (value string, err error)
+}
+
+// formatStoragePrefixer is implemented by a Format which does not simply remove a prefix when normalizing a value.
+type formatStoragePrefixer interface {
+	storagePrefix(value, encodedDigest string) (prefix string)
+}
+
+// NewFormatPrefix returns a Format which stores encoded digests with the provided prefix such as {CRYPT}. If any
+// identifiers are provided the Format can only encode digests which use one of those identifiers.
+func NewFormatPrefix(name, prefix string, identifiers ...string) Format {
+	return &formatPrefix{name: name, prefix: prefix, identifiers: identifiers}
+}
+
+// NewFormatLDAPCrypt returns the Format for the OpenLDAP {CRYPT} storage format.
+func NewFormatLDAPCrypt() Format {
+	return NewFormatPrefix(FormatNameLDAPCrypt, StorageFormatPrefixLDAPCrypt)
+}
+
+// NewFormatLDAPArgon2 returns the Format for the OpenLDAP {ARGON2} storage format.
+func NewFormatLDAPArgon2() Format {
+	return NewFormatPrefix(FormatNameLDAPArgon2, StorageFormatPrefixLDAPArgon2, argon2.AlgIdentifierVariantID, argon2.AlgIdentifierVariantI, argon2.AlgIdentifierVariantD)
+}
+
+// NewFormatLDAPPBKDF2 returns the Format for the {PBKDF2}, {PBKDF2-SHA1}, {PBKDF2-SHA256}, and {PBKDF2-SHA512}
+// storage formats used by OpenLDAP and passlib. Unlike the other formats the scheme replaces the identifier.
+func NewFormatLDAPPBKDF2() Format {
+	return &formatLDAPPBKDF2{}
+}
+
+type formatPrefix struct {
+	name, prefix string
+	identifiers  []string
+}
+
+func (f *formatPrefix) Name() (name string) {
+	return f.name
+}
+
+func (f *formatPrefix) Normalize(value string) (encodedDigest string, ok bool) {
+	if !strings.HasPrefix(value, f.prefix) {
+		return value, false
+	}
+
+	return value[len(f.prefix):], true
+}
+
+func (f *formatPrefix) Encode(digest algorithm.Digest) (value string, err error) {
+	encodedDigest := EncodeCanonical(digest)
+
+	if len(f.identifiers) == 0 {
+		return f.prefix + encodedDigest, nil
+	}
+
+	identifier := formatIdentifier(encodedDigest)
+
+	for _, i := range f.identifiers {
+		if i == identifier {
+			return f.prefix + encodedDigest, nil
+		}
+	}
+
+	return "", fmt.Errorf("format '%s' does not support digests with the identifier '%s'", f.name, identifier)
+}
+
+type formatLDAPPBKDF2 struct{}
+
+func (f *formatLDAPPBKDF2) Name() (name string) {
+	return FormatNameLDAPPBKDF2
+}
+
+func (f *formatLDAPPBKDF2) Normalize(value string) (encodedDigest string, ok bool) {
+	matches := reAlgorithmPrefixPBKDF2.FindStringSubmatch(value)
+
+	if len(matches) == 0 {
+		return value, false
+	}
+
+	return fmt.Sprintf("$%s$%s",
+		strings.ToLower(matches[reAlgorithmPrefixPBKDF2.SubexpIndex("identifier")]),
+		matches[reAlgorithmPrefixPBKDF2.SubexpIndex("remainder")],
+	), true
+}
+
+func (f *formatLDAPPBKDF2) Encode(digest algorithm.Digest) (value string, err error) {
+	encodedDigest := EncodeCanonical(digest)
+
+	parts := phc.Split(encodedDigest, 3)
+
+	if len(parts) == 3 {
+		value = fmt.Sprintf("{%s}%s", strings.ToUpper(parts[1]), parts[2])
+
+		if strings.HasPrefix(parts[1], "pbkdf2") && reAlgorithmPrefixPBKDF2.MatchString(value) {
+			return value, nil
+		}
+	}
+
+	return "", fmt.Errorf("format '%s' does not support digests with the identifier '%s'", FormatNameLDAPPBKDF2, formatIdentifier(encodedDigest))
+}
+
+func (f *formatLDAPPBKDF2) storagePrefix(value, encodedDigest string) (prefix string) {
+	return reAlgorithmPrefixPBKDF2.FindStringSubmatch(value)[reAlgorithmPrefixPBKDF2.SubexpIndex("prefix")]
+}
+
+func formatIdentifier(encodedDigest string) (identifier string) {
+	if parts := phc.Split(encodedDigest, 3); len(parts) == 3 {
+		return parts[1]
+	}
+
+	return ""
+}
+
+func formatsDefault() []Format {
+	return []Format{NewFormatLDAPCrypt(), NewFormatLDAPArgon2(), NewFormatLDAPPBKDF2()}
+}
+
+type decodeNormalizer struct {
+	normalize NormalizeFunc
+	prefixer  formatStoragePrefixer
+}
+
+func newDecodeNormalizer(format Format) decodeNormalizer {
+	n := decodeNormalizer{normalize: format.Normalize}
+
+	if p, ok := format.(formatStoragePrefixer); ok {
+		n.prefixer = p
+	}
+
+	return n
+}
+
+// normalize applies each normalizer to the value in order returning the encoded digest and the storage prefix which was
+// removed if it can be retained.
+func normalize(normalizers []decodeNormalizer, value string) (encodedDigest, prefix string) {
+	encodedDigest = value
+
+	lossless := true
+
+	for _, n := range normalizers {
+		normalized, ok := n.normalize(encodedDigest)
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case n.prefixer != nil:
+			prefix += n.prefixer.storagePrefix(encodedDigest, normalized)
+		default:
+			if strings.HasSuffix(encodedDigest, normalized) {
+				prefix += encodedDigest[:len(encodedDigest)-len(normalized)]
+			} else {
+				lossless = false
+			}
+		}
+
+		encodedDigest = normalized
+	}
+
+	if !lossless {
+		return encodedDigest, ""
+	}
+
+	return encodedDigest, prefix
+}
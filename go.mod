@@ -7,6 +7,7 @@ toolchain go1.27.0
 require (
 	github.com/go-crypt/x v0.4.16
 	github.com/stretchr/testify v1.12.1
+	golang.org/x/text v0.41.0
 )
 
 require (
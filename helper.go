@@ -1,6 +1,8 @@
 package crypt
 
 import (
+	"fmt"
+
 	"github.com/go-crypt/crypt/algorithm"
 )
 
@@ -44,3 +46,65 @@ func CheckPasswordWithPlainText(password, encodedDigest string) (valid bool, err
 
 	return digest.MatchAdvanced(password)
 }
+
+// CheckPasswordWithContext is the same as CheckPassword however the algorithm.MatchContext is passed to digests which
+// implement algorithm.ContextualMatcher, such as those which mix the username or realm into the key.
+//
+// CRITICAL STABILITY NOTE: the decoders loaded via this function are not guaranteed to remain the same. It is strongly
+// recommended that users implementing this library use the NewDecoder function and explicitly register each decoder
+// which they wish to support.
+func CheckPasswordWithContext(password, encodedDigest string, ctx algorithm.MatchContext) (valid bool, err error) {
+	var digest algorithm.Digest
+
+	if digest, err = Decode(encodedDigest); err != nil {
+		return false, err
+	}
+
+	return MatchWithContext(digest, password, ctx)
+}
+
+// MatchWithContext performs the MatchAdvancedWithContext function on the algorithm.Digest if it implements
+// algorithm.ContextualMatcher, otherwise it returns the result of the MatchAdvanced function and the
+// algorithm.MatchContext is ignored. An error is returned if the algorithm.Digest is nil.
+func MatchWithContext(digest algorithm.Digest, password string, ctx algorithm.MatchContext) (match bool, err error) {
+	if digest == nil {
+		return false, fmt.Errorf("can't match a nil digest")
+	}
+
+	if c, ok := digest.(algorithm.ContextualMatcher); ok {
+		return c.MatchAdvancedWithContext(password, ctx)
+	}
+
+	return digest.MatchAdvanced(password)
+}
+
+// Canonical returns the canonical form of the algorithm.Digest if it implements algorithm.CanonicalDigest, otherwise it
+// returns the algorithm.Digest as is.
+func Canonical(digest algorithm.Digest) algorithm.Digest {
+	if c, ok := digest.(algorithm.CanonicalDigest); ok {
+		return c.Canonical()
+	}
+
+	return digest
+}
+
+// EncodeCanonical returns the canonical encoded form of the algorithm.Digest if it implements
+// algorithm.CanonicalDigest, otherwise it returns the result of the Encode function.
+func EncodeCanonical(digest algorithm.Digest) string {
+	if c, ok := digest.(algorithm.CanonicalDigest); ok {
+		return c.Canonical().Encode()
+	}
+
+	return digest.Encode()
+}
+
+// IsCanonical returns true if the algorithm.Digest is encoded in the canonical form. This is intended to be used to
+// identify encoded digests which should be normalized by replacing them with the result of EncodeCanonical. Digests
+// which do not implement algorithm.CanonicalDigest are always considered canonical.
+func IsCanonical(digest algorithm.Digest) bool {
+	if c, ok := digest.(algorithm.CanonicalDigest); ok {
+		return c.Encode() == c.Canonical().Encode()
+	}
+
+	return true
+}
@@ -0,0 +1,194 @@
+package crypt
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Confidence describes how likely it is that a Candidate describes the algorithm which produced an encoded digest.
+type Confidence int
+
+const (
+	// ConfidenceLow indicates the encoded digest only matches the length and alphabet of the Candidate, such as an
+	// unsalted hex encoded digest.
+	ConfidenceLow Confidence = iota + 1
+
+	// ConfidenceMedium indicates the encoded digest matches the structure of the Candidate but the structure is not
+	// unique to it.
+	ConfidenceMedium
+
+	// ConfidenceHigh indicates the encoded digest matches a unique identifier and the structure of the Candidate.
+	ConfidenceHigh
+)
+
+// String returns the name of the Confidence.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return ""
+	}
+}
+
+// Candidate is a possible algorithm and variant for an encoded digest which is returned by Identify.
+type Candidate struct {
+	// Algorithm is the name of the algorithm, which is the AlgName of the package when the algorithm is implemented by
+	// this module.
+	Algorithm string
+
+	// Variant is the name of the variant of the algorithm.
+	Variant string
+
+	// Confidence describes how likely it is that this is the algorithm which produced the encoded digest.
+	Confidence Confidence
+
+	// Verifiable is true if this module is able to verify passwords against the encoded digest.
+	Verifiable bool
+
+	// RegisterDecoder is the function which registers the decoder for this Candidate with a Decoder, for example
+	// argon2.RegisterDecoder. It's empty if the Candidate is not Verifiable or can't be decoded from the encoded digest
+	// on its own.
+	RegisterDecoder string
+
+	// Format is the name of the Format the encoded digest was stored in, for example ldap-crypt. It's empty if the
+	// encoded digest was not stored in a Format.
+	Format string
+
+	// Note is additional information about the Candidate such as how to verify it when RegisterDecoder is empty.
+	Note string
+}
+
+type signature struct {
+	pattern   *regexp.Regexp
+	candidate Candidate
+
+	// binary is true if the encoded digest may contain arbitrary bytes including CR and LF.
+	binary bool
+}
+
+const (
+	reCrypt64  = `[./0-9A-Za-z]`
+	reBase64   = `[+/0-9A-Za-z]`
+	reBase64Pd = `[+/0-9A-Za-z]+={0,2}`
+	reHex      = `[0-9A-Fa-f]`
+)
+
+var signatures = []signature{
+	sig(`^\$argon2id\$(v=\d+\$)?m=\d+,t=\d+,p=\d+(,[^$]*)?\$`+reBase64+`+\$`+reBase64+`+$`, Candidate{Algorithm: "argon2", Variant: "argon2id", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "argon2.RegisterDecoder"}),
+	sig(`^\$argon2i\$(v=\d+\$)?m=\d+,t=\d+,p=\d+(,[^$]*)?\$`+reBase64+`+\$`+reBase64+`+$`, Candidate{Algorithm: "argon2", Variant: "argon2i", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "argon2.RegisterDecoder"}),
+	sig(`^\$argon2d\$(v=\d+\$)?m=\d+,t=\d+,p=\d+(,[^$]*)?\$`+reBase64+`+\$`+reBase64+`+$`, Candidate{Algorithm: "argon2", Variant: "argon2d", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "argon2.RegisterDecoder"}),
+	sig(`^\$2[abxy]?\$\d{2}\$`+reCrypt64+`{53}$`, Candidate{Algorithm: "bcrypt", Variant: "standard", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "bcrypt.RegisterDecoder"}),
+	sig(`^\$bcrypt-sha256\$(v=2,t=2[abxy]?,r=\d+|2[abxy]?,\d+)\$`+reCrypt64+`{22}\$`+reCrypt64+`{31}$`, Candidate{Algorithm: "bcrypt", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "bcrypt.RegisterDecoder"}),
+	sig(`^\$bcrypt-sha384\$(v=2,t=2[abxy]?,r=\d+|2[abxy]?,\d+)\$`+reCrypt64+`{22}\$`+reCrypt64+`{31}$`, Candidate{Algorithm: "bcrypt", Variant: "sha384", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "bcrypt.RegisterDecoder"}),
+	sig(`^\$bcrypt-sha512\$(v=2,t=2[abxy]?,r=\d+|2[abxy]?,\d+)\$`+reCrypt64+`{22}\$`+reCrypt64+`{31}$`, Candidate{Algorithm: "bcrypt", Variant: "sha512", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "bcrypt.RegisterDecoder"}),
+	sig(`^\$pbkdf2\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha1", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha1\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha1", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha224\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha224", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha256\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha384\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha384", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha512\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha512", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoder"}),
+	sig(`^\$pbkdf2-sha512-256\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha512-256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoderSHA512_256"}),
+	sig(`^\$pbkdf2-sha3-256\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha3-256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoderSHA3_256"}),
+	sig(`^\$pbkdf2-sha3-512\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "sha3-512", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoderSHA3_512"}),
+	sig(`^\$pbkdf2-blake2b-512\$\d+\$[./+0-9A-Za-z]+\$[./+0-9A-Za-z]+$`, Candidate{Algorithm: "pbkdf2", Variant: "blake2b-512", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pbkdf2.RegisterDecoderBLAKE2b_512"}),
+	sig(`^\$scrypt\$ln=\d+,r=\d+,p=\d+\$`+reBase64+`+\$`+reBase64+`+$`, Candidate{Algorithm: "scrypt", Variant: "scrypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"}),
+	sig(`^\$y\$`+reCrypt64+`+\$`+reCrypt64+`*\$`+reCrypt64+`{43}$`, Candidate{Algorithm: "scrypt", Variant: "yescrypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"}),
+	sig(`^\$7\$`+reCrypt64+`{11}`+reCrypt64+`*\$`+reCrypt64+`{43}$`, Candidate{Algorithm: "scrypt", Variant: "scrypt-crypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"}),
+	sig(`^\$gy\$`+reCrypt64+`+\$`+reCrypt64+`*\$`+reCrypt64+`{43}$`, Candidate{Algorithm: "scrypt", Variant: "gost-yescrypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"}),
+	sig(`^\$firebase-scrypt\$`, Candidate{Algorithm: "scrypt", Variant: "firebase", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"}),
+	sig(`^\$5\$(rounds=\d+\$)?[^$]{0,16}\$`+reCrypt64+`{43}$`, Candidate{Algorithm: "shacrypt", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "shacrypt.RegisterDecoder"}),
+	sig(`^\$6\$(rounds=\d+\$)?[^$]{0,16}\$`+reCrypt64+`{86}$`, Candidate{Algorithm: "shacrypt", Variant: "sha512", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "shacrypt.RegisterDecoder"}),
+	sig(`^\$1\$[^$]{0,8}\$`+reCrypt64+`{22}$`, Candidate{Algorithm: "md5crypt", Variant: "standard", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "md5crypt.RegisterDecoder"}),
+	sig(`^\$md5(,iterations=\d+)?\$[^$]*\$\$?`+reCrypt64+`{22}$`, Candidate{Algorithm: "md5crypt", Variant: "sun", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "md5crypt.RegisterDecoder"}),
+	sig(`^\$apr1\$[^$]{0,8}\$`+reCrypt64+`{22}$`, Candidate{Algorithm: "apr1", Variant: "apache", Confidence: ConfidenceHigh, Note: "the Apache APR1 MD5 variant of md5crypt is not supported"}),
+	sig(`^\$sha1\$\d+\$`+reCrypt64+`{0,64}\$`+reCrypt64+`{28}$`, Candidate{Algorithm: "sha1crypt", Variant: "standard", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "sha1crypt.RegisterDecoder"}),
+	sig(`^\$plaintext\$`, Candidate{Algorithm: "plaintext", Variant: "plaintext", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "plaintext.RegisterDecoder"}),
+	sig(`^\$base64\$`+reBase64Pd+`$`, Candidate{Algorithm: "plaintext", Variant: "base64", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "plaintext.RegisterDecoder"}),
+	sig(`^\$3\$\$`+reHex+`{32}$`, Candidate{Algorithm: "nthash", Variant: "freebsd", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "nthash.RegisterDecoder"}),
+	sig(`^\$legacy\$r=`+reBase64+`+\$`+reBase64+`*\$`+reBase64+`+$`, Candidate{Algorithm: "legacy", Variant: "recipe", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "legacy.RegisterDecoder"}),
+	sigBinary(`(?s)^\$A\$[0-9A-F]{3}\$.{20}`+reCrypt64+`{43}$`, Candidate{Algorithm: "mysql", Variant: "caching_sha2", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder"}),
+	sig(`^\*`+reHex+`{40}$`, Candidate{Algorithm: "mysql", Variant: "native", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder"}),
+	sig(`^md5[0-9a-f]{32}$`, Candidate{Algorithm: "pgmd5", Variant: "md5", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pgmd5.RegisterDecoder", Note: "matching requires the username via a MatchContext"}),
+	sig(`^SCRAM-SHA-1\$`, Candidate{Algorithm: "scram", Variant: "sha1", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scram.RegisterDecoder"}),
+	sig(`^SCRAM-SHA-256\$`, Candidate{Algorithm: "scram", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scram.RegisterDecoder"}),
+	sig(`^\{PKCS5S2\}`+reBase64+`{64}$`, Candidate{Algorithm: "atlassian", Variant: "pkcs5s2", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "atlassian.RegisterDecoder"}),
+	sig(`(?i)^\{(MD5-CRYPT|SHA256-CRYPT|SHA512-CRYPT|BLF-CRYPT|ARGON2I|ARGON2ID|PBKDF2)\}`, Candidate{Algorithm: "dovecot", Variant: "crypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "dovecot.RegisterDecoder"}),
+	sig(`(?i)^\{(SHA|SSHA|SHA256|SSHA256|SHA512|SSHA512|CRAM-MD5)(\.(HEX|B64|BASE64))?\}`, Candidate{Algorithm: "dovecot", Variant: "binary", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "dovecot.RegisterDecoder"}),
+	sig(`^\{(bcrypt|noop|pbkdf2|scrypt|argon2|sha256|MD5|SHA-1|SHA-256)(@SpringSecurity_v5_8)?\}`, Candidate{Algorithm: "spring", Variant: "delegating", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "spring.RegisterDecoder"}),
+	sig(`^\$[PH]\$`+reCrypt64+`{31}$`, Candidate{Algorithm: "phpass", Variant: "portable", Confidence: ConfidenceHigh, Note: "phpass portable digests are not supported"}),
+	sig(`^pbkdf2_sha(1|256)\$\d+\$`, Candidate{Algorithm: "django", Variant: "pbkdf2", Confidence: ConfidenceHigh, Note: "Django pbkdf2 digests are not supported directly but can be converted to the pbkdf2 encoding"}),
+	sig(`^_`+reCrypt64+`{19}$`, Candidate{Algorithm: "descrypt", Variant: "extended", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "descrypt.RegisterDecoderExtended"}),
+	sig(`^`+reCrypt64+`{13}$`, Candidate{Algorithm: "descrypt", Variant: "standard", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "descrypt.RegisterDecoderStandard"}),
+	sig(`^[^:]+:[^:]+:[0-9a-f]{32}$`, Candidate{Algorithm: "htdigest", Variant: "md5", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "htdigest.RegisterDecoder"}),
+	sig(`^A[A-P]`+reBase64+`{64}(==)?$`, Candidate{Algorithm: "aspnet", Variant: "v2", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"}),
+	sig(`^AQAAAA[A-I]`+reBase64Pd+`$`, Candidate{Algorithm: "aspnet", Variant: "v3", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"}),
+	sig(`^`+reHex+`{16}$`, Candidate{Algorithm: "mysql", Variant: "old_password", Confidence: ConfidenceLow, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoderOldPassword"}),
+	sig(`^`+reHex+`{32}$`, Candidate{Algorithm: "md5", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the md5($pass) recipe"}),
+	sig(`^`+reHex+`{32}$`, Candidate{Algorithm: "nthash", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, RegisterDecoder: "nthash.RegisterDecoderRaw"}),
+	sig(`^`+reHex+`{32}$`, Candidate{Algorithm: "lm", Variant: "raw", Confidence: ConfidenceLow, Note: "LAN Manager digests are not supported"}),
+	sig(`^`+reHex+`{40}$`, Candidate{Algorithm: "sha1", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the sha1($pass) recipe"}),
+	sig(`^`+reHex+`{40}$`, Candidate{Algorithm: "mysql", Variant: "native", Confidence: ConfidenceLow, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder", Note: "the encoded digest must be prefixed with '*' to be decoded"}),
+	sig(`^`+reHex+`{56}$`, Candidate{Algorithm: "sha224", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the sha224($pass) recipe"}),
+	sig(`^`+reHex+`{64}$`, Candidate{Algorithm: "sha256", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the sha256($pass) recipe"}),
+	sig(`^`+reHex+`{96}$`, Candidate{Algorithm: "sha384", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the sha384($pass) recipe"}),
+	sig(`^`+reHex+`{128}$`, Candidate{Algorithm: "sha512", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the sha512($pass) recipe"}),
+}
+
+func sig(pattern string, candidate Candidate) signature {
+	return signature{pattern: regexp.MustCompile(pattern), candidate: candidate}
+}
+
+// sigBinary is the same as sig except the encoded digest may contain CR and LF such as the raw salt of the MySQL
+// caching_sha2_password digests.
+func sigBinary(pattern string, candidate Candidate) signature {
+	return signature{pattern: regexp.MustCompile(pattern), candidate: candidate, binary: true}
+}
+
+// Identify inspects the structure, identifier, alphabet, and lengths of an encoded digest and returns the candidate
+// algorithms and variants which may have produced it, ranked from the most to the least likely. Candidates are
+// returned for every algorithm known to this module, including ones which are not registered with the default
+// decoders, as well as some well known algorithms which this module is unable to verify. Encoded digests stored in
+// one of the default formats such as the OpenLDAP {CRYPT} storage format are identified by the encoded digest they
+// contain.
+func Identify(encoded string) (candidates []Candidate) {
+	candidates = identify(encoded, "")
+
+	for _, format := range formatsDefault() {
+		if encodedDigest, ok := format.Normalize(encoded); ok {
+			candidates = append(candidates, identify(encodedDigest, format.Name())...)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	return candidates
+}
+
+func identify(encoded, format string) (candidates []Candidate) {
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	newline := strings.ContainsAny(encoded, "\r\n")
+
+	for _, s := range signatures {
+		if newline && !s.binary || !s.pattern.MatchString(encoded) {
+			continue
+		}
+
+		candidate := s.candidate
+		candidate.Format = format
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
@@ -0,0 +1,266 @@
+package crypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/descrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/algorithm/sha1crypt"
+	"github.com/go-crypt/crypt/algorithm/shacrypt"
+)
+
+func TestIdentify(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected []Candidate
+	}{
+		{
+			"ShouldIdentifyArgon2id",
+			encodedArgon2id,
+			[]Candidate{
+				{Algorithm: "argon2", Variant: "argon2id", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "argon2.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyArgon2idLDAP",
+			"{ARGON2}" + encodedArgon2id,
+			[]Candidate{
+				{Algorithm: "argon2", Variant: "argon2id", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "argon2.RegisterDecoder", Format: FormatNameLDAPArgon2},
+			},
+		},
+		{
+			"ShouldIdentifyBcryptSHA256",
+			"$bcrypt-sha256$2a,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+			[]Candidate{
+				{Algorithm: "bcrypt", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "bcrypt.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyYescrypt",
+			"$y$j9T$AAt9R641xPvCI9nXw1HHW/$cuQRBMN3N/f8IcmVN.4YrZ1bHMOiLOoz9/XQMKV/v0A",
+			[]Candidate{
+				{Algorithm: "scrypt", Variant: "yescrypt", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scrypt.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyMySQLNative",
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+			[]Candidate{
+				{Algorithm: "mysql", Variant: "native", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyMySQLOldPassword",
+			"5d2e19393cc5ef67",
+			[]Candidate{
+				{Algorithm: "mysql", Variant: "old_password", Confidence: ConfidenceLow, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoderOldPassword"},
+			},
+		},
+		{
+			"ShouldIdentifyPostgreSQLMD5",
+			"md532e12f215ba27cb750c9e093ce4b5127",
+			[]Candidate{
+				{Algorithm: "pgmd5", Variant: "md5", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "pgmd5.RegisterDecoder", Note: "matching requires the username via a MatchContext"},
+			},
+		},
+		{
+			"ShouldIdentifyHTDigest",
+			"Mufasa:testrealm@host.com:939e7578ed9e3c518a452acee763bce9",
+			[]Candidate{
+				{Algorithm: "htdigest", Variant: "md5", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "htdigest.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyNTHashFreeBSD",
+			"$3$$8846f7eaee8fb117ad06bdd830b7586c",
+			[]Candidate{
+				{Algorithm: "nthash", Variant: "freebsd", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "nthash.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyRawHex128Bit",
+			"8846f7eaee8fb117ad06bdd830b7586c",
+			[]Candidate{
+				{Algorithm: "md5", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, Note: "can be verified with legacy.NewDigest using the md5($pass) recipe"},
+				{Algorithm: "nthash", Variant: "raw", Confidence: ConfidenceLow, Verifiable: true, RegisterDecoder: "nthash.RegisterDecoderRaw"},
+				{Algorithm: "lm", Variant: "raw", Confidence: ConfidenceLow, Note: "LAN Manager digests are not supported"},
+			},
+		},
+		{
+			"ShouldIdentifySCRAM",
+			"SCRAM-SHA-256$4096:c2FsdA==$c3RvcmVk:c2VydmVy",
+			[]Candidate{
+				{Algorithm: "scram", Variant: "sha256", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "scram.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyDovecot",
+			"{SSHA256.b64}IS+u+mYD3Bt8ALFAaRHWXCRaDgAhBNrT5o4YCqeyOqTvCXN6",
+			[]Candidate{
+				{Algorithm: "dovecot", Variant: "binary", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "dovecot.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifySpring",
+			"{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG",
+			[]Candidate{
+				{Algorithm: "spring", Variant: "delegating", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "spring.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyAtlassian",
+			"{PKCS5S2}l8uGSaIOKWbuAuH1wY4V6V40oJ8SSX2nq0fBvfvWrfRsTqmEG2BoZCqwxiqdF+Qy",
+			[]Candidate{
+				{Algorithm: "atlassian", Variant: "pkcs5s2", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "atlassian.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyASPNETV3",
+			"AQAAAAAAACcQAAAAEAABAgMEBQYHCAkKCwwNDg+OPi9zw+tjkKgau8gQHANDsBenr//7WrZeE08JCdzKLA==",
+			[]Candidate{
+				{Algorithm: "aspnet", Variant: "v3", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyASPNETV3SHA256",
+			"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg/rbIFTVZIgPAkrFY+NOQlnI2Km9dvQDZgoBEy6qLJS6Q==",
+			[]Candidate{
+				{Algorithm: "aspnet", Variant: "v3", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyASPNETV3SHA512",
+			"AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w==",
+			[]Candidate{
+				{Algorithm: "aspnet", Variant: "v3", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyASPNETV2",
+			"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==",
+			[]Candidate{
+				{Algorithm: "aspnet", Variant: "v2", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyASPNETV2Unpadded",
+			"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ",
+			[]Candidate{
+				{Algorithm: "aspnet", Variant: "v2", Confidence: ConfidenceMedium, Verifiable: true, RegisterDecoder: "aspnet.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldNotIdentifySCRAMMongoDB",
+			"SCRAM-SHA-1:mongo:c2FsdA==",
+			nil,
+		},
+		{
+			"ShouldIdentifyUnsupportedAPR1",
+			"$apr1$salt$Xxd1irWT9ycqoYxGFn4cb.",
+			[]Candidate{
+				{Algorithm: "apr1", Variant: "apache", Confidence: ConfidenceHigh, Note: "the Apache APR1 MD5 variant of md5crypt is not supported"},
+			},
+		},
+		{
+			"ShouldRankHighConfidenceFirst",
+			"{CRYPT}$1$salt$0NlY3dbYl1ONTKr7s7vLf.",
+			[]Candidate{
+				{Algorithm: "md5crypt", Variant: "standard", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "md5crypt.RegisterDecoder", Format: FormatNameLDAPCrypt},
+			},
+		},
+		{
+			"ShouldIdentifyMySQLCachingSHA2",
+			"$A$005$" + "\x1b6\x7f\x1e\x02&\x14\x10\x7f\x1b\x05%Zj\x11C&\x19l@" + "bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/",
+			[]Candidate{
+				{Algorithm: "mysql", Variant: "caching_sha2", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldIdentifyMySQLCachingSHA2SaltNewline",
+			"$A$005$" + "\x1b6\x7f\n\x02&\x14\r\x7f\x1b\x05%Zj\x11C&\x19l@" + "bTy95Y99eAME1dwEkHOA1ndHGBWz.1bxSSRkuTXFGV/",
+			[]Candidate{
+				{Algorithm: "mysql", Variant: "caching_sha2", Confidence: ConfidenceHigh, Verifiable: true, RegisterDecoder: "mysql.RegisterDecoder"},
+			},
+		},
+		{
+			"ShouldNotIdentifyNewline",
+			"$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/\n",
+			nil,
+		},
+		{
+			"ShouldNotIdentifyEmpty",
+			"",
+			nil,
+		},
+		{
+			"ShouldNotIdentifyUnknown",
+			"not a digest",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Identify(tc.have))
+		})
+	}
+}
+
+func TestIdentifyHashers(t *testing.T) {
+	testCases := []struct {
+		name      string
+		hasher    func() (algorithm.Hash, error)
+		algorithm string
+		variant   string
+	}{
+		{"ShouldIdentifyArgon2", func() (algorithm.Hash, error) { return argon2.New(argon2.WithM(8192), argon2.WithT(1)) }, "argon2", "argon2id"},
+		{"ShouldIdentifyBcrypt", func() (algorithm.Hash, error) { return bcrypt.New(bcrypt.WithCost(10)) }, "bcrypt", "standard"},
+		{"ShouldIdentifyBcryptSHA512", func() (algorithm.Hash, error) { return bcrypt.NewSHA512(bcrypt.WithCost(10)) }, "bcrypt", "sha512"},
+		{"ShouldIdentifyPBKDF2", func() (algorithm.Hash, error) { return pbkdf2.NewSHA256() }, "pbkdf2", "sha256"},
+		{"ShouldIdentifyScrypt", func() (algorithm.Hash, error) { return scrypt.New() }, "scrypt", "scrypt"},
+		{"ShouldIdentifyYescrypt", func() (algorithm.Hash, error) { return scrypt.NewYescrypt() }, "scrypt", "yescrypt"},
+		{"ShouldIdentifySHA256Crypt", func() (algorithm.Hash, error) { return shacrypt.NewSHA256() }, "shacrypt", "sha256"},
+		{"ShouldIdentifySHA512Crypt", func() (algorithm.Hash, error) { return shacrypt.NewSHA512() }, "shacrypt", "sha512"},
+		{"ShouldIdentifyMD5Crypt", func() (algorithm.Hash, error) { return md5crypt.New() }, "md5crypt", "standard"},
+		{"ShouldIdentifyMD5CryptSun", func() (algorithm.Hash, error) {
+			return md5crypt.New(md5crypt.WithVariant(md5crypt.VariantSun), md5crypt.WithRounds(1000))
+		}, "md5crypt", "sun"},
+		{"ShouldIdentifySHA1Crypt", func() (algorithm.Hash, error) { return sha1crypt.New() }, "sha1crypt", "standard"},
+		{"ShouldIdentifyDESCrypt", func() (algorithm.Hash, error) { return descrypt.New() }, "descrypt", "standard"},
+		{"ShouldIdentifyNTHash", func() (algorithm.Hash, error) { return nthash.New() }, "nthash", "freebsd"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := tc.hasher()
+			require.NoError(t, err)
+
+			digest, err := hasher.Hash(password)
+			require.NoError(t, err)
+
+			candidates := Identify(digest.Encode())
+			require.NotEmpty(t, candidates)
+
+			assert.Equal(t, tc.algorithm, candidates[0].Algorithm)
+			assert.Equal(t, tc.variant, candidates[0].Variant)
+			assert.True(t, candidates[0].Verifiable)
+		})
+	}
+}
+
+func TestConfidenceString(t *testing.T) {
+	assert.Equal(t, "low", ConfidenceLow.String())
+	assert.Equal(t, "medium", ConfidenceMedium.String())
+	assert.Equal(t, "high", ConfidenceHigh.String())
+	assert.Equal(t, "", Confidence(0).String())
+}
@@ -0,0 +1,327 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+)
+
+const (
+	auth0AlgorithmArgon2 = "argon2"
+	auth0AlgorithmBcrypt = "bcrypt"
+	auth0AlgorithmPBKDF2 = "pbkdf2"
+	auth0AlgorithmScrypt = "scrypt"
+
+	auth0EncodingUTF8   = "utf8"
+	auth0EncodingHex    = "hex"
+	auth0EncodingBase64 = "base64"
+
+	auth0PositionPrefix = "prefix"
+	auth0PositionSuffix = "suffix"
+
+	auth0ScryptCostDefault            = 16384
+	auth0ScryptBlockSizeDefault       = 8
+	auth0ScryptParallelizationDefault = 1
+)
+
+// Auth0PasswordHash is the custom_password_hash object of an Auth0 bulk user import. The argon2, bcrypt, pbkdf2,
+// scrypt, md5, sha1, sha256, and sha512 algorithms are supported.
+type Auth0PasswordHash struct {
+	Algorithm       string         `json:"algorithm"`
+	Hash            Auth0Hash      `json:"hash"`
+	Salt            *Auth0Salt     `json:"salt,omitempty"`
+	Password        *Auth0Password `json:"password,omitempty"`
+	KeyLen          int            `json:"keylen,omitempty"`
+	Cost            int            `json:"cost,omitempty"`
+	BlockSize       int            `json:"blockSize,omitempty"`
+	Parallelization int            `json:"parallelization,omitempty"`
+}
+
+// Auth0Hash is the hash object of an Auth0PasswordHash.
+type Auth0Hash struct {
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+	Digest   string `json:"digest,omitempty"`
+}
+
+// Auth0Salt is the salt object of an Auth0PasswordHash.
+type Auth0Salt struct {
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+	Position string `json:"position,omitempty"`
+}
+
+// Auth0Password is the password object of an Auth0PasswordHash.
+type Auth0Password struct {
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Digest converts the Auth0PasswordHash into an algorithm.Digest.
+func (h Auth0PasswordHash) Digest() (digest algorithm.Digest, err error) {
+	if h.Password != nil && h.Password.Encoding != "" && h.Password.Encoding != auth0EncodingUTF8 {
+		return nil, fmt.Errorf(errFmtImport, NameAuth0, fmt.Errorf("%w: password encoding '%s' is not supported", ErrUnsupported, h.Password.Encoding))
+	}
+
+	switch h.Algorithm {
+	case auth0AlgorithmArgon2:
+		digest, err = h.phc(argon2.Decode)
+	case auth0AlgorithmBcrypt:
+		digest, err = h.phc(bcrypt.Decode)
+	case auth0AlgorithmPBKDF2:
+		digest, err = h.pbkdf2()
+	case auth0AlgorithmScrypt:
+		digest, err = h.scrypt()
+	case legacyMD5, legacySHA1, legacySHA256, legacySHA512:
+		digest, err = h.legacy()
+	default:
+		return nil, fmt.Errorf(errFmtImport, NameAuth0, fmt.Errorf("%w: algorithm '%s' is unknown", ErrUnsupported, h.Algorithm))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameAuth0, err)
+	}
+
+	return digest, nil
+}
+
+func (h Auth0PasswordHash) phc(decode algorithm.DecodeFunc) (digest algorithm.Digest, err error) {
+	if h.Hash.Encoding != "" && h.Hash.Encoding != auth0EncodingUTF8 {
+		return nil, fmt.Errorf("%w: hash encoding '%s' is not supported by the %s algorithm", ErrUnsupported, h.Hash.Encoding, h.Algorithm)
+	}
+
+	return decode(h.Hash.Value)
+}
+
+func (h Auth0PasswordHash) pbkdf2() (digest algorithm.Digest, err error) {
+	if h.Hash.Encoding != "" && h.Hash.Encoding != auth0EncodingUTF8 {
+		return nil, fmt.Errorf("%w: hash encoding '%s' is not supported by the %s algorithm", ErrUnsupported, h.Hash.Encoding, h.Algorithm)
+	}
+
+	parts := strings.Split(h.Hash.Value, "$")
+
+	if len(parts) != 5 || parts[0] != "" || !strings.HasPrefix(parts[1], "pbkdf2-") {
+		return nil, fmt.Errorf("%w: hash value is not a pbkdf2 PHC string", ErrInvalid)
+	}
+
+	variant := pbkdf2.NewVariant(strings.TrimPrefix(parts[1], "pbkdf2-"))
+
+	if variant == pbkdf2.VariantNone {
+		return nil, fmt.Errorf("%w: pbkdf2 digest '%s' is unknown", ErrUnsupported, strings.TrimPrefix(parts[1], "pbkdf2-"))
+	}
+
+	var iterations, length = -1, -1
+
+	for _, param := range strings.Split(parts[2], ",") {
+		k, v, _ := strings.Cut(param, "=")
+
+		switch k {
+		case "i":
+			iterations, err = strconv.Atoi(v)
+		case "l":
+			length, err = strconv.Atoi(v)
+		default:
+			return nil, fmt.Errorf("%w: pbkdf2 parameter '%s' is unknown", ErrInvalid, k)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: pbkdf2 parameter '%s' has invalid value '%s'", ErrInvalid, k, v)
+		}
+	}
+
+	var salt, key []byte
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return nil, fmt.Errorf("%w: salt could not be decoded: %v", ErrInvalid, err)
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("%w: hash could not be decoded: %v", ErrInvalid, err)
+	}
+
+	if length != -1 && length != len(key) {
+		return nil, fmt.Errorf("%w: pbkdf2 key length is %d but the hash has %d bytes", ErrInvalid, length, len(key))
+	}
+
+	return pbkdf2.NewDigest(variant, iterations, salt, key)
+}
+
+func (h Auth0PasswordHash) scrypt() (digest algorithm.Digest, err error) {
+	var salt, key []byte
+
+	if key, err = auth0Decode(h.Hash.Value, h.Hash.Encoding, auth0EncodingHex); err != nil {
+		return nil, err
+	}
+
+	if h.Salt != nil {
+		if h.Salt.Position != "" {
+			return nil, fmt.Errorf("%w: salt position is not supported by the %s algorithm", ErrUnsupported, h.Algorithm)
+		}
+
+		if salt, err = auth0Decode(h.Salt.Value, h.Salt.Encoding, auth0EncodingUTF8); err != nil {
+			return nil, err
+		}
+	}
+
+	if h.KeyLen != 0 && h.KeyLen != len(key) {
+		return nil, fmt.Errorf("%w: scrypt key length is %d but the hash has %d bytes", ErrInvalid, h.KeyLen, len(key))
+	}
+
+	cost, r, p := auth0Default(h.Cost, auth0ScryptCostDefault), auth0Default(h.BlockSize, auth0ScryptBlockSizeDefault), auth0Default(h.Parallelization, auth0ScryptParallelizationDefault)
+
+	if cost < 2 || cost&(cost-1) != 0 {
+		return nil, fmt.Errorf("%w: scrypt cost must be a power of 2 greater than 1 but is %d", ErrInvalid, cost)
+	}
+
+	return scrypt.NewDigest(scrypt.VariantScrypt, bits.TrailingZeros(uint(cost)), r, p, salt, key)
+}
+
+func (h Auth0PasswordHash) legacy() (digest algorithm.Digest, err error) {
+	var salt, key []byte
+
+	if key, err = auth0Decode(h.Hash.Value, h.Hash.Encoding, auth0EncodingHex); err != nil {
+		return nil, err
+	}
+
+	prefix := false
+
+	if h.Salt != nil {
+		if salt, err = auth0Decode(h.Salt.Value, h.Salt.Encoding, auth0EncodingUTF8); err != nil {
+			return nil, err
+		}
+
+		// The position defaults to prefix when it's omitted.
+		switch h.Salt.Position {
+		case "", auth0PositionPrefix:
+			prefix = true
+		case auth0PositionSuffix:
+			prefix = false
+		default:
+			return nil, fmt.Errorf("%w: salt position '%s' is unknown", ErrInvalid, h.Salt.Position)
+		}
+	}
+
+	return newLegacyDigest(h.Algorithm, salt, key, prefix)
+}
+
+// NewAuth0PasswordHash converts the algorithm.Digest into an Auth0PasswordHash. The supported digests are the
+// argon2.Digest, the standard variant of the bcrypt.Digest, the pbkdf2.Digest, the scrypt.Digest using the scrypt
+// variant, and the legacy.Digest values created from an Auth0PasswordHash.
+func NewAuth0PasswordHash(digest algorithm.Digest) (hash Auth0PasswordHash, err error) {
+	switch d := digest.(type) {
+	case *argon2.Digest:
+		if len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("%w: argon2 digests must not use the keyid or data parameters", ErrUnsupported))
+		}
+
+		hash.Algorithm, hash.Hash = auth0AlgorithmArgon2, Auth0Hash{Value: d.Canonical().Encode(), Encoding: auth0EncodingUTF8}
+	case *bcrypt.Digest:
+		if d.Variant() != bcrypt.VariantStandard {
+			return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("%w: bcrypt digests must use the standard variant", ErrUnsupported))
+		}
+
+		hash.Algorithm, hash.Hash = auth0AlgorithmBcrypt, Auth0Hash{Value: d.Canonical().Encode(), Encoding: auth0EncodingUTF8}
+	case *pbkdf2.Digest:
+		hash.Algorithm = auth0AlgorithmPBKDF2
+		hash.Hash = Auth0Hash{
+			Value: fmt.Sprintf("$pbkdf2-%s$i=%d,l=%d$%s$%s", d.Variant(), d.Iterations(), len(d.Key()),
+				base64.RawStdEncoding.EncodeToString(d.Salt()), base64.RawStdEncoding.EncodeToString(d.Key())),
+			Encoding: auth0EncodingUTF8,
+		}
+	case *scrypt.Digest:
+		if d.Variant() != scrypt.VariantScrypt {
+			return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("%w: scrypt digests must use the scrypt variant", ErrUnsupported))
+		}
+
+		hash.Algorithm = auth0AlgorithmScrypt
+		hash.Hash = Auth0Hash{Value: hex.EncodeToString(d.Key()), Encoding: auth0EncodingHex}
+		hash.Salt = &Auth0Salt{Value: base64.StdEncoding.EncodeToString(d.Salt()), Encoding: auth0EncodingBase64}
+		hash.KeyLen, hash.Cost, hash.BlockSize, hash.Parallelization = len(d.Key()), 1<<d.LN(), d.R(), d.P()
+	case *legacy.Digest:
+		name, prefix, ok := parseLegacyDigest(d)
+
+		if !ok {
+			return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("%w: legacy digests using the '%s' recipe are not supported", ErrUnsupported, d.Recipe()))
+		}
+
+		hash.Algorithm = name
+		hash.Hash = Auth0Hash{Value: hex.EncodeToString(d.Key()), Encoding: auth0EncodingHex}
+
+		if len(d.Salt()) != 0 {
+			hash.Salt = &Auth0Salt{Value: base64.StdEncoding.EncodeToString(d.Salt()), Encoding: auth0EncodingBase64, Position: auth0PositionSuffix}
+
+			if prefix {
+				hash.Salt.Position = auth0PositionPrefix
+			}
+		}
+	case nil:
+		return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("can't export a nil digest"))
+	default:
+		return hash, fmt.Errorf(errFmtExport, NameAuth0, fmt.Errorf("%w: digests of type '%T' are not supported", ErrUnsupported, digest))
+	}
+
+	return hash, nil
+}
+
+// DecodeAuth0 converts the JSON encoded Auth0PasswordHash into an algorithm.Digest.
+func DecodeAuth0(data []byte) (digest algorithm.Digest, err error) {
+	var hash Auth0PasswordHash
+
+	if err = json.Unmarshal(data, &hash); err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameAuth0, fmt.Errorf("%w: %v", ErrInvalid, err))
+	}
+
+	return hash.Digest()
+}
+
+// EncodeAuth0 converts the algorithm.Digest into a JSON encoded Auth0PasswordHash.
+func EncodeAuth0(digest algorithm.Digest) (data []byte, err error) {
+	var hash Auth0PasswordHash
+
+	if hash, err = NewAuth0PasswordHash(digest); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(hash)
+}
+
+func auth0Decode(value, encoding, fallback string) (decoded []byte, err error) {
+	if encoding == "" {
+		encoding = fallback
+	}
+
+	switch encoding {
+	case auth0EncodingUTF8:
+		return []byte(value), nil
+	case auth0EncodingHex:
+		decoded, err = hex.DecodeString(value)
+	case auth0EncodingBase64:
+		decoded, err = base64.StdEncoding.DecodeString(value)
+	default:
+		return nil, fmt.Errorf("%w: encoding '%s' is unknown", ErrUnsupported, encoding)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: value could not be decoded using the %s encoding: %v", ErrInvalid, encoding, err)
+	}
+
+	return decoded, nil
+}
+
+func auth0Default(value, fallback int) int {
+	if value == 0 {
+		return fallback
+	}
+
+	return value
+}
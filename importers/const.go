@@ -0,0 +1,12 @@
+package importers
+
+const (
+	// NameKeycloak is the name of the Keycloak identity provider.
+	NameKeycloak = "keycloak"
+
+	// NameAuth0 is the name of the Auth0 identity provider.
+	NameAuth0 = "auth0"
+
+	// NameOkta is the name of the Okta identity provider.
+	NameOkta = "okta"
+)
@@ -0,0 +1,12 @@
+// Package importers converts the password credentials exported by identity providers such as Keycloak, Auth0, and
+// Okta into the equivalent algorithm.Digest implementations and converts those digests back into the credential
+// formats, allowing credentials to be imported from and exported to these identity providers losslessly.
+//
+// Each identity provider has a credential type which mirrors its JSON representation, a method which converts the
+// credential into an algorithm.Digest, a function which converts an algorithm.Digest into the credential, and a pair of
+// functions which perform the same conversions on the JSON encoded form. Credentials and digests which can't be
+// converted return an error wrapping importers.ErrUnsupported.
+//
+// The salted SHA-2 and MD5 credentials of Auth0 and Okta are converted into legacy.Digest values with the recipes
+// raw(<hash>($salt.$pass)) or raw(<hash>($pass.$salt)) depending on the position of the salt.
+package importers
@@ -0,0 +1,18 @@
+package importers
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnsupported is returned when a credential or digest can't be converted.
+	ErrUnsupported = errors.New("the credential is not supported")
+
+	// ErrInvalid is returned when a credential is malformed.
+	ErrInvalid = errors.New("the credential is invalid")
+)
+
+const (
+	errFmtImport = "%s import error: %w"
+	errFmtExport = "%s export error: %w"
+)
@@ -0,0 +1,302 @@
+package importers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/nthash"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+type (
+	decodeFunc func(data []byte) (digest algorithm.Digest, err error)
+	encodeFunc func(digest algorithm.Digest) (data []byte, err error)
+)
+
+func TestImportExport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		decode   decodeFunc
+		encode   encodeFunc
+		have     string
+		expected string
+		digest   string
+		err      string
+	}{
+		{
+			"ShouldImportKeycloakPBKDF2SHA256",
+			DecodeKeycloak,
+			EncodeKeycloak,
+			`{"algorithm":"pbkdf2-sha256","hashIterations":27500,"salt":"c2FsdHNhbHRzYWx0c2FsdA==","value":"FLAKrylANDgDgtPBQiUvp4/v52EpM6tnb3b2ZEP26EQ="}`,
+			"",
+			"$pbkdf2-sha256$27500$c2FsdHNhbHRzYWx0c2FsdA$FLAKrylANDgDgtPBQiUvp4/v52EpM6tnb3b2ZEP26EQ",
+			"",
+		},
+		{
+			"ShouldImportAuth0PBKDF2",
+			DecodeAuth0,
+			EncodeAuth0,
+			`{"algorithm":"pbkdf2","hash":{"value":"$pbkdf2-sha256$i=27500,l=32$c2FsdHNhbHRzYWx0c2FsdA$FLAKrylANDgDgtPBQiUvp4/v52EpM6tnb3b2ZEP26EQ","encoding":"utf8"}}`,
+			"",
+			"$pbkdf2-sha256$27500$c2FsdHNhbHRzYWx0c2FsdA$FLAKrylANDgDgtPBQiUvp4/v52EpM6tnb3b2ZEP26EQ",
+			"",
+		},
+		{
+			"ShouldImportAuth0Scrypt",
+			DecodeAuth0,
+			EncodeAuth0,
+			`{"algorithm":"scrypt","hash":{"value":"00e2d710448270f99fd83c54dc3e3b649c69e594dc1c2d12d8c6f67855dce2d2","encoding":"hex"},"salt":{"value":"c2FsdHNhbHQ=","encoding":"base64"},"keylen":32,"cost":1024,"blockSize":8,"parallelization":1}`,
+			"",
+			"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHQ$AOLXEESCcPmf2DxU3D47ZJxp5ZTcHC0S2Mb2eFXc4tI",
+			"",
+		},
+		{
+			"ShouldImportAuth0MD5",
+			DecodeAuth0,
+			EncodeAuth0,
+			`{"algorithm":"md5","hash":{"value":"5f4dcc3b5aa765d61d8327deb882cf99","encoding":"hex"}}`,
+			"",
+			"",
+			"",
+		},
+		{
+			"ShouldImportAuth0SHA256SaltPrefixUTF8",
+			DecodeAuth0,
+			EncodeAuth0,
+			`{"algorithm":"sha256","hash":{"value":"E2Ab2k6njlWge5iGbSvmvgdE44ZvE8AMgRyrYIoo8yI=","encoding":"base64"},"salt":{"value":"salt","encoding":"utf8","position":"prefix"}}`,
+			`{"algorithm":"sha256","hash":{"value":"13601bda4ea78e55a07b98866d2be6be0744e3866f13c00c811cab608a28f322","encoding":"hex"},"salt":{"value":"c2FsdA==","encoding":"base64","position":"prefix"}}`,
+			"",
+			"",
+		},
+		{
+			"ShouldImportAuth0SHA256SaltPositionDefault",
+			DecodeAuth0,
+			EncodeAuth0,
+			`{"algorithm":"sha256","hash":{"value":"E2Ab2k6njlWge5iGbSvmvgdE44ZvE8AMgRyrYIoo8yI=","encoding":"base64"},"salt":{"value":"salt","encoding":"utf8"}}`,
+			`{"algorithm":"sha256","hash":{"value":"13601bda4ea78e55a07b98866d2be6be0744e3866f13c00c811cab608a28f322","encoding":"hex"},"salt":{"value":"c2FsdA==","encoding":"base64","position":"prefix"}}`,
+			"",
+			"",
+		},
+		{
+			"ShouldImportOktaSHA256",
+			DecodeOkta,
+			EncodeOkta,
+			`{"algorithm":"SHA-256","salt":"c2FsdA==","saltOrder":"PREFIX","value":"E2Ab2k6njlWge5iGbSvmvgdE44ZvE8AMgRyrYIoo8yI="}`,
+			"",
+			"",
+			"",
+		},
+		{
+			"ShouldImportOktaPBKDF2",
+			DecodeOkta,
+			EncodeOkta,
+			`{"algorithm":"PBKDF2","iterationCount":1000,"keySize":20,"digestAlgorithm":"SHA1_HMAC","salt":"c2FsdHNhbHRzYWx0c2FsdA==","value":"2FWw/oC7TQkskizC+81lWlmFAMM="}`,
+			"",
+			"$pbkdf2$1000$c2FsdHNhbHRzYWx0c2FsdA$2FWw/oC7TQkskizC.81lWlmFAMM",
+			"",
+		},
+		{
+			"ShouldNotImportKeycloakUnknownAlgorithm",
+			DecodeKeycloak,
+			nil,
+			`{"algorithm":"bcrypt","hashIterations":10,"salt":"","value":""}`,
+			"",
+			"",
+			"keycloak import error: the credential is not supported: algorithm 'bcrypt' is unknown",
+		},
+		{
+			"ShouldNotImportKeycloakBadArgon2HashLength",
+			DecodeKeycloak,
+			nil,
+			`{"algorithm":"argon2","hashIterations":1,"salt":"c2FsdA==","value":"c2FsdA==","additionalParameters":{"hashLength":["32"],"memory":["8192"],"parallelism":["1"],"type":["id"],"version":["1.3"]}}`,
+			"",
+			"",
+			"keycloak import error: the credential is invalid: argon2 hash length is 32 but the value has 4 bytes",
+		},
+		{
+			"ShouldNotImportKeycloakZeroIterations",
+			DecodeKeycloak,
+			nil,
+			`{"algorithm":"pbkdf2","hashIterations":0,"salt":"c2FsdA==","value":"c2FsdA=="}`,
+			"",
+			"",
+			"keycloak import error: pbkdf2 decode error: parameter is invalid: parameter 'iterations' must be between 1 and 2147483647 but is set to '0'",
+		},
+		{
+			"ShouldNotImportAuth0PasswordEncoding",
+			DecodeAuth0,
+			nil,
+			`{"algorithm":"md5","hash":{"value":"5f4dcc3b5aa765d61d8327deb882cf99"},"password":{"encoding":"utf16le"}}`,
+			"",
+			"",
+			"auth0 import error: the credential is not supported: password encoding 'utf16le' is not supported",
+		},
+		{
+			"ShouldNotImportAuth0SaltPositionUnknown",
+			DecodeAuth0,
+			nil,
+			`{"algorithm":"sha256","hash":{"value":"E2Ab2k6njlWge5iGbSvmvgdE44ZvE8AMgRyrYIoo8yI=","encoding":"base64"},"salt":{"value":"salt","encoding":"utf8","position":"middle"}}`,
+			"",
+			"",
+			"auth0 import error: the credential is invalid: salt position 'middle' is unknown",
+		},
+		{
+			"ShouldNotImportAuth0ScryptCost",
+			DecodeAuth0,
+			nil,
+			`{"algorithm":"scrypt","hash":{"value":"00"},"cost":1000}`,
+			"",
+			"",
+			"auth0 import error: the credential is invalid: scrypt cost must be a power of 2 greater than 1 but is 1000",
+		},
+		{
+			"ShouldNotImportOktaSaltOrder",
+			DecodeOkta,
+			nil,
+			`{"algorithm":"MD5","salt":"c2FsdA==","value":"X03MO1qnZdYdgyfeuILPmQ=="}`,
+			"",
+			"",
+			"okta import error: the credential is invalid: salt order '' is unknown",
+		},
+		{
+			"ShouldNotImportOktaBcryptSalt",
+			DecodeOkta,
+			nil,
+			`{"algorithm":"BCRYPT","workFactor":10,"salt":"abc","value":"qaMqvAPULkbiQzkTCWo5XDcvzpk8Tna"}`,
+			"",
+			"",
+			"okta import error: the credential is invalid: salt is expected to be 22 characters but it has 3 characters",
+		},
+		{
+			"ShouldNotImportMalformedJSON",
+			DecodeOkta,
+			nil,
+			`{"algorithm":`,
+			"",
+			"",
+			"okta import error: the credential is invalid: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := tc.decode([]byte(tc.have))
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, digest)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, digest)
+
+			assert.True(t, digest.Match("password"))
+			assert.False(t, digest.Match("notpassword"))
+
+			if tc.digest != "" {
+				assert.Equal(t, tc.digest, digest.Encode())
+			}
+
+			expected := tc.expected
+
+			if expected == "" {
+				expected = tc.have
+			}
+
+			data, err := tc.encode(digest)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, string(data))
+		})
+	}
+}
+
+func TestRoundTripHashers(t *testing.T) {
+	argon2id, err := argon2.New(argon2.WithVariantName("argon2id"), argon2.WithM(8192), argon2.WithT(1), argon2.WithP(1))
+	require.NoError(t, err)
+
+	argon2i, err := argon2.New(argon2.WithVariantName("argon2i"), argon2.WithM(8192), argon2.WithT(1), argon2.WithP(1))
+	require.NoError(t, err)
+
+	bcryptStandard, err := bcrypt.New(bcrypt.WithCost(10))
+	require.NoError(t, err)
+
+	pbkdf2SHA512, err := pbkdf2.NewSHA512()
+	require.NoError(t, err)
+
+	md5SaltSuffix, err := legacy.New(legacy.WithRecipe("raw(md5($pass.$salt))"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		hasher   algorithm.Hash
+		keycloak bool
+		auth0    bool
+		okta     bool
+	}{
+		{"ShouldRoundTripArgon2id", argon2id, true, true, false},
+		{"ShouldRoundTripArgon2i", argon2i, true, true, false},
+		{"ShouldRoundTripBcrypt", bcryptStandard, false, true, true},
+		{"ShouldRoundTripPBKDF2SHA512", pbkdf2SHA512, true, true, true},
+		{"ShouldRoundTripLegacyMD5SaltSuffix", md5SaltSuffix, false, true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := tc.hasher.Hash("password")
+			require.NoError(t, err)
+
+			for _, provider := range []struct {
+				name      string
+				supported bool
+				encode    encodeFunc
+				decode    decodeFunc
+			}{
+				{NameKeycloak, tc.keycloak, EncodeKeycloak, DecodeKeycloak},
+				{NameAuth0, tc.auth0, EncodeAuth0, DecodeAuth0},
+				{NameOkta, tc.okta, EncodeOkta, DecodeOkta},
+			} {
+				data, err := provider.encode(digest)
+
+				if !provider.supported {
+					assert.ErrorIs(t, err, ErrUnsupported, provider.name)
+
+					continue
+				}
+
+				require.NoError(t, err, provider.name)
+
+				decoded, err := provider.decode(data)
+				require.NoError(t, err, provider.name)
+
+				assert.Equal(t, digest.Encode(), decoded.Encode(), provider.name)
+				assert.True(t, decoded.Match("password"), provider.name)
+			}
+		})
+	}
+}
+
+func TestExportUnsupported(t *testing.T) {
+	digest, err := nthash.Decode("$3$$8846f7eaee8fb117ad06bdd830b7586c")
+	require.NoError(t, err)
+
+	_, err = EncodeKeycloak(digest)
+	assert.EqualError(t, err, "keycloak export error: the credential is not supported: digests of type '*nthash.Digest' are not supported")
+
+	_, err = EncodeAuth0(nil)
+	assert.EqualError(t, err, "auth0 export error: can't export a nil digest")
+
+	sha224, err := legacy.NewDigest("raw(sha224($pass))", nil, make([]byte, 28))
+	require.NoError(t, err)
+
+	_, err = EncodeOkta(sha224)
+	assert.EqualError(t, err, "okta export error: the credential is not supported: legacy digests using the 'raw(sha224($pass))' recipe are not supported")
+}
@@ -0,0 +1,189 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+const (
+	keycloakAlgorithmPBKDF2       = "pbkdf2"
+	keycloakAlgorithmPBKDF2SHA256 = "pbkdf2-sha256"
+	keycloakAlgorithmPBKDF2SHA512 = "pbkdf2-sha512"
+	keycloakAlgorithmArgon2       = "argon2"
+
+	keycloakParamType        = "type"
+	keycloakParamVersion     = "version"
+	keycloakParamHashLength  = "hashLength"
+	keycloakParamMemory      = "memory"
+	keycloakParamParallelism = "parallelism"
+
+	keycloakArgon2Version = "1.3"
+)
+
+// KeycloakCredential is a Keycloak password credential. The pbkdf2, pbkdf2-sha256, pbkdf2-sha512, and argon2
+// algorithms are supported.
+type KeycloakCredential struct {
+	Algorithm            string              `json:"algorithm"`
+	HashIterations       int                 `json:"hashIterations"`
+	Salt                 string              `json:"salt"`
+	Value                string              `json:"value"`
+	AdditionalParameters map[string][]string `json:"additionalParameters,omitempty"`
+}
+
+// Digest converts the KeycloakCredential into an algorithm.Digest.
+func (c KeycloakCredential) Digest() (digest algorithm.Digest, err error) {
+	var salt, key []byte
+
+	if salt, err = base64.StdEncoding.DecodeString(c.Salt); err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameKeycloak, fmt.Errorf("%w: salt could not be decoded: %v", ErrInvalid, err))
+	}
+
+	if key, err = base64.StdEncoding.DecodeString(c.Value); err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameKeycloak, fmt.Errorf("%w: value could not be decoded: %v", ErrInvalid, err))
+	}
+
+	switch c.Algorithm {
+	case keycloakAlgorithmPBKDF2:
+		digest, err = pbkdf2.NewDigest(pbkdf2.VariantSHA1, c.HashIterations, salt, key)
+	case keycloakAlgorithmPBKDF2SHA256:
+		digest, err = pbkdf2.NewDigest(pbkdf2.VariantSHA256, c.HashIterations, salt, key)
+	case keycloakAlgorithmPBKDF2SHA512:
+		digest, err = pbkdf2.NewDigest(pbkdf2.VariantSHA512, c.HashIterations, salt, key)
+	case keycloakAlgorithmArgon2:
+		digest, err = c.argon2(salt, key)
+	default:
+		return nil, fmt.Errorf(errFmtImport, NameKeycloak, fmt.Errorf("%w: algorithm '%s' is unknown", ErrUnsupported, c.Algorithm))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameKeycloak, err)
+	}
+
+	return digest, nil
+}
+
+func (c KeycloakCredential) argon2(salt, key []byte) (digest algorithm.Digest, err error) {
+	variant := argon2.NewVariant("argon2" + c.param(keycloakParamType, "id"))
+
+	if variant == argon2.VariantNone {
+		return nil, fmt.Errorf("%w: argon2 type '%s' is unknown", ErrUnsupported, c.param(keycloakParamType, ""))
+	}
+
+	if version := c.param(keycloakParamVersion, keycloakArgon2Version); version != keycloakArgon2Version {
+		return nil, fmt.Errorf("%w: argon2 version '%s' is not supported", ErrUnsupported, version)
+	}
+
+	var m, p, length int
+
+	if m, err = c.paramInt(keycloakParamMemory); err != nil {
+		return nil, err
+	}
+
+	if p, err = c.paramInt(keycloakParamParallelism); err != nil {
+		return nil, err
+	}
+
+	if length, err = c.paramInt(keycloakParamHashLength); err != nil {
+		return nil, err
+	}
+
+	if length != len(key) {
+		return nil, fmt.Errorf("%w: argon2 hash length is %d but the value has %d bytes", ErrInvalid, length, len(key))
+	}
+
+	if m < 0 || p < 0 || c.HashIterations < 0 {
+		return nil, fmt.Errorf("%w: argon2 parameters must not be negative", ErrInvalid)
+	}
+
+	return argon2.NewDigest(variant, uint32(m), uint32(c.HashIterations), uint32(p), salt, key)
+}
+
+func (c KeycloakCredential) param(name, fallback string) string {
+	if values := c.AdditionalParameters[name]; len(values) != 0 {
+		return values[0]
+	}
+
+	return fallback
+}
+
+func (c KeycloakCredential) paramInt(name string) (value int, err error) {
+	raw := c.param(name, "")
+
+	if value, err = strconv.Atoi(raw); err != nil {
+		return 0, fmt.Errorf("%w: additional parameter '%s' has invalid value '%s'", ErrInvalid, name, raw)
+	}
+
+	return value, nil
+}
+
+// NewKeycloakCredential converts the algorithm.Digest into a KeycloakCredential. The supported digests are the SHA1,
+// SHA256, and SHA512 variants of the pbkdf2.Digest and argon2.Digest values using version 19 without the keyid or data
+// parameters.
+func NewKeycloakCredential(digest algorithm.Digest) (credential KeycloakCredential, err error) {
+	switch d := digest.(type) {
+	case *pbkdf2.Digest:
+		switch d.Variant() {
+		case pbkdf2.VariantSHA1:
+			credential.Algorithm = keycloakAlgorithmPBKDF2
+		case pbkdf2.VariantSHA256:
+			credential.Algorithm = keycloakAlgorithmPBKDF2SHA256
+		case pbkdf2.VariantSHA512:
+			credential.Algorithm = keycloakAlgorithmPBKDF2SHA512
+		default:
+			return credential, fmt.Errorf(errFmtExport, NameKeycloak, fmt.Errorf("%w: pbkdf2 digests using the %s variant are not supported", ErrUnsupported, d.Variant()))
+		}
+
+		credential.HashIterations = d.Iterations()
+	case *argon2.Digest:
+		if d.Version() != argon2.Version13 || len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return credential, fmt.Errorf(errFmtExport, NameKeycloak, fmt.Errorf("%w: argon2 digests must use version 19 without the keyid or data parameters", ErrUnsupported))
+		}
+
+		credential.Algorithm = keycloakAlgorithmArgon2
+		credential.HashIterations = int(d.T())
+		credential.AdditionalParameters = map[string][]string{
+			keycloakParamType:        {d.Variant().String()[len("argon2"):]},
+			keycloakParamVersion:     {keycloakArgon2Version},
+			keycloakParamHashLength:  {strconv.Itoa(len(d.Key()))},
+			keycloakParamMemory:      {strconv.FormatUint(uint64(d.M()), 10)},
+			keycloakParamParallelism: {strconv.FormatUint(uint64(d.P()), 10)},
+		}
+	case nil:
+		return credential, fmt.Errorf(errFmtExport, NameKeycloak, fmt.Errorf("can't export a nil digest"))
+	default:
+		return credential, fmt.Errorf(errFmtExport, NameKeycloak, fmt.Errorf("%w: digests of type '%T' are not supported", ErrUnsupported, digest))
+	}
+
+	credential.Salt = base64.StdEncoding.EncodeToString(digest.Salt())
+	credential.Value = base64.StdEncoding.EncodeToString(digest.Key())
+
+	return credential, nil
+}
+
+// DecodeKeycloak converts the JSON encoded KeycloakCredential into an algorithm.Digest.
+func DecodeKeycloak(data []byte) (digest algorithm.Digest, err error) {
+	var credential KeycloakCredential
+
+	if err = json.Unmarshal(data, &credential); err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameKeycloak, fmt.Errorf("%w: %v", ErrInvalid, err))
+	}
+
+	return credential.Digest()
+}
+
+// EncodeKeycloak converts the algorithm.Digest into a JSON encoded KeycloakCredential.
+func EncodeKeycloak(digest algorithm.Digest) (data []byte, err error) {
+	var credential KeycloakCredential
+
+	if credential, err = NewKeycloakCredential(digest); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(credential)
+}
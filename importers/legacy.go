@@ -0,0 +1,50 @@
+package importers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm/legacy"
+)
+
+// newLegacyDigest returns a legacy.Digest for a salted or unsalted raw hash where the salt is either a prefix or a
+// suffix of the password.
+func newLegacyDigest(hash string, salt, key []byte, prefix bool) (digest *legacy.Digest, err error) {
+	var expression string
+
+	switch {
+	case len(salt) == 0:
+		expression = fmt.Sprintf("raw(%s($pass))", hash)
+	case prefix:
+		expression = fmt.Sprintf("raw(%s($salt.$pass))", hash)
+	default:
+		expression = fmt.Sprintf("raw(%s($pass.$salt))", hash)
+	}
+
+	return legacy.NewDigest(expression, salt, key)
+}
+
+// parseLegacyDigest returns the hash and the position of the salt of a legacy.Digest created by newLegacyDigest.
+func parseLegacyDigest(digest *legacy.Digest) (hash string, prefix, ok bool) {
+	expression := strings.NewReplacer(" ", "", "\t", "").Replace(digest.Recipe().String())
+
+	for _, hash = range []string{legacyMD5, legacySHA1, legacySHA256, legacySHA512} {
+		switch expression {
+		case fmt.Sprintf("raw(%s($pass))", hash):
+			return hash, false, len(digest.Salt()) == 0
+		case fmt.Sprintf("raw(%s($salt.$pass))", hash):
+			return hash, true, true
+		case fmt.Sprintf("raw(%s($pass.$salt))", hash):
+			return hash, false, true
+		}
+	}
+
+	return "", false, false
+}
+
+const (
+	legacyMD5    = "md5"
+	legacySHA1   = "sha1"
+	legacySHA256 = "sha256"
+	legacySHA512 = "sha512"
+)
@@ -0,0 +1,229 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	xbcrypt "github.com/go-crypt/x/bcrypt"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/legacy"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+const (
+	oktaAlgorithmBcrypt = "BCRYPT"
+	oktaAlgorithmPBKDF2 = "PBKDF2"
+	oktaAlgorithmMD5    = "MD5"
+	oktaAlgorithmSHA1   = "SHA-1"
+	oktaAlgorithmSHA256 = "SHA-256"
+	oktaAlgorithmSHA512 = "SHA-512"
+
+	oktaDigestSHA1   = "SHA1_HMAC"
+	oktaDigestSHA256 = "SHA256_HMAC"
+	oktaDigestSHA512 = "SHA512_HMAC"
+
+	oktaSaltOrderPrefix  = "PREFIX"
+	oktaSaltOrderPostfix = "POSTFIX"
+)
+
+var (
+	oktaLegacyAlgorithms = map[string]string{
+		oktaAlgorithmMD5:    legacyMD5,
+		oktaAlgorithmSHA1:   legacySHA1,
+		oktaAlgorithmSHA256: legacySHA256,
+		oktaAlgorithmSHA512: legacySHA512,
+	}
+
+	oktaDigestAlgorithms = map[string]pbkdf2.Variant{
+		oktaDigestSHA1:   pbkdf2.VariantSHA1,
+		oktaDigestSHA256: pbkdf2.VariantSHA256,
+		oktaDigestSHA512: pbkdf2.VariantSHA512,
+	}
+)
+
+// OktaHash is the credentials.password.hash object of the Okta Users API. The BCRYPT, PBKDF2, MD5, SHA-1, SHA-256, and
+// SHA-512 algorithms are supported.
+type OktaHash struct {
+	Algorithm       string `json:"algorithm"`
+	WorkFactor      int    `json:"workFactor,omitempty"`
+	IterationCount  int    `json:"iterationCount,omitempty"`
+	KeySize         int    `json:"keySize,omitempty"`
+	DigestAlgorithm string `json:"digestAlgorithm,omitempty"`
+	Salt            string `json:"salt,omitempty"`
+	SaltOrder       string `json:"saltOrder,omitempty"`
+	Value           string `json:"value"`
+}
+
+// Digest converts the OktaHash into an algorithm.Digest.
+func (h OktaHash) Digest() (digest algorithm.Digest, err error) {
+	switch h.Algorithm {
+	case oktaAlgorithmBcrypt:
+		digest, err = h.bcrypt()
+	case oktaAlgorithmPBKDF2:
+		digest, err = h.pbkdf2()
+	case oktaAlgorithmMD5, oktaAlgorithmSHA1, oktaAlgorithmSHA256, oktaAlgorithmSHA512:
+		digest, err = h.legacy()
+	default:
+		return nil, fmt.Errorf(errFmtImport, NameOkta, fmt.Errorf("%w: algorithm '%s' is unknown", ErrUnsupported, h.Algorithm))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameOkta, err)
+	}
+
+	return digest, nil
+}
+
+func (h OktaHash) bcrypt() (digest algorithm.Digest, err error) {
+	if len(h.Salt) != xbcrypt.EncodedSaltSize {
+		return nil, fmt.Errorf("%w: salt is expected to be %d characters but it has %d characters", ErrInvalid, xbcrypt.EncodedSaltSize, len(h.Salt))
+	}
+
+	var salt []byte
+
+	if salt, err = xbcrypt.Base64Decode([]byte(h.Salt)); err != nil {
+		return nil, fmt.Errorf("%w: salt could not be decoded: %v", ErrInvalid, err)
+	}
+
+	return bcrypt.NewDigest(bcrypt.VariantStandard, h.WorkFactor, salt, []byte(h.Value))
+}
+
+func (h OktaHash) pbkdf2() (digest algorithm.Digest, err error) {
+	variant, ok := oktaDigestAlgorithms[h.DigestAlgorithm]
+
+	if !ok {
+		return nil, fmt.Errorf("%w: digest algorithm '%s' is unknown", ErrUnsupported, h.DigestAlgorithm)
+	}
+
+	var salt, key []byte
+
+	if salt, key, err = h.decode(); err != nil {
+		return nil, err
+	}
+
+	if h.KeySize != len(key) {
+		return nil, fmt.Errorf("%w: key size is %d but the value has %d bytes", ErrInvalid, h.KeySize, len(key))
+	}
+
+	return pbkdf2.NewDigest(variant, h.IterationCount, salt, key)
+}
+
+func (h OktaHash) legacy() (digest algorithm.Digest, err error) {
+	var salt, key []byte
+
+	if salt, key, err = h.decode(); err != nil {
+		return nil, err
+	}
+
+	prefix := false
+
+	if len(salt) != 0 {
+		switch h.SaltOrder {
+		case oktaSaltOrderPrefix:
+			prefix = true
+		case oktaSaltOrderPostfix:
+			prefix = false
+		default:
+			return nil, fmt.Errorf("%w: salt order '%s' is unknown", ErrInvalid, h.SaltOrder)
+		}
+	}
+
+	return newLegacyDigest(oktaLegacyAlgorithms[h.Algorithm], salt, key, prefix)
+}
+
+func (h OktaHash) decode() (salt, key []byte, err error) {
+	if salt, err = base64.StdEncoding.DecodeString(h.Salt); err != nil {
+		return nil, nil, fmt.Errorf("%w: salt could not be decoded: %v", ErrInvalid, err)
+	}
+
+	if key, err = base64.StdEncoding.DecodeString(h.Value); err != nil {
+		return nil, nil, fmt.Errorf("%w: value could not be decoded: %v", ErrInvalid, err)
+	}
+
+	return salt, key, nil
+}
+
+// NewOktaHash converts the algorithm.Digest into an OktaHash. The supported digests are the standard variant of the
+// bcrypt.Digest, the SHA1, SHA256, and SHA512 variants of the pbkdf2.Digest, and the legacy.Digest values created from
+// an OktaHash.
+func NewOktaHash(digest algorithm.Digest) (hash OktaHash, err error) {
+	switch d := digest.(type) {
+	case *bcrypt.Digest:
+		if d.Variant() != bcrypt.VariantStandard {
+			return hash, fmt.Errorf(errFmtExport, NameOkta, fmt.Errorf("%w: bcrypt digests must use the standard variant", ErrUnsupported))
+		}
+
+		hash = OktaHash{
+			Algorithm:  oktaAlgorithmBcrypt,
+			WorkFactor: d.Iterations(),
+			Salt:       string(xbcrypt.Base64Encode(d.Salt())),
+			Value:      string(d.Key()),
+		}
+	case *pbkdf2.Digest:
+		for name, variant := range oktaDigestAlgorithms {
+			if variant == d.Variant() {
+				hash.DigestAlgorithm = name
+			}
+		}
+
+		if hash.DigestAlgorithm == "" {
+			return hash, fmt.Errorf(errFmtExport, NameOkta, fmt.Errorf("%w: pbkdf2 digests using the %s variant are not supported", ErrUnsupported, d.Variant()))
+		}
+
+		hash.Algorithm, hash.IterationCount, hash.KeySize = oktaAlgorithmPBKDF2, d.Iterations(), len(d.Key())
+		hash.Salt, hash.Value = base64.StdEncoding.EncodeToString(d.Salt()), base64.StdEncoding.EncodeToString(d.Key())
+	case *legacy.Digest:
+		name, prefix, ok := parseLegacyDigest(d)
+
+		if !ok {
+			return hash, fmt.Errorf(errFmtExport, NameOkta, fmt.Errorf("%w: legacy digests using the '%s' recipe are not supported", ErrUnsupported, d.Recipe()))
+		}
+
+		for alg, n := range oktaLegacyAlgorithms {
+			if n == name {
+				hash.Algorithm = alg
+			}
+		}
+
+		hash.Value = base64.StdEncoding.EncodeToString(d.Key())
+
+		if len(d.Salt()) != 0 {
+			hash.Salt, hash.SaltOrder = base64.StdEncoding.EncodeToString(d.Salt()), oktaSaltOrderPostfix
+
+			if prefix {
+				hash.SaltOrder = oktaSaltOrderPrefix
+			}
+		}
+	case nil:
+		return hash, fmt.Errorf(errFmtExport, NameOkta, fmt.Errorf("can't export a nil digest"))
+	default:
+		return hash, fmt.Errorf(errFmtExport, NameOkta, fmt.Errorf("%w: digests of type '%T' are not supported", ErrUnsupported, digest))
+	}
+
+	return hash, nil
+}
+
+// DecodeOkta converts the JSON encoded OktaHash into an algorithm.Digest.
+func DecodeOkta(data []byte) (digest algorithm.Digest, err error) {
+	var hash OktaHash
+
+	if err = json.Unmarshal(data, &hash); err != nil {
+		return nil, fmt.Errorf(errFmtImport, NameOkta, fmt.Errorf("%w: %v", ErrInvalid, err))
+	}
+
+	return hash.Digest()
+}
+
+// EncodeOkta converts the algorithm.Digest into a JSON encoded OktaHash.
+func EncodeOkta(digest algorithm.Digest) (data []byte, err error) {
+	var hash OktaHash
+
+	if hash, err = NewOktaHash(digest); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(hash)
+}
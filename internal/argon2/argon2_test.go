@@ -0,0 +1,56 @@
+package argon2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyRFC9106(t *testing.T) {
+	var (
+		password = bytes.Repeat([]byte{0x01}, 32)
+		salt     = bytes.Repeat([]byte{0x02}, 16)
+		secret   = bytes.Repeat([]byte{0x03}, 8)
+		data     = bytes.Repeat([]byte{0x04}, 12)
+	)
+
+	testCases := []struct {
+		name     string
+		mode     int
+		expected string
+	}{
+		{"ShouldMatchArgon2d", ModeD, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"},
+		{"ShouldMatchArgon2i", ModeI, "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8"},
+		{"ShouldMatchArgon2id", ModeID, "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hex.EncodeToString(Key(tc.mode, Version, password, salt, secret, data, 3, 32, 4, 32)))
+		})
+	}
+}
+
+func TestKeyVersion10(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mode           int
+		time, mem, par uint32
+		expected       string
+	}{
+		{"ShouldMatchArgon2d", ModeD, 2, 65536, 1, "2ec0d925358f5830caf0c1cc8a3ee58b34505759428b859c79b72415f51f9221"},
+		{"ShouldMatchArgon2dParallelism", ModeD, 3, 32, 4, "c03e6ea388e8b206ee787353eebf6225f4a487546952f0fca2a76efb0ab3d8ae"},
+		{"ShouldMatchArgon2i", ModeI, 2, 65536, 1, "f6c4db4a54e2a370627aff3db6176b94a2a209a62c8e36152711802f7b30c694"},
+		{"ShouldMatchArgon2iParallelism", ModeI, 3, 32, 4, "a5742f9e3bf59936648fffc596029c224361c5efe42ccc704d4b2f7bf4ac0403"},
+		{"ShouldMatchArgon2id", ModeID, 2, 65536, 1, "980ebd24a4e667f16346f9d4a78b175728783613e0cc6fb17c2ec884b16435df"},
+		{"ShouldMatchArgon2idParallelism", ModeID, 3, 32, 4, "4ed472a832960d0fd677459f7926f9b4bf72df7ec7e55436d578dd5f24e85fd1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hex.EncodeToString(Key(tc.mode, Version10, []byte("password"), []byte("somesalt"), nil, nil, tc.time, tc.mem, tc.par, 32)))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package argon2
+
+const (
+	blockLength = 128
+	syncPoints  = 4
+)
+
+const (
+	// Version10 is the legacy Argon2 version 1.0.
+	Version10 = 0x10
+
+	// Version13 is the Argon2 version 1.3.
+	Version13 = 0x13
+
+	// Version is the current Argon2 version.
+	Version = Version13
+)
+
+const (
+	// ModeD is the Argon2d mode.
+	ModeD = iota
+
+	// ModeI is the Argon2i mode.
+	ModeI
+
+	// ModeID is the Argon2id mode.
+	ModeID
+)
@@ -0,0 +1,6 @@
+// Package argon2 is an internal implementation of Argon2 derived from github.com/go-crypt/x/argon2 which additionally
+// exposes the optional secret (K) and associated data (X) inputs described in RFC9106. Only the generic implementation
+// of the BlaMka round function is included, digests without these inputs should use github.com/go-crypt/x/argon2.
+//
+// The code derived from github.com/go-crypt/x/argon2 is governed by the BSD-style license in the LICENSE file.
+package argon2
@@ -1,14 +1,10 @@
 package encoding
 
 import (
-	"encoding/base64"
-)
-
-const (
-	encodeBase64Adapted = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./"
+	"github.com/go-crypt/crypt/encoding"
 )
 
 var (
 	// Base64RawAdaptedEncoding is the adapted encoding for crypt purposes without padding.
-	Base64RawAdaptedEncoding = base64.NewEncoding(encodeBase64Adapted).WithPadding(base64.NoPadding)
+	Base64RawAdaptedEncoding = encoding.Base64RawAdaptedEncoding
 )
@@ -0,0 +1,3 @@
+// Package md4 is an internal helper package which implements the MD4 hash algorithm described in RFC1320. MD4 is
+// cryptographically broken and is only implemented for compatibility with legacy formats.
+package md4
@@ -0,0 +1,167 @@
+package md4
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size is the size of an MD4 checksum in bytes.
+	Size = 16
+
+	// BlockSize is the block size of MD4 in bytes.
+	BlockSize = 64
+)
+
+const (
+	init0 = 0x67452301
+	init1 = 0xefcdab89
+	init2 = 0x98badcfe
+	init3 = 0x10325476
+
+	round2 = 0x5a827999
+	round3 = 0x6ed9eba1
+)
+
+var (
+	shifts1 = [4]int{3, 7, 11, 19}
+	shifts2 = [4]int{3, 5, 9, 13}
+	shifts3 = [4]int{3, 9, 11, 15}
+
+	order2 = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+	order3 = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+)
+
+// New returns a new hash.Hash computing the MD4 checksum.
+func New() hash.Hash {
+	d := &digest{}
+
+	d.Reset()
+
+	return d
+}
+
+// Sum returns the MD4 checksum of the data.
+func Sum(data []byte) (sum [Size]byte) {
+	d := &digest{}
+
+	d.Reset()
+
+	_, _ = d.Write(data)
+
+	copy(sum[:], d.Sum(nil))
+
+	return sum
+}
+
+type digest struct {
+	s   [4]uint32
+	x   [BlockSize]byte
+	nx  int
+	len uint64
+}
+
+func (d *digest) Reset() {
+	d.s = [4]uint32{init0, init1, init2, init3}
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.len += uint64(n)
+
+	if d.nx > 0 {
+		i := copy(d.x[d.nx:], p)
+
+		d.nx += i
+
+		if d.nx == BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+
+		p = p[i:]
+	}
+
+	for len(p) >= BlockSize {
+		d.block(p[:BlockSize])
+		p = p[BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	c := *d
+
+	length := c.len
+
+	var pad [BlockSize + 8]byte
+
+	pad[0] = 0x80
+
+	if length%BlockSize < 56 {
+		_, _ = c.Write(pad[:56-length%BlockSize])
+	} else {
+		_, _ = c.Write(pad[:BlockSize+56-length%BlockSize])
+	}
+
+	binary.LittleEndian.PutUint64(pad[:8], length<<3)
+
+	_, _ = c.Write(pad[:8])
+
+	var sum [Size]byte
+
+	for i, s := range c.s {
+		binary.LittleEndian.PutUint32(sum[i*4:], s)
+	}
+
+	return append(in, sum[:]...)
+}
+
+func (d *digest) block(p []byte) {
+	var x [16]uint32
+
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(p[i*4:])
+	}
+
+	a, b, c, dd := d.s[0], d.s[1], d.s[2], d.s[3]
+
+	for i := 0; i < 16; i++ {
+		f := (b & c) | (^b & dd)
+		a = bits.RotateLeft32(a+f+x[i], shifts1[i%4])
+		a, b, c, dd = dd, a, b, c
+	}
+
+	for i := 0; i < 16; i++ {
+		g := (b & c) | (b & dd) | (c & dd)
+		a = bits.RotateLeft32(a+g+x[order2[i]]+round2, shifts2[i%4])
+		a, b, c, dd = dd, a, b, c
+	}
+
+	for i := 0; i < 16; i++ {
+		h := b ^ c ^ dd
+		a = bits.RotateLeft32(a+h+x[order3[i]]+round3, shifts3[i%4])
+		a, b, c, dd = dd, a, b, c
+	}
+
+	d.s[0] += a
+	d.s[1] += b
+	d.s[2] += c
+	d.s[3] += dd
+}
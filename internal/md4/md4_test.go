@@ -0,0 +1,43 @@
+package md4
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldHashEmpty", "", "31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"ShouldHashA", "a", "bde52cb31de33e46245e05fbdbd6fb24"},
+		{"ShouldHashABC", "abc", "a448017aaf21d8525fc10ae87aa6729d"},
+		{"ShouldHashMessageDigest", "message digest", "d9130a8164549fe818874806e1c7014b"},
+		{"ShouldHashAlphabet", "abcdefghijklmnopqrstuvwxyz", "d79e1c308aa5bbcdeea8ed63df412da9"},
+		{"ShouldHashAlphanumeric", "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", "043f8582f241db351ce627e153e7f0e4"},
+		{"ShouldHashMultipleBlocks", strings.Repeat("1234567890", 8), "e33b4ddc9c38f2199c3e7b164fcc0536"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sum := Sum([]byte(tc.have))
+
+			assert.Equal(t, tc.expected, hex.EncodeToString(sum[:]))
+
+			h := New()
+
+			for i := 0; i < len(tc.have); i++ {
+				_, _ = h.Write([]byte{tc.have[i]})
+			}
+
+			assert.Equal(t, tc.expected, hex.EncodeToString(h.Sum(nil)))
+			assert.Equal(t, Size, h.Size())
+			assert.Equal(t, BlockSize, h.BlockSize())
+		})
+	}
+}
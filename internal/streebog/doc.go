@@ -0,0 +1,3 @@
+// Package streebog is an internal implementation of the GOST R 34.11-2012 (Streebog) hash function as described in
+// RFC6986. It's used by the gost-yescrypt variant of scrypt.
+package streebog
@@ -0,0 +1,219 @@
+package streebog
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// BlockSize is the block size of Streebog in bytes.
+	BlockSize = 64
+
+	// Size256 is the size of the Streebog-256 digest in bytes.
+	Size256 = 32
+
+	// Size512 is the size of the Streebog-512 digest in bytes.
+	Size512 = 64
+)
+
+// lps is the precomputed combination of the S, P, and L transformations.
+var lps [8][256]uint64
+
+func init() {
+	for j := 0; j < 8; j++ {
+		for b := 0; b < 256; b++ {
+			var v uint64
+
+			for t := 0; t < 8; t++ {
+				if pi[b]>>t&1 == 1 {
+					v ^= a[63-(8*j+t)]
+				}
+			}
+
+			lps[j][b] = v
+		}
+	}
+}
+
+type block [8]uint64
+
+// New256 returns a new hash.Hash computing the Streebog-256 checksum.
+func New256() hash.Hash {
+	d := &digest{size: Size256}
+	d.Reset()
+
+	return d
+}
+
+// New512 returns a new hash.Hash computing the Streebog-512 checksum.
+func New512() hash.Hash {
+	d := &digest{size: Size512}
+	d.Reset()
+
+	return d
+}
+
+// Sum256 returns the Streebog-256 checksum of the data.
+func Sum256(data []byte) (sum [Size256]byte) {
+	h := New256()
+	h.Write(data)
+
+	copy(sum[:], h.Sum(nil))
+
+	return sum
+}
+
+type digest struct {
+	size int
+
+	h, n, sigma block
+
+	buf [BlockSize]byte
+	nx  int
+}
+
+func (d *digest) Reset() {
+	var iv uint64
+
+	if d.size == Size256 {
+		iv = 0x0101010101010101
+	}
+
+	for i := range d.h {
+		d.h[i], d.n[i], d.sigma[i] = iv, 0, 0
+	}
+
+	d.nx = 0
+}
+
+func (d *digest) Size() int {
+	return d.size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+
+	for len(p) > 0 {
+		c := copy(d.buf[d.nx:], p)
+
+		d.nx += c
+		p = p[c:]
+
+		if d.nx == BlockSize && len(p) > 0 {
+			d.compress(d.buf[:], BlockSize*8)
+
+			d.nx = 0
+		}
+	}
+
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	dd := *d
+
+	if dd.nx == BlockSize {
+		dd.compress(dd.buf[:], BlockSize*8)
+
+		dd.nx = 0
+	}
+
+	var m [BlockSize]byte
+
+	copy(m[:], dd.buf[:dd.nx])
+
+	m[dd.nx] = 0x01
+
+	dd.compress(m[:], uint64(dd.nx)*8)
+
+	var zero block
+
+	dd.h = g(&zero, &dd.h, &dd.n)
+	dd.h = g(&zero, &dd.h, &dd.sigma)
+
+	var out [Size512]byte
+
+	for i, w := range dd.h {
+		binary.LittleEndian.PutUint64(out[i*8:], w)
+	}
+
+	return append(in, out[Size512-dd.size:]...)
+}
+
+// compress processes a single block and adds the number of bits to the counter.
+func (d *digest) compress(p []byte, bits uint64) {
+	var m block
+
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(p[i*8:])
+	}
+
+	d.h = g(&d.n, &d.h, &m)
+
+	add(&d.n, &block{bits})
+	add(&d.sigma, &m)
+}
+
+// add performs addition modulo 2^512.
+func add(x, y *block) {
+	var carry uint64
+
+	for i := range x {
+		s := x[i] + y[i]
+		c := uint64(0)
+
+		if s < x[i] {
+			c = 1
+		}
+
+		s += carry
+
+		if s < carry {
+			c = 1
+		}
+
+		x[i], carry = s, c
+	}
+}
+
+// xlps computes LPS(x xor y).
+func xlps(x, y *block) (out block) {
+	var r block
+
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+
+	for i := range out {
+		shift := uint(i) * 8
+
+		for j := range r {
+			out[i] ^= lps[j][byte(r[j]>>shift)]
+		}
+	}
+
+	return out
+}
+
+// g is the compression function.
+func g(n, h, m *block) (out block) {
+	k := xlps(h, n)
+	t := xlps(&k, m)
+
+	for i := 0; i < 11; i++ {
+		k = xlps(&k, (*block)(&c[i]))
+		t = xlps(&k, &t)
+	}
+
+	k = xlps(&k, (*block)(&c[11]))
+
+	for i := range out {
+		out[i] = t[i] ^ k[i] ^ h[i] ^ m[i]
+	}
+
+	return out
+}
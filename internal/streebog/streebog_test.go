@@ -0,0 +1,89 @@
+package streebog
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreebog(t *testing.T) {
+	testCases := []struct {
+		name        string
+		have        string
+		expected256 string
+		expected512 string
+	}{
+		{
+			"ShouldHashEmpty",
+			"",
+			"3f539a213e97c802cc229d474c6aa32a825a360b2a933a949fd925208d9ce1bb",
+			"8e945da209aa869f0455928529bcae4679e9873ab707b55315f56ceb98bef0a7362f715528356ee83cda5f2aac4c6ad2ba3a715c1bcd81cb8e9f90bf4c1c1a8a",
+		},
+		{
+			"ShouldHashShort",
+			"abc",
+			"4e2919cf137ed41ec4fb6270c61826cc4fffb660341e0af3688cd0626d23b481",
+			"28156e28317da7c98f4fe2bed6b542d0dab85bb224445fcedaf75d46e26d7eb8d5997f3e0915dd6b7f0aab08d9c8beb0d8c64bae2ab8b3c8c6bc53b3bf0db728",
+		},
+		{
+			"ShouldHashRFC6986Example1",
+			"012345678901234567890123456789012345678901234567890123456789012",
+			"9d151eefd8590b89daa6ba6cb74af9275dd051026bb149a452fd84e5e57b5500",
+			"1b54d01a4af5b9d5cc3d86d68d285462b19abc2475222f35c085122be4ba1ffa00ad30f8767b3a82384c6574f024c311e2a481332b08ef7f41797891c1646f48",
+		},
+		{
+			"ShouldHashSingleBlock",
+			strings.Repeat("a", 64),
+			"c2ce0969b6e468445ecfaed89f614178f89cc37ab59523528a58745007f33ab2",
+			"613852076ca11156cf7d00f4feef0d5e3198e638f8e20eb02da2f5f7dca5b62dd9fb88e22e825f727ed6f25e4145dc868d0ef41e3e451e34b780e5547ade0d43",
+		},
+		{
+			"ShouldHashMultipleBlocks",
+			strings.Repeat("b", 200),
+			"a6e1bf7d6673158a7010c9308fac3526594c3d785dbbf834f2564d214cc6ddd6",
+			"ba1dc605ab6edb424158511d2851cfe5eec99e1b229cab328ff5a14fd081e4cda8d9c54142575632ead23f5d91a3c3d5029bfc712775e31752a01f88ecc5ba8c",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sum := Sum256([]byte(tc.have))
+
+			assert.Equal(t, tc.expected256, hex.EncodeToString(sum[:]))
+
+			for _, h := range []hash.Hash{New256(), New512()} {
+				for i := 0; i < len(tc.have); i += 7 {
+					h.Write([]byte(tc.have[i:min(i+7, len(tc.have))]))
+				}
+
+				expected := tc.expected512
+
+				if h.Size() == Size256 {
+					expected = tc.expected256
+				}
+
+				assert.Equal(t, expected, hex.EncodeToString(h.Sum(nil)))
+				assert.Equal(t, expected, hex.EncodeToString(h.Sum(nil)))
+
+				h.Reset()
+				h.Write([]byte(tc.have))
+
+				assert.Equal(t, expected, hex.EncodeToString(h.Sum(nil)))
+			}
+		})
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	key, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	data, _ := hex.DecodeString("0126bdb87800af214341456535942a2a")
+
+	h := hmac.New(New256, key)
+	h.Write(data)
+
+	assert.Equal(t, "3a0f644ebe068c1a5971b6fa21a0840bf09032ad7c7a51bbca36cdc765cc6db1", hex.EncodeToString(h.Sum(nil)))
+}
@@ -0,0 +1,4 @@
+// Package yescrypt is an internal implementation of yescrypt which supports the full set of parameters which can be
+// described by the libxcrypt setting string, unlike github.com/go-crypt/x/yescrypt which only supports the default
+// flags, a parallelism of 1, and no time parameter.
+package yescrypt
@@ -0,0 +1,315 @@
+package yescrypt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-crypt/crypt/encoding"
+)
+
+const itoa64 = encoding.AlphabetHash64
+
+const (
+	haveP = 1 << iota
+	haveT
+	haveG
+	haveROM
+)
+
+// Setting is the decoded form of the parameters portion of a libxcrypt yescrypt setting string.
+type Setting struct {
+	// Flags are the yescrypt flags.
+	Flags int
+
+	// LN is the base 2 logarithm of N.
+	LN int
+
+	// R is the block size.
+	R int
+
+	// P is the parallelism.
+	P int
+
+	// T is the time parameter.
+	T int
+
+	// G is the number of hash upgrades.
+	G int
+
+	// LNROM is the base 2 logarithm of the ROM size, 0 means no ROM is used.
+	LNROM int
+}
+
+// DecodeSetting decodes the parameters portion of a libxcrypt yescrypt setting string i.e. the section between the
+// identifier and the salt.
+func DecodeSetting(setting string) (s Setting, err error) {
+	var (
+		flavor, have, v uint32
+		ok              bool
+	)
+
+	errBadSetting := errors.New("yescrypt: bad setting")
+
+	if flavor, setting, ok = decode64Uint32(setting, 0); !ok {
+		return s, errBadSetting
+	}
+
+	switch {
+	case flavor < FlagRW:
+		s.Flags = int(flavor)
+	case flavor <= FlagRW+(FlagsRWFlavorMask>>2):
+		s.Flags = FlagRW + (int(flavor)-FlagRW)<<2
+	default:
+		return s, errBadSetting
+	}
+
+	s.P = 1
+
+	params := []struct {
+		bit   uint32
+		min   uint32
+		value *int
+	}{
+		{0, 1, &s.LN},
+		{0, 1, &s.R},
+		{haveP, 2, &s.P},
+		{haveT, 1, &s.T},
+		{haveG, 1, &s.G},
+		{haveROM, 1, &s.LNROM},
+	}
+
+	for i, param := range params {
+		if i == 2 && len(setting) != 0 {
+			if have, setting, ok = decode64Uint32(setting, 1); !ok || have > haveP|haveT|haveG|haveROM {
+				return s, errBadSetting
+			}
+		}
+
+		if param.bit != 0 && have&param.bit == 0 {
+			continue
+		}
+
+		if v, setting, ok = decode64Uint32(setting, param.min); !ok {
+			return s, errBadSetting
+		}
+
+		*param.value = int(v)
+	}
+
+	if len(setting) != 0 || s.LN > 63 || s.LNROM > 63 {
+		return s, errBadSetting
+	}
+
+	return s, nil
+}
+
+// Encode returns the encoded form of the Setting which is the inverse of DecodeSetting.
+func (s Setting) Encode() (setting string) {
+	var flavor int
+
+	if s.Flags < FlagRW {
+		flavor = s.Flags
+	} else {
+		flavor = FlagRW + s.Flags>>2
+	}
+
+	buf := &strings.Builder{}
+
+	encode64Uint32(buf, uint32(flavor), 0)
+	encode64Uint32(buf, uint32(s.LN), 1)
+	encode64Uint32(buf, uint32(s.R), 1)
+
+	var have uint32
+
+	if s.P != 1 {
+		have |= haveP
+	}
+
+	if s.T != 0 {
+		have |= haveT
+	}
+
+	if s.G != 0 {
+		have |= haveG
+	}
+
+	if s.LNROM != 0 {
+		have |= haveROM
+	}
+
+	if have != 0 {
+		encode64Uint32(buf, have, 1)
+	}
+
+	if have&haveP != 0 {
+		encode64Uint32(buf, uint32(s.P), 2)
+	}
+
+	if have&haveT != 0 {
+		encode64Uint32(buf, uint32(s.T), 1)
+	}
+
+	if have&haveG != 0 {
+		encode64Uint32(buf, uint32(s.G), 1)
+	}
+
+	if have&haveROM != 0 {
+		encode64Uint32(buf, uint32(s.LNROM), 1)
+	}
+
+	return buf.String()
+}
+
+// Encode64 encodes the bytes using the yescrypt encoding.
+func Encode64(src []byte) (dst []byte) {
+	return encoding.Hash64Encoding.Encode(src)
+}
+
+// Decode64 decodes the bytes using the yescrypt encoding returning nil if the encoding is not valid.
+func Decode64(src []byte) (dst []byte) {
+	var err error
+
+	if dst, err = encoding.Hash64Encoding.Decode(src); err != nil {
+		return nil
+	}
+
+	return dst
+}
+
+// encode64Uint32 encodes the value using the variable length integer encoding used by yescrypt.
+func encode64Uint32(buf *strings.Builder, src, min uint32) {
+	start, end, chars, bits := uint32(0), uint32(47), 1, uint32(0)
+
+	src -= min
+
+	for {
+		count := (end + 1 - start) << bits
+
+		if src < count {
+			break
+		}
+
+		start = end + 1
+		end = start + (62-end)/2
+		src -= count
+		chars++
+		bits += 6
+	}
+
+	buf.WriteByte(itoa64[start+(src>>bits)])
+
+	for ; chars > 1; chars-- {
+		bits -= 6
+
+		buf.WriteByte(itoa64[(src>>bits)&0x3f])
+	}
+}
+
+// decode64Uint32 decodes a value encoded with encode64Uint32 returning the remaining characters.
+func decode64Uint32(src string, min uint32) (value uint32, rest string, ok bool) {
+	if len(src) == 0 {
+		return 0, src, false
+	}
+
+	start, end, chars, bits := uint32(0), uint32(47), 1, uint32(0)
+
+	c := strings.IndexByte(itoa64, src[0])
+
+	if c == -1 {
+		return 0, src, false
+	}
+
+	value = min
+
+	for uint32(c) > end {
+		value += (end + 1 - start) << bits
+		start = end + 1
+		end = start + (62-end)/2
+		chars++
+		bits += 6
+	}
+
+	value += (uint32(c) - start) << bits
+
+	if len(src) < chars {
+		return 0, src, false
+	}
+
+	for i := 1; i < chars; i++ {
+		if c = strings.IndexByte(itoa64, src[i]); c == -1 {
+			return 0, src, false
+		}
+
+		bits -= 6
+
+		value += uint32(c) << bits
+	}
+
+	return value, src[chars:], true
+}
+
+// DecodeScryptSetting decodes the parameters portion of a libxcrypt scrypt setting string i.e. the section between
+// the '$7$' identifier and the salt. The salt is returned as is as it's used in its encoded form by the algorithm.
+func DecodeScryptSetting(setting string) (s Setting, salt string, err error) {
+	errBadSetting := errors.New("scrypt: bad setting")
+
+	if len(setting) < 11 {
+		return s, "", errBadSetting
+	}
+
+	if s.LN = strings.IndexByte(itoa64, setting[0]); s.LN < 1 {
+		return s, "", errBadSetting
+	}
+
+	var (
+		r, p uint32
+		ok   bool
+	)
+
+	if r, ok = decode64Uint32Fixed(setting[1:6]); !ok || r == 0 {
+		return s, "", errBadSetting
+	}
+
+	if p, ok = decode64Uint32Fixed(setting[6:11]); !ok || p == 0 {
+		return s, "", errBadSetting
+	}
+
+	s.Flags, s.R, s.P = FlagsScrypt, int(r), int(p)
+
+	return s, setting[11:], nil
+}
+
+// EncodeScryptSetting returns the encoded form of the libxcrypt scrypt setting which is the inverse of
+// DecodeScryptSetting. Only the LN, R, and P values are encoded.
+func EncodeScryptSetting(s Setting) (setting string) {
+	buf := &strings.Builder{}
+
+	buf.WriteByte(itoa64[s.LN&0x3f])
+
+	encode64Uint32Fixed(buf, uint32(s.R))
+	encode64Uint32Fixed(buf, uint32(s.P))
+
+	return buf.String()
+}
+
+// encode64Uint32Fixed encodes the 30 least significant bits of the value as 5 characters.
+func encode64Uint32Fixed(buf *strings.Builder, src uint32) {
+	for bits := 0; bits < 30; bits += 6 {
+		buf.WriteByte(itoa64[(src>>bits)&0x3f])
+	}
+}
+
+// decode64Uint32Fixed decodes a value encoded with encode64Uint32Fixed.
+func decode64Uint32Fixed(src string) (value uint32, ok bool) {
+	for i, bits := 0, 0; i < len(src); i, bits = i+1, bits+6 {
+		c := strings.IndexByte(itoa64, src[i])
+
+		if c == -1 {
+			return 0, false
+		}
+
+		value |= uint32(c) << bits
+	}
+
+	return value, true
+}
@@ -0,0 +1,358 @@
+package yescrypt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/go-crypt/x/pbkdf2"
+)
+
+const (
+	// FlagsScrypt is the flags value which results in the classic scrypt algorithm.
+	FlagsScrypt = 0
+
+	// FlagWORM is the flag which enables the write-once-read-many mode which is a conservative enhancement of scrypt.
+	FlagWORM = 0x001
+
+	// FlagRW is the flag which enables the read-write mode which is the native yescrypt mode.
+	FlagRW = 0x002
+
+	// FlagRounds6 is the flag which sets the number of pwxform rounds to 6.
+	FlagRounds6 = 0x004
+
+	// FlagGather4 is the flag which sets the pwxform gather width to 4.
+	FlagGather4 = 0x010
+
+	// FlagSimple2 is the flag which sets the pwxform simple width to 2.
+	FlagSimple2 = 0x020
+
+	// FlagSBox12K is the flag which sets the pwxform S-box size to 12KiB.
+	FlagSBox12K = 0x080
+
+	// FlagsModeMask is the mask for the mode flags.
+	FlagsModeMask = 0x003
+
+	// FlagsRWFlavorMask is the mask for the flags which describe the flavor of the read-write mode.
+	FlagsRWFlavorMask = 0x3fc
+
+	// FlagsDefault is the default flags value used by libxcrypt and the reference implementation.
+	FlagsDefault = FlagRW | FlagRounds6 | FlagGather4 | FlagSimple2 | FlagSBox12K
+
+	// flagPrehash is the internal flag used to indicate the prehash pass.
+	flagPrehash = 0x10000000
+)
+
+var (
+	// ErrROMNotSupported is returned when the parameters require a ROM.
+	ErrROMNotSupported = errors.New("yescrypt: ROM is not supported")
+
+	// ErrUpgradesNotSupported is returned when the parameters require a hash upgrade.
+	ErrUpgradesNotSupported = errors.New("yescrypt: hash upgrades are not supported")
+)
+
+// ValidateFlags returns an error if the flags are not supported by this implementation. Only the classic scrypt mode,
+// the write-once-read-many mode, and the default read-write flavor are supported which is the same set of flags
+// supported by the reference implementation and libxcrypt.
+func ValidateFlags(flags int) (err error) {
+	switch flags & FlagsModeMask {
+	case FlagsScrypt, FlagWORM:
+		if flags&^FlagsModeMask != 0 {
+			return errors.New("yescrypt: flags are not supported")
+		}
+	case FlagRW:
+		if flags != FlagsDefault {
+			return errors.New("yescrypt: flags are not supported")
+		}
+	default:
+		return errors.New("yescrypt: flags are not supported")
+	}
+
+	return nil
+}
+
+// Key computes the yescrypt key using the provided parameters. The nrom and g parameters exist to allow full
+// representation of the libxcrypt setting string, however like libxcrypt this implementation does not support ROM's
+// or hash upgrades and will return an error if they are not 0.
+func Key(password, salt []byte, flags, N, r, p, t, nrom, g, keyLen int) (key []byte, err error) {
+	if g != 0 {
+		return nil, ErrUpgradesNotSupported
+	}
+
+	if nrom != 0 {
+		return nil, ErrROMNotSupported
+	}
+
+	if err = validate(flags, N, r, p, t, keyLen); err != nil {
+		return nil, err
+	}
+
+	if flags&FlagRW != 0 && N/p >= 0x100 && N/p*r >= 0x20000 {
+		if password, err = kdf(password, salt, flags|flagPrehash, N>>6, r, p, 0, 32); err != nil {
+			return nil, err
+		}
+	}
+
+	return kdf(password, salt, flags, N, r, p, t, keyLen)
+}
+
+func validate(flags, N, r, p, t, keyLen int) (err error) {
+	if err = ValidateFlags(flags &^ flagPrehash); err != nil {
+		return err
+	}
+
+	if flags == FlagsScrypt && t != 0 {
+		return errors.New("yescrypt: t must be 0 when using the classic scrypt mode")
+	}
+
+	switch {
+	case keyLen < 1 || uint64(keyLen) > (1<<32-1)*32:
+		return errors.New("yescrypt: key length is invalid")
+	case N <= 3 || N&(N-1) != 0 || uint64(N) > math.MaxUint32:
+		return errors.New("yescrypt: N must be > 3 and a power of 2")
+	case r < 1:
+		return errors.New("yescrypt: r must be > 0")
+	case p < 1:
+		return errors.New("yescrypt: p must be > 0")
+	case t < 0 || uint64(t) > math.MaxUint32:
+		return errors.New("yescrypt: t must be >= 0")
+	case uint64(r)*uint64(p) >= 1<<30 || r > math.MaxInt/256/p || N > math.MaxInt/128/r:
+		return errors.New("yescrypt: parameters are too large")
+	case flags&FlagRW != 0 && N/p <= 3:
+		return errors.New("yescrypt: N / p must be > 3")
+	}
+
+	return nil
+}
+
+func kdf(password, salt []byte, flags, N, r, p, t, keyLen int) (key []byte, err error) {
+	if err = validate(flags, N, r, p, t, keyLen); err != nil {
+		return nil, err
+	}
+
+	var passwd []byte
+
+	if flags != FlagsScrypt {
+		prehash := []byte("yescrypt-prehash")
+
+		if flags&flagPrehash == 0 {
+			prehash = prehash[:8]
+		}
+
+		h := hmac.New(sha256.New, prehash)
+		h.Write(password)
+
+		password = h.Sum(nil)
+	}
+
+	b := pbkdf2.Key(password, salt, 1, p*128*r, sha256.New)
+
+	if flags != FlagsScrypt {
+		passwd = make([]byte, sha256.Size)
+
+		copy(passwd, b[:sha256.Size])
+
+		password = passwd
+	}
+
+	v := make([]uint64, 16*N*r)
+	xy := make([]uint64, 32*max(r, 1))
+
+	if flags&FlagRW != 0 {
+		smix(b, r, N, p, t, flags, v, xy, passwd)
+	} else {
+		s := 128 * r
+
+		for i := 0; i < p; i++ {
+			smix(b[i*s:(i+1)*s], r, N, 1, t, flags, v, xy, nil)
+		}
+	}
+
+	if flags == FlagsScrypt {
+		return pbkdf2.Key(password, b, 1, keyLen, sha256.New), nil
+	}
+
+	key = pbkdf2.Key(password, b, 1, max(keyLen, sha256.Size), sha256.New)
+
+	if flags&flagPrehash == 0 {
+		h := hmac.New(sha256.New, key[:sha256.Size])
+		h.Write([]byte("Client Key"))
+
+		stored := sha256.Sum256(h.Sum(nil))
+
+		copy(key, stored[:])
+	}
+
+	return key[:keyLen], nil
+}
+
+// smix implements the yescrypt sMix function which handles both the read-write mode and the non read-write modes.
+func smix(b []byte, r, N, p, t, flags int, v, xy []uint64, passwd []byte) {
+	var (
+		rw     = flags&FlagRW != 0
+		s      = 128 * r
+		R      = 16 * r
+		nchunk = N / p
+		ctxs   = make([]*pwxformCtx, p)
+	)
+
+	nloopAll := nchunk
+
+	switch {
+	case rw && t <= 1:
+		if t != 0 {
+			nloopAll *= 2
+		}
+
+		nloopAll = (nloopAll + 2) / 3
+	case rw:
+		nloopAll *= t - 1
+	case t != 0:
+		if t == 1 {
+			nloopAll += (nloopAll + 1) / 2
+		}
+
+		nloopAll *= t
+	}
+
+	nloopRW := 0
+
+	if rw {
+		nloopRW = nloopAll / p
+	}
+
+	nchunk &^= 1
+	nloopAll = (nloopAll + 1) &^ 1
+	nloopRW = (nloopRW + 1) &^ 1
+
+	for i, vchunk := 0, 0; i < p; i, vchunk = i+1, vchunk+nchunk {
+		np := nchunk
+
+		if i == p-1 {
+			np = N - vchunk
+		}
+
+		bp, vp := b[i*s:(i+1)*s], v[vchunk*R:]
+
+		if rw {
+			sbox := make([]uint64, Swords)
+
+			smix1(bp, 1, Sbytes/128, false, sbox, xy, nil)
+
+			ctxs[i] = &pwxformCtx{
+				S2: sbox,
+				S1: sbox[(1<<Swidth)*PWXsimple:],
+				S0: sbox[(1<<Swidth)*PWXsimple*2:],
+			}
+
+			if i == 0 {
+				h := hmac.New(sha256.New, bp[s-64:])
+				h.Write(passwd)
+
+				copy(passwd, h.Sum(nil))
+			}
+		}
+
+		smix1(bp, r, np, rw, vp, xy, ctxs[i])
+		smix2(bp, r, int(p2floor(uint32(np))), nloopRW, rw, vp, xy, ctxs[i])
+	}
+
+	for i := 0; i < p; i++ {
+		smix2(b[i*s:(i+1)*s], r, N, nloopAll-nloopRW, false, v, xy, ctxs[i])
+	}
+}
+
+func smix1(b []byte, r, N int, rw bool, v, xy []uint64, ctx *pwxformCtx) {
+	var (
+		tmp  [8]uint64
+		xpwx [PWXwords]uint64
+	)
+
+	R := 16 * r
+	x, y := xy[:R], xy[R:2*R]
+
+	decodeBlock(b, x, R)
+
+	for i := 0; i < N; i++ {
+		blockCopy(v[i*R:], x, R)
+
+		if rw && i > 1 {
+			j := int(wrap(integer(x, r), uint32(i)))
+			blockXOR(x, v[j*R:], R)
+		}
+
+		if ctx != nil {
+			blockMixPwxform(&xpwx, x, r, ctx)
+		} else {
+			blockMix(&tmp, x, y, r)
+
+			x, y = y, x
+		}
+	}
+
+	encodeBlock(b, x, R)
+}
+
+func smix2(b []byte, r, N, nloop int, rw bool, v, xy []uint64, ctx *pwxformCtx) {
+	if nloop == 0 {
+		return
+	}
+
+	var (
+		tmp  [8]uint64
+		xpwx [PWXwords]uint64
+	)
+
+	R := 16 * r
+	x, y := xy[:R], xy[R:2*R]
+
+	decodeBlock(b, x, R)
+
+	for i := 0; i < nloop; i++ {
+		j := int(integer(x, r) & uint32(N-1))
+
+		blockXOR(x, v[j*R:], R)
+
+		if rw {
+			blockCopy(v[j*R:], x, R)
+		}
+
+		if ctx != nil {
+			blockMixPwxform(&xpwx, x, r, ctx)
+		} else {
+			blockMix(&tmp, x, y, r)
+
+			x, y = y, x
+		}
+	}
+
+	encodeBlock(b, x, R)
+}
+
+// decodeBlock converts the bytes into the shuffled word layout used by the salsa20 and pwxform primitives.
+func decodeBlock(b []byte, x []uint64, R int) {
+	j := 0
+
+	for i := 0; i < R; i++ {
+		lo := binary.LittleEndian.Uint32(b[(j & ^63)|((j*5)&63):])
+		j += 4
+		hi := binary.LittleEndian.Uint32(b[(j & ^63)|((j*5)&63):])
+		j += 4
+
+		x[i] = uint64(lo) | uint64(hi)<<32
+	}
+}
+
+// encodeBlock is the inverse of decodeBlock.
+func encodeBlock(b []byte, x []uint64, R int) {
+	j := 0
+
+	for _, w := range x[:R] {
+		binary.LittleEndian.PutUint32(b[(j & ^63)|((j*5)&63):], uint32(w))
+		j += 4
+		binary.LittleEndian.PutUint32(b[(j & ^63)|((j*5)&63):], uint32(w>>32))
+		j += 4
+	}
+}
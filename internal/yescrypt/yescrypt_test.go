@@ -0,0 +1,157 @@
+package yescrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-crypt/x/scrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	testCases := []struct {
+		name    string
+		encoded string
+	}{
+		{"ShouldMatchDefault", "$y$j75$saltsaltsaltsalt$hI02SdBpr3mSssvBRd05Dwe0nTFc/hsy01KTxh646J."},
+		{"ShouldMatchDefaultPrehash", "$y$jD5$saltsaltsaltsalt$lEQv88uwGMHoETBCG/T//.oeMhXTT3rHToiyhUiV8E."},
+		{"ShouldMatchDefaultLargeBlockSize", "$y$j9T$saltsaltsaltsalt$Uxvkjnhdr/2B6SINV1mXACdXVbd5kc899ms5aqhxMQD"},
+		{"ShouldMatchParallelism", "$y$j75..$saltsaltsaltsalt$m89vc6HyWxsfkkH188K53LeyR7/KCYBs.jfnq610747"},
+		{"ShouldMatchTime", "$y$j75/.$saltsaltsaltsalt$sFYLocQqUtAxuWjBNStLIcLA4LRy9tBVhDBqtZfP/a5"},
+		{"ShouldMatchParallelismAndTime", "$y$j750//$saltsaltsaltsalt$E.bpTc857WEVvUt4el8pJg8f.Swhxr4xLSauXF3jnX6"},
+		{"ShouldMatchLargeParallelism", "$y$j75.km$saltsaltsaltsalt$.xLx7hGdQJGmkjn5/bpFjFoExI3tXwyZql75TDFuf.7"},
+		{"ShouldMatchWORM", "$y$/75$saltsaltsaltsalt$ajDS8nt1YQa5qYzZoBxnzBW5fej7mTblixS1htlz7L4"},
+		{"ShouldMatchWORMTime", "$y$/75/0$saltsaltsaltsalt$f0JoskSkZjbx1qvxlySyL0VEiddodkrUB1sQD0O0364"},
+		{"ShouldMatchScrypt", "$y$.75$saltsaltsaltsalt$htRE.RgnnyJvLmIBls.xMqAJWqQLqZrpM8.Zksar5H."},
+		{"ShouldMatchScryptLargeParallelism", "$y$.75.km$saltsaltsaltsalt$0u7YqPtTKsm4FMcNZ8UP7ZXJGz6zcsxobOl9jeR71R1"},
+		{"ShouldMatchEmptySalt", "$y$j75$$MY7LY7iSiXDbIK//WLX8B9MRa5LUgGVUicMJCn3sKE1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts := strings.Split(tc.encoded, "$")
+			require.Len(t, parts, 5)
+
+			setting, err := DecodeSetting(parts[2])
+			require.NoError(t, err)
+
+			assert.Equal(t, parts[2], setting.Encode())
+
+			salt := Decode64([]byte(parts[3]))
+			require.NotNil(t, salt)
+
+			key, err := Key([]byte("password"), salt, setting.Flags, 1<<setting.LN, setting.R, setting.P, setting.T, setting.LNROM, setting.G, 32)
+			require.NoError(t, err)
+
+			assert.Equal(t, parts[4], string(Encode64(key)))
+		})
+	}
+}
+
+func TestKeyScryptEquivalence(t *testing.T) {
+	expected, err := scrypt.Key([]byte("password"), []byte("saltsalt"), 1024, 8, 2, 64)
+	require.NoError(t, err)
+
+	actual, err := Key([]byte("password"), []byte("saltsalt"), FlagsScrypt, 1024, 8, 2, 0, 0, 0, 64)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestKeyErrors(t *testing.T) {
+	testCases := []struct {
+		name                             string
+		flags, N, r, p, t, nrom, g, klen int
+		err                              string
+	}{
+		{"ShouldErrROM", FlagsDefault, 1024, 8, 1, 0, 1 << 20, 0, 32, "yescrypt: ROM is not supported"},
+		{"ShouldErrUpgrades", FlagsDefault, 1024, 8, 1, 0, 0, 1, 32, "yescrypt: hash upgrades are not supported"},
+		{"ShouldErrFlavor", FlagRW, 1024, 8, 1, 0, 0, 0, 32, "yescrypt: flags are not supported"},
+		{"ShouldErrScryptTime", FlagsScrypt, 1024, 8, 1, 1, 0, 0, 32, "yescrypt: t must be 0 when using the classic scrypt mode"},
+		{"ShouldErrN", FlagsDefault, 1000, 8, 1, 0, 0, 0, 32, "yescrypt: N must be > 3 and a power of 2"},
+		{"ShouldErrR", FlagsDefault, 1024, 0, 1, 0, 0, 0, 32, "yescrypt: r must be > 0"},
+		{"ShouldErrP", FlagsDefault, 1024, 8, 0, 0, 0, 0, 32, "yescrypt: p must be > 0"},
+		{"ShouldErrNP", FlagsDefault, 16, 8, 8, 0, 0, 0, 32, "yescrypt: N / p must be > 3"},
+		{"ShouldErrKeyLength", FlagsDefault, 1024, 8, 1, 0, 0, 0, 0, "yescrypt: key length is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Key([]byte("password"), []byte("salt"), tc.flags, tc.N, tc.r, tc.p, tc.t, tc.nrom, tc.g, tc.klen)
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecodeSetting(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Setting
+		err      string
+	}{
+		{"ShouldDecodeDefault", "j9T", Setting{Flags: FlagsDefault, LN: 12, R: 32, P: 1}, ""},
+		{"ShouldDecodeWORM", "/75", Setting{Flags: FlagWORM, LN: 10, R: 8, P: 1}, ""},
+		{"ShouldDecodeScrypt", ".75", Setting{Flags: FlagsScrypt, LN: 10, R: 8, P: 1}, ""},
+		{"ShouldDecodeAll", "k.75C./.H", Setting{Flags: FlagsDefault + 4, LN: 10, R: 8, P: 2, T: 2, G: 1, LNROM: 20}, ""},
+		{"ShouldDecodeLargeParallelism", "j75.km", Setting{Flags: FlagsDefault, LN: 10, R: 8, P: 100}, ""},
+		{"ShouldErrEmpty", "", Setting{}, "yescrypt: bad setting"},
+		{"ShouldErrMissingR", "j7", Setting{}, "yescrypt: bad setting"},
+		{"ShouldErrInvalidCharacter", "j7$", Setting{}, "yescrypt: bad setting"},
+		{"ShouldErrMissingOptional", "j75.", Setting{}, "yescrypt: bad setting"},
+		{"ShouldErrTrailing", "j75..x", Setting{}, "yescrypt: bad setting"},
+		{"ShouldErrTruncatedMultiCharacter", "j75.k", Setting{}, "yescrypt: bad setting"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := DecodeSetting(tc.have)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.have, actual.Encode())
+		})
+	}
+}
+
+func TestDecodeScryptSetting(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected Setting
+		salt     string
+		err      string
+	}{
+		{"ShouldDecode", "CU..../....saltsalt", Setting{LN: 14, R: 32, P: 1}, "saltsalt", ""},
+		{"ShouldDecodeParallelism", "A/....0....s", Setting{LN: 12, R: 1, P: 2}, "s", ""},
+		{"ShouldDecodeEmptySalt", "AU..../....", Setting{LN: 12, R: 32, P: 1}, "", ""},
+		{"ShouldErrShort", "AU..../...", Setting{}, "", "scrypt: bad setting"},
+		{"ShouldErrInvalidCharacter", "AU..$./....", Setting{}, "", "scrypt: bad setting"},
+		{"ShouldErrZeroP", "AU........", Setting{}, "", "scrypt: bad setting"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, salt, err := DecodeScryptSetting(tc.have)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.salt, salt)
+			assert.Equal(t, tc.have, EncodeScryptSetting(actual)+salt)
+		})
+	}
+}
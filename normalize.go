@@ -1,42 +1,16 @@
 package crypt
 
-import (
-	"fmt"
-	"regexp"
-	"strings"
-)
-
-var (
-	reAlgorithmPrefixPBKDF2 = regexp.MustCompile(`^\{(?P<identifier>PBKDF2(-SHA\d+)?)}(?P<remainder>\d+\$.*)$`)
-)
-
 // Normalize performs normalization on an encoded digest. This removes prefixes which are not necessary and performs
-// minimal modification to the encoded digest to make it possible for decoding.
+// minimal modification to the encoded digest to make it possible for decoding. This uses the input side of the
+// built-in Format's, see NewFormatLDAPCrypt, NewFormatLDAPArgon2, and NewFormatLDAPPBKDF2.
 func Normalize(encodedDigest string) string {
-	if strings.HasPrefix(encodedDigest, StorageFormatPrefixLDAPCrypt) {
-		encodedDigest = encodedDigest[7:]
-	}
+	var normalizers []decodeNormalizer
 
-	if strings.HasPrefix(encodedDigest, StorageFormatPrefixLDAPArgon2) {
-		encodedDigest = encodedDigest[8:]
+	for _, format := range formatsDefault() {
+		normalizers = append(normalizers, newDecodeNormalizer(format))
 	}
 
-	matchesPBKDF2 := reAlgorithmPrefixPBKDF2.FindStringSubmatch(encodedDigest)
-
-	if len(matchesPBKDF2) != 0 {
-		var identifier, remainder string
-
-		for g, group := range reAlgorithmPrefixPBKDF2.SubexpNames() {
-			switch group {
-			case "identifier":
-				identifier = matchesPBKDF2[g]
-			case "remainder":
-				remainder = matchesPBKDF2[g]
-			}
-		}
-
-		encodedDigest = fmt.Sprintf("$%s$%s", strings.ToLower(identifier), remainder)
-	}
+	encodedDigest, _ = normalize(normalizers, encodedDigest)
 
 	return encodedDigest
 }
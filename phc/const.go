@@ -0,0 +1,27 @@
+package phc
+
+const (
+	// Delimiter is the delimiter between each of the segments of a PHC string.
+	Delimiter = '$'
+
+	// ParameterSeparator is the separator between each of the parameters of a PHC string.
+	ParameterSeparator = ','
+
+	// KeyValueSeparator is the separator between the name and value of a parameter of a PHC string.
+	KeyValueSeparator = '='
+
+	// VersionKey is the name of the version segment of a PHC string.
+	VersionKey = "v"
+
+	// IdentifierLengthMax is the maximum length of the identifier of a PHC string.
+	IdentifierLengthMax = 32
+
+	// ParameterKeyLengthMax is the maximum length of the name of a parameter of a PHC string.
+	ParameterKeyLengthMax = 32
+)
+
+const (
+	charSetSymbol = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	charSetValue  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789/+.-"
+	charSetDigit  = "0123456789"
+)
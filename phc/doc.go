@@ -0,0 +1,15 @@
+// Package phc decodes and encodes strings in the PHC string format which is used by many of the algorithms in this
+// module such as argon2, scrypt, and the bcrypt-sha256 family. The format is described by the Password Hashing
+// Competition at https://github.com/P-H-C/phc-string-format/blob/master/phc-sf-spec.md and has the form:
+//
+//	$<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+//
+// The identifier and parameter names are limited to 32 characters from the set [a-z0-9-], parameter values and the
+// salt are limited to the set [a-zA-Z0-9/+.-], and the salt and hash are validated as B64 which is the standard base64
+// encoding without padding unless another encoding is provided. The order of the parameters is retained so a decoded
+// phc.String is encoded losslessly. The phc.Split and phc.DecodeParameters functions are provided for algorithms which
+// use the modular crypt format but are not PHC strings.
+//
+// All errors returned by this package wrap one of the algorithm.ErrEncodedHash errors so third-party algorithms
+// registered with a decoder report the same errors as the built-in algorithms.
+package phc
@@ -0,0 +1,63 @@
+package phc
+
+import (
+	"encoding/base64"
+)
+
+// B64 is the encoding the PHC string format uses for the salt and hash which is the standard base64 encoding without
+// padding.
+var B64 = base64.RawStdEncoding
+
+// Encoding is an encoding which validates the salt or hash of a PHC string. The *base64.Encoding type implements it.
+type Encoding interface {
+	DecodeString(s string) ([]byte, error)
+}
+
+// Opt describes the functional option pattern for the phc.Decode function.
+type Opt func(o *options)
+
+type options struct {
+	hash bool
+
+	salt, key Encoding
+
+	parameters map[string]Encoding
+}
+
+// WithHashRequired requires the PHC string to have both the salt and hash segments.
+func WithHashRequired() Opt {
+	return func(o *options) {
+		o.hash = true
+	}
+}
+
+// WithSaltEncoding validates the salt using the provided phc.Encoding instead of B64. If the encoding is nil the salt
+// is only validated against the PHC salt character set.
+func WithSaltEncoding(encoding Encoding) Opt {
+	return func(o *options) {
+		o.salt = encoding
+	}
+}
+
+// WithHashEncoding validates the hash using the provided phc.Encoding instead of B64. If the encoding is nil the hash
+// is only validated against the PHC salt character set.
+func WithHashEncoding(encoding Encoding) Opt {
+	return func(o *options) {
+		o.key = encoding
+	}
+}
+
+// WithParameterEncoding validates the values of the parameters with the provided keys using the provided phc.Encoding
+// instead of the PHC value character set. This is useful for parameters which contain binary values such as a key
+// identifier or associated data.
+func WithParameterEncoding(encoding Encoding, keys ...string) Opt {
+	return func(o *options) {
+		if o.parameters == nil {
+			o.parameters = make(map[string]Encoding, len(keys))
+		}
+
+		for _, key := range keys {
+			o.parameters[key] = encoding
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package phc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Parameter is a parameter of a PHC string.
+type Parameter struct {
+	Key   string
+	Value string
+}
+
+// Int converts the Value to an int using strconv.Atoi.
+func (p Parameter) Int() (int, error) {
+	return strconv.Atoi(p.Value)
+}
+
+// String is a decoded PHC string. The Salt and Hash are in their encoded form, and the Version is empty when the
+// version segment is omitted.
+type String struct {
+	Identifier string
+	Version    string
+	Parameters []Parameter
+	Salt       string
+	Hash       string
+}
+
+// Decode a PHC string into a phc.String. The salt and hash are validated by decoding them as B64 unless the
+// phc.WithSaltEncoding or phc.WithHashEncoding options are provided.
+func Decode(encoded string, opts ...Opt) (s *String, err error) {
+	o := &options{salt: B64, key: B64}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(encoded) == 0 || encoded[0] != Delimiter {
+		return nil, algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	segments := strings.Split(encoded[1:], string(Delimiter))
+
+	if segments[0] == "" {
+		return nil, algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	s = &String{Identifier: segments[0]}
+
+	if err = validateIdentifier(s.Identifier); err != nil {
+		return nil, err
+	}
+
+	segments = segments[1:]
+
+	if len(segments) != 0 && isVersion(segments[0]) {
+		s.Version, segments = segments[0][len(VersionKey)+1:], segments[1:]
+
+		if err = validateVersion(s.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	// A segment which precedes both the salt and hash segments can only be the parameters segment, so it's decoded as
+	// such to provide a descriptive error when it's malformed.
+	if len(segments) != 0 && (strings.ContainsRune(segments[0], KeyValueSeparator) || len(segments) == 3) {
+		if s.Parameters, err = decodeParameters(segments[0], o.parameters); err != nil {
+			return nil, err
+		}
+
+		segments = segments[1:]
+	}
+
+	switch len(segments) {
+	case 0:
+		break
+	case 1:
+		if o.hash || segments[0] == "" {
+			return nil, algorithm.ErrEncodedHashInvalidFormat
+		}
+
+		s.Salt = segments[0]
+	case 2:
+		s.Salt, s.Hash = segments[0], segments[1]
+	default:
+		return nil, algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	if o.hash && len(segments) != 2 {
+		return nil, algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	if err = validateSegment(s.Salt, o.salt, algorithm.ErrEncodedHashSaltEncoding, "salt"); err != nil {
+		return nil, err
+	}
+
+	if err = validateSegment(s.Hash, o.key, algorithm.ErrEncodedHashKeyEncoding, "hash"); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Lookup returns the value of the parameter with the provided key and true if the phc.String has the parameter.
+func (s *String) Lookup(key string) (value string, ok bool) {
+	for _, param := range s.Parameters {
+		if param.Key == key {
+			return param.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Validate the phc.String against the PHC string format. The salt and hash are only validated against the character
+// set of the format as the encoding of them is specific to each algorithm.
+func (s *String) Validate() (err error) {
+	if err = validateIdentifier(s.Identifier); err != nil {
+		return err
+	}
+
+	if s.Version != "" {
+		if err = validateVersion(s.Version); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(s.Parameters))
+
+	for _, param := range s.Parameters {
+		if err = validateParameter(param, seen); err != nil {
+			return err
+		}
+	}
+
+	if err = validateSegment(s.Salt, nil, algorithm.ErrEncodedHashSaltEncoding, "salt"); err != nil {
+		return err
+	}
+
+	return validateSegment(s.Hash, nil, algorithm.ErrEncodedHashKeyEncoding, "hash")
+}
+
+// Encode the phc.String in the PHC string format. The salt segment is omitted when both the salt and hash are empty,
+// and the hash segment is omitted when the hash is empty.
+func (s *String) Encode() string {
+	var b strings.Builder
+
+	b.WriteRune(Delimiter)
+	b.WriteString(s.Identifier)
+
+	if s.Version != "" {
+		b.WriteRune(Delimiter)
+		b.WriteString(VersionKey)
+		b.WriteRune(KeyValueSeparator)
+		b.WriteString(s.Version)
+	}
+
+	for i, param := range s.Parameters {
+		if i == 0 {
+			b.WriteRune(Delimiter)
+		} else {
+			b.WriteRune(ParameterSeparator)
+		}
+
+		b.WriteString(param.Key)
+		b.WriteRune(KeyValueSeparator)
+		b.WriteString(param.Value)
+	}
+
+	if s.Salt != "" || s.Hash != "" {
+		b.WriteRune(Delimiter)
+		b.WriteString(s.Salt)
+	}
+
+	if s.Hash != "" {
+		b.WriteRune(Delimiter)
+		b.WriteString(s.Hash)
+	}
+
+	return b.String()
+}
+
+// String implements the fmt.Stringer returning the encoded phc.String.
+func (s *String) String() string {
+	return s.Encode()
+}
+
+// Split the encoded string by the phc.Delimiter into at most n segments with the same semantics as strings.SplitN. It's
+// useful for algorithms which use the modular crypt format but which aren't PHC strings.
+func Split(encoded string, n int) (segments []string) {
+	return strings.SplitN(encoded, string(Delimiter), n)
+}
+
+// DecodeParameters decodes a parameters segment such as 'm=65536,t=3,p=4' into a []phc.Parameter validating the names
+// and values the same way as phc.Decode.
+func DecodeParameters(segment string) (params []Parameter, err error) {
+	return decodeParameters(segment, nil)
+}
+
+func isVersion(segment string) bool {
+	return strings.HasPrefix(segment, VersionKey+string(KeyValueSeparator)) && !strings.ContainsRune(segment, ParameterSeparator)
+}
+
+func decodeParameters(segment string, encodings map[string]Encoding) (params []Parameter, err error) {
+	items := strings.Split(segment, string(ParameterSeparator))
+
+	params = make([]Parameter, 0, len(items))
+	seen := make(map[string]bool, len(items))
+
+	for _, item := range items {
+		key, value, found := strings.Cut(item, string(KeyValueSeparator))
+
+		if !found {
+			return nil, fmt.Errorf("%w: parameter pair '%s' is not properly encoded: does not contain kv separator '%c'", algorithm.ErrEncodedHashInvalidOption, item, KeyValueSeparator)
+		}
+
+		param := Parameter{Key: key, Value: value}
+
+		if encoding, ok := encodings[key]; ok && encoding != nil && !seen[key] {
+			if _, err = encoding.DecodeString(value); err != nil {
+				return nil, fmt.Errorf("%w: option '%s' has invalid value '%s': %v", algorithm.ErrEncodedHashInvalidOptionValue, key, value, err)
+			}
+		}
+
+		if err = validateParameter(param, seen); err != nil {
+			return nil, err
+		}
+
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+func validateIdentifier(identifier string) (err error) {
+	if len(identifier) == 0 || len(identifier) > IdentifierLengthMax || !inCharSet(identifier, charSetSymbol) {
+		return fmt.Errorf("%w: identifier '%s' must be between 1 and %d characters from the set [a-z0-9-]", algorithm.ErrEncodedHashInvalidIdentifier, identifier, IdentifierLengthMax)
+	}
+
+	return nil
+}
+
+func validateVersion(version string) (err error) {
+	if len(version) == 0 || !inCharSet(version, charSetDigit) {
+		return fmt.Errorf("%w: version '%s' must be a decimal number", algorithm.ErrEncodedHashInvalidVersion, version)
+	}
+
+	return nil
+}
+
+func validateParameter(param Parameter, seen map[string]bool) (err error) {
+	switch {
+	case len(param.Key) == 0 || len(param.Key) > ParameterKeyLengthMax || !inCharSet(param.Key, charSetSymbol):
+		return fmt.Errorf("%w: parameter '%s' must have a name between 1 and %d characters from the set [a-z0-9-]", algorithm.ErrEncodedHashInvalidOptionKey, param.Key, ParameterKeyLengthMax)
+	case seen[param.Key]:
+		return fmt.Errorf("%w: parameter '%s' must not be specified more than once", algorithm.ErrEncodedHashInvalidOptionKey, param.Key)
+	case len(param.Value) == 0 || !inCharSet(param.Value, charSetValue):
+		return fmt.Errorf("%w: parameter '%s' has value '%s' which must be one or more characters from the set [a-zA-Z0-9/+.-]", algorithm.ErrEncodedHashInvalidOptionValue, param.Key, param.Value)
+	}
+
+	seen[param.Key] = true
+
+	return nil
+}
+
+func validateSegment(segment string, encoding Encoding, sentinel error, name string) (err error) {
+	if encoding != nil {
+		if _, err = encoding.DecodeString(segment); err != nil {
+			return fmt.Errorf("%w: %v", sentinel, err)
+		}
+
+		return nil
+	}
+
+	if !inCharSet(segment, charSetValue) {
+		return fmt.Errorf("%w: %s must only contain characters from the set [a-zA-Z0-9/+.-]", sentinel, name)
+	}
+
+	return nil
+}
+
+func inCharSet(value, charset string) bool {
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(charset, value[i]) == -1 {
+			return false
+		}
+	}
+
+	return true
+}
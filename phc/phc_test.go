@@ -0,0 +1,227 @@
+package phc
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		opts     []Opt
+		expected *String
+	}{
+		{
+			"ShouldDecodeFull",
+			"$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHQ$aGFzaGhhc2g",
+			nil,
+			&String{Identifier: "argon2id", Version: "19", Parameters: []Parameter{{"m", "65536"}, {"t", "3"}, {"p", "4"}}, Salt: "c2FsdHNhbHQ", Hash: "aGFzaGhhc2g"},
+		},
+		{
+			"ShouldDecodeWithoutVersion",
+			"$argon2id$m=65536,t=3,p=4$c2FsdHNhbHQ$aGFzaGhhc2g",
+			nil,
+			&String{Identifier: "argon2id", Parameters: []Parameter{{"m", "65536"}, {"t", "3"}, {"p", "4"}}, Salt: "c2FsdHNhbHQ", Hash: "aGFzaGhhc2g"},
+		},
+		{
+			"ShouldDecodeVersionParameterWithOthers",
+			"$bcrypt-sha256$v=2,t=2b,r=12$n79VH.0Q2TMWmt3Oqt9uku$Kq4Noyk3094Y2QlB8NdRT8SvGiI4ft2",
+			[]Opt{WithSaltEncoding(nil), WithHashEncoding(nil)},
+			&String{Identifier: "bcrypt-sha256", Parameters: []Parameter{{"v", "2"}, {"t", "2b"}, {"r", "12"}}, Salt: "n79VH.0Q2TMWmt3Oqt9uku", Hash: "Kq4Noyk3094Y2QlB8NdRT8SvGiI4ft2"},
+		},
+		{
+			"ShouldDecodeIdentifierOnly",
+			"$plain",
+			nil,
+			&String{Identifier: "plain"},
+		},
+		{
+			"ShouldDecodeSaltOnly",
+			"$scrypt$ln=16,r=8,p=1$c2FsdA",
+			nil,
+			&String{Identifier: "scrypt", Parameters: []Parameter{{"ln", "16"}, {"r", "8"}, {"p", "1"}}, Salt: "c2FsdA"},
+		},
+		{
+			"ShouldDecodeEmptySalt",
+			"$legacy$r=bWQ1KCRwYXNzKQ$$X03MO1qnZdYdgyfeuILPmQ",
+			[]Opt{WithHashRequired()},
+			&String{Identifier: "legacy", Parameters: []Parameter{{"r", "bWQ1KCRwYXNzKQ"}}, Hash: "X03MO1qnZdYdgyfeuILPmQ"},
+		},
+		{
+			"ShouldDecodeSaltAndHashWithoutParameters",
+			"$id$c2FsdA$aGFzaA",
+			nil,
+			&String{Identifier: "id", Salt: "c2FsdA", Hash: "aGFzaA"},
+		},
+		{
+			"ShouldDecodeWithAlternativeEncoding",
+			"$id$c2-sdA$aG-zaA",
+			[]Opt{WithSaltEncoding(base64.RawURLEncoding), WithHashEncoding(base64.RawURLEncoding)},
+			&String{Identifier: "id", Salt: "c2-sdA", Hash: "aG-zaA"},
+		},
+		{
+			"ShouldDecodeWithParameterEncoding",
+			"$argon2id$m=1,keyid=AAECAw,data=BAUGBw$c2FsdA$aGFzaA",
+			[]Opt{WithParameterEncoding(B64, "keyid", "data")},
+			&String{Identifier: "argon2id", Parameters: []Parameter{{"m", "1"}, {"keyid", "AAECAw"}, {"data", "BAUGBw"}}, Salt: "c2FsdA", Hash: "aGFzaA"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Decode(tc.have, tc.opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.NoError(t, actual.Validate())
+			assert.Equal(t, tc.have, actual.Encode())
+			assert.Equal(t, tc.have, actual.String())
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have string
+		opts []Opt
+		err  string
+	}{
+		{"ShouldErrEmpty", "", nil, "provided encoded hash has an invalid format"},
+		{"ShouldErrNoDelimiter", "argon2id", nil, "provided encoded hash has an invalid format"},
+		{"ShouldErrEmptyIdentifier", "$", nil, "provided encoded hash has an invalid format"},
+		{"ShouldErrIdentifierCharSet", "$Argon2id$m=1$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid identifier: identifier 'Argon2id' must be between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrIdentifierLength", "$" + strings.Repeat("a", 33), nil, "provided encoded hash has an invalid identifier: identifier '" + strings.Repeat("a", 33) + "' must be between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrVersion", "$argon2id$v=a$m=1$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid version: version 'a' must be a decimal number"},
+		{"ShouldErrVersionEmpty", "$argon2id$v=$m=1$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid version: version '' must be a decimal number"},
+		{"ShouldErrParameterSeparator", "$argon2id$m=1,t$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option: parameter pair 't' is not properly encoded: does not contain kv separator '='"},
+		{"ShouldErrParameterMalformed", "$bcrypt-sha512$2b,10$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option: parameter pair '2b' is not properly encoded: does not contain kv separator '='"},
+		{"ShouldErrParameterKeyCharSet", "$argon2id$M=1$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option key: parameter 'M' must have a name between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrParameterKeyEmpty", "$argon2id$=1$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option key: parameter '' must have a name between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrParameterKeyDuplicate", "$argon2id$m=1,m=2$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option key: parameter 'm' must not be specified more than once"},
+		{"ShouldErrParameterValueEmpty", "$argon2id$m=$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option value: parameter 'm' has value '' which must be one or more characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrParameterValueCharSet", "$argon2id$m=1_0$c2FsdA$aGFzaA", nil, "provided encoded hash has an invalid option value: parameter 'm' has value '1_0' which must be one or more characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrTooManySegments", "$argon2id$v=19$m=1$c2FsdA$aGFzaA$aGFzaA", nil, "provided encoded hash has an invalid format"},
+		{"ShouldErrHashRequired", "$argon2id$v=19$m=1$c2FsdA", []Opt{WithHashRequired()}, "provided encoded hash has an invalid format"},
+		{"ShouldErrTrailingDelimiter", "$argon2id$v=19$m=1$", nil, "provided encoded hash has an invalid format"},
+		{"ShouldErrSaltEncoding", "$argon2id$m=1$c2FsdA==$aGFzaA", nil, "provided encoded hash has a salt value that can't be decoded: illegal base64 data at input byte 6"},
+		{"ShouldErrHashEncoding", "$argon2id$m=1$c2FsdA$aGFzaA!", nil, "provided encoded hash has a key value that can't be decoded: illegal base64 data at input byte 6"},
+		{"ShouldErrSaltCharSet", "$argon2id$m=1$c2Fs_A$aGFzaA", []Opt{WithSaltEncoding(nil)}, "provided encoded hash has a salt value that can't be decoded: salt must only contain characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrHashCharSet", "$argon2id$m=1$c2FsdA$aGFz_A", []Opt{WithHashEncoding(nil)}, "provided encoded hash has a key value that can't be decoded: hash must only contain characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrParameterEncoding", "$argon2id$m=1,keyid=!!$c2FsdA$aGFzaA", []Opt{WithParameterEncoding(B64, "keyid", "data")}, "provided encoded hash has an invalid option value: option 'keyid' has invalid value '!!': illegal base64 data at input byte 0"},
+		{"ShouldErrParameterEncodingDuplicate", "$argon2id$keyid=AA,keyid=!!$c2FsdA$aGFzaA", []Opt{WithParameterEncoding(B64, "keyid")}, "provided encoded hash has an invalid option key: parameter 'keyid' must not be specified more than once"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Decode(tc.have, tc.opts...)
+
+			assert.Nil(t, actual)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	s := &String{
+		Identifier: "pbkdf2-sha256",
+		Parameters: []Parameter{{"i", "29000"}, {"l", "32"}},
+		Salt:       "c2FsdA",
+		Hash:       "aGFzaA",
+	}
+
+	require.NoError(t, s.Validate())
+	assert.Equal(t, "$pbkdf2-sha256$i=29000,l=32$c2FsdA$aGFzaA", s.Encode())
+
+	value, ok := s.Lookup("i")
+	assert.True(t, ok)
+	assert.Equal(t, "29000", value)
+
+	value, ok = s.Lookup("x")
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	i, err := s.Parameters[1].Int()
+	assert.NoError(t, err)
+	assert.Equal(t, 32, i)
+
+	decoded, err := Decode(s.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, s, decoded)
+}
+
+func TestStringValidateErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		have *String
+		err  string
+	}{
+		{"ShouldErrIdentifier", &String{}, "provided encoded hash has an invalid identifier: identifier '' must be between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrVersion", &String{Identifier: "id", Version: "1.3"}, "provided encoded hash has an invalid version: version '1.3' must be a decimal number"},
+		{"ShouldErrParameterKeyLength", &String{Identifier: "id", Parameters: []Parameter{{strings.Repeat("k", 33), "1"}}}, "provided encoded hash has an invalid option key: parameter '" + strings.Repeat("k", 33) + "' must have a name between 1 and 32 characters from the set [a-z0-9-]"},
+		{"ShouldErrParameterKeyDuplicate", &String{Identifier: "id", Parameters: []Parameter{{"a", "1"}, {"a", "2"}}}, "provided encoded hash has an invalid option key: parameter 'a' must not be specified more than once"},
+		{"ShouldErrParameterValue", &String{Identifier: "id", Parameters: []Parameter{{"a", "$"}}}, "provided encoded hash has an invalid option value: parameter 'a' has value '$' which must be one or more characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrSalt", &String{Identifier: "id", Salt: "a$b"}, "provided encoded hash has a salt value that can't be decoded: salt must only contain characters from the set [a-zA-Z0-9/+.-]"},
+		{"ShouldErrHash", &String{Identifier: "id", Salt: "ab", Hash: "a=="}, "provided encoded hash has a key value that can't be decoded: hash must only contain characters from the set [a-zA-Z0-9/+.-]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.have.Validate(), tc.err)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		n        int
+		expected []string
+	}{
+		{"ShouldSplit", "$argon2id$v=19$m=65536,t=3,p=4$salt$key", 3, []string{"", "argon2id", "v=19$m=65536,t=3,p=4$salt$key"}},
+		{"ShouldSplitUnlimited", "$5$salt$key", -1, []string{"", "5", "salt", "key"}},
+		{"ShouldSplitEmptyString", "", 3, []string{""}},
+		{"ShouldSplitNoDelimiters", "nope", 3, []string{"nope"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Split(tc.have, tc.n))
+		})
+	}
+}
+
+func TestDecodeParameters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected []Parameter
+		err      string
+	}{
+		{"ShouldDecodeParameters", "m=65536,t=3,p=4", []Parameter{{"m", "65536"}, {"t", "3"}, {"p", "4"}}, ""},
+		{"ShouldDecodeSingleParameter", "rounds=5000", []Parameter{{"rounds", "5000"}}, ""},
+		{"ShouldErrEmpty", "", nil, "provided encoded hash has an invalid option: parameter pair '' is not properly encoded: does not contain kv separator '='"},
+		{"ShouldErrSeparator", "abc", nil, "provided encoded hash has an invalid option: parameter pair 'abc' is not properly encoded: does not contain kv separator '='"},
+		{"ShouldErrValue", "rounds=5$00", nil, "provided encoded hash has an invalid option value: parameter 'rounds' has value '5$00' which must be one or more characters from the set [a-zA-Z0-9/+.-]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := DecodeParameters(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.Nil(t, actual)
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
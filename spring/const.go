@@ -0,0 +1,60 @@
+package spring
+
+const (
+	// AlgName is the name for this package.
+	AlgName = "spring"
+
+	// AlgIdentifier is the identifier the decoder for this package is registered with.
+	AlgIdentifier = AlgName
+
+	// FormatName is the name of the crypt.Format implemented by the spring.Encoder.
+	FormatName = AlgName
+
+	// IDBcrypt is the id of the Spring BCryptPasswordEncoder.
+	IDBcrypt = "bcrypt"
+
+	// IDNoop is the id of the Spring NoOpPasswordEncoder.
+	IDNoop = "noop"
+
+	// IDPBKDF2 is the id of the Spring Pbkdf2PasswordEncoder using the Spring Security 5.5 defaults.
+	IDPBKDF2 = "pbkdf2"
+
+	// IDPBKDF2V5_8 is the id of the Spring Pbkdf2PasswordEncoder using the Spring Security 5.8 defaults.
+	IDPBKDF2V5_8 = "pbkdf2@SpringSecurity_v5_8"
+
+	// IDSCrypt is the id of the Spring SCryptPasswordEncoder using the Spring Security 4.1 defaults.
+	IDSCrypt = "scrypt"
+
+	// IDSCryptV5_8 is the id of the Spring SCryptPasswordEncoder using the Spring Security 5.8 defaults.
+	IDSCryptV5_8 = "scrypt@SpringSecurity_v5_8"
+
+	// IDArgon2 is the id of the Spring Argon2PasswordEncoder using the Spring Security 5.2 defaults.
+	IDArgon2 = "argon2"
+
+	// IDArgon2V5_8 is the id of the Spring Argon2PasswordEncoder using the Spring Security 5.8 defaults.
+	IDArgon2V5_8 = "argon2@SpringSecurity_v5_8"
+
+	// IDStandard is the id of the Spring StandardPasswordEncoder.
+	IDStandard = "sha256"
+
+	// IDMD5 is the id of the Spring MessageDigestPasswordEncoder using MD5.
+	IDMD5 = "MD5"
+
+	// IDSHA1 is the id of the Spring MessageDigestPasswordEncoder using SHA-1.
+	IDSHA1 = "SHA-1"
+
+	// IDSHA256 is the id of the Spring MessageDigestPasswordEncoder using SHA-256.
+	IDSHA256 = "SHA-256"
+
+	// EncodingFmtSCrypt is the encoding format of the Spring SCryptPasswordEncoder without the id.
+	EncodingFmtSCrypt = "$%x$%s$%s"
+
+	// StandardIterations is the number of iterations used by the Spring StandardPasswordEncoder.
+	StandardIterations = 1024
+
+	// StandardSaltLength is the salt size used by the Spring StandardPasswordEncoder.
+	StandardSaltLength = 8
+
+	// SCryptKeyLength is the key size used by the Spring SCryptPasswordEncoder.
+	SCryptKeyLength = 32
+)
@@ -0,0 +1,100 @@
+package spring
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// Digest is an algorithm.Digest which handles the legacy Spring StandardPasswordEncoder and
+// MessageDigestPasswordEncoder formats.
+type Digest struct {
+	variant Variant
+
+	secret, salt, key []byte
+}
+
+// Match returns true if the string password matches the current spring.Digest.
+func (d *Digest) Match(password string) (match bool) {
+	return d.MatchBytes([]byte(password))
+}
+
+// MatchBytes returns true if the []byte passwordBytes matches the current spring.Digest.
+func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
+	match, _ = d.MatchBytesAdvanced(passwordBytes)
+
+	return match
+}
+
+// MatchAdvanced is the same as Match except if there is an error it returns that as well.
+func (d *Digest) MatchAdvanced(password string) (match bool, err error) {
+	return d.MatchBytesAdvanced([]byte(password))
+}
+
+// MatchBytesAdvanced is the same as MatchBytes except if there is an error it returns that as well.
+func (d *Digest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err error) {
+	if len(d.key) == 0 {
+		return false, fmt.Errorf(algorithm.ErrFmtDigestMatch, AlgName, fmt.Errorf("%w: key has 0 bytes", algorithm.ErrPasswordInvalid))
+	}
+
+	return subtle.ConstantTimeCompare(d.key, d.derive(passwordBytes)) == 1, nil
+}
+
+// Encode returns the encoded form of this spring.Digest.
+func (d *Digest) Encode() string {
+	switch d.variant {
+	case VariantStandard:
+		return d.variant.Prefix() + hex.EncodeToString(append(append([]byte{}, d.salt...), d.key...))
+	default:
+		return d.variant.Prefix() + string(d.salt) + hex.EncodeToString(d.key)
+	}
+}
+
+// String returns the storable format of the spring.Digest encoded hash.
+func (d *Digest) String() string {
+	return d.Encode()
+}
+
+// Key returns the raw unencoded key which is the final result of this digest.
+func (d *Digest) Key() (key []byte) {
+	return d.key
+}
+
+// Salt returns the raw unencoded salt used to generate this digest. The salt of the MessageDigestPasswordEncoder
+// variants includes the surrounding braces as this is the value appended to the password.
+func (d *Digest) Salt() (salt []byte) {
+	return d.salt
+}
+
+// Variant returns the spring.Variant of this spring.Digest.
+func (d *Digest) Variant() (variant Variant) {
+	return d.variant
+}
+
+func (d *Digest) derive(passwordBytes []byte) (key []byte) {
+	h := d.variant.HashFunc()()
+
+	if d.variant != VariantStandard {
+		h.Write(passwordBytes)
+		h.Write(d.salt)
+
+		return h.Sum(nil)
+	}
+
+	h.Write(d.salt)
+	h.Write(d.secret)
+	h.Write(passwordBytes)
+
+	key = h.Sum(nil)
+
+	for i := 1; i < StandardIterations; i++ {
+		h.Reset()
+		h.Write(key)
+
+		key = h.Sum(key[:0])
+	}
+
+	return key
+}
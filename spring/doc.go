@@ -0,0 +1,14 @@
+// Package spring provides compatibility with the Spring Security DelegatingPasswordEncoder storage formats and
+// implements github.com/go-crypt/crypt interfaces.
+//
+// The bcrypt and argon2 formats are decoded into the bcrypt.Digest and argon2.Digest and retain the id as their
+// storage prefix. The pbkdf2, scrypt, and noop formats use layouts specific to Spring and are decoded into the
+// pbkdf2.Digest, scrypt.Digest, and plaintext.Digest respectively. The legacy sha256, MD5, SHA-1, and SHA-256 formats
+// are decoded into a spring.Digest. The spring.Encoder.Encode method encodes any of these digests in the format Spring
+// expects, and the spring.Encoder implements the crypt.Format interface so it can be registered with a crypt.Decoder.
+//
+// The parameters of the Spring Pbkdf2PasswordEncoder and StandardPasswordEncoder are not included in the encoded
+// digest, the spring.Encoder uses the Spring defaults for each id unless configured otherwise.
+//
+// This implementation is not loaded by any of the crypt decoders and must be registered explicitly.
+package spring
@@ -0,0 +1,461 @@
+package spring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/plaintext"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+	"github.com/go-crypt/crypt/internal/encoding"
+	"github.com/go-crypt/crypt/phc"
+)
+
+// RegisterDecoder the decoder using the Spring defaults with the algorithm.DecoderMatchRegister.
+func RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	var e *Encoder
+
+	if e, err = New(); err != nil {
+		return err
+	}
+
+	return e.RegisterDecoder(r)
+}
+
+// Decode the encoded digest into a algorithm.Digest using the Spring defaults.
+func Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	var e *Encoder
+
+	if e, err = New(); err != nil {
+		return nil, err
+	}
+
+	return e.Decode(encodedDigest)
+}
+
+// Encode the algorithm.Digest in the format Spring expects using the Spring defaults.
+func Encode(digest algorithm.Digest) (value string, err error) {
+	var e *Encoder
+
+	if e, err = New(); err != nil {
+		return "", err
+	}
+
+	return e.Encode(digest)
+}
+
+// New returns a *spring.Encoder with the additional opts applied if any.
+func New(opts ...Opt) (encoder *Encoder, err error) {
+	encoder = &Encoder{
+		pbkdf2: []encoderPBKDF2{
+			{id: IDPBKDF2, config: PBKDF2{Variant: pbkdf2.VariantSHA1, Iterations: 185000, SaltLength: 8, KeyLength: 32}},
+			{id: IDPBKDF2V5_8, config: PBKDF2{Variant: pbkdf2.VariantSHA256, Iterations: 310000, SaltLength: 16, KeyLength: 32}},
+		},
+	}
+
+	for _, opt := range opts {
+		if err = opt(encoder); err != nil {
+			return nil, err
+		}
+	}
+
+	return encoder, nil
+}
+
+// Encoder decodes and encodes the formats of the Spring DelegatingPasswordEncoder which can be initialized via
+// spring.New using a functional options pattern. It implements the crypt.Format interface so it can be registered with
+// a crypt.Decoder via its RegisterFormat method and used with its EncodeAs method.
+type Encoder struct {
+	pbkdf2 []encoderPBKDF2
+	secret []byte
+}
+
+type encoderPBKDF2 struct {
+	id     string
+	config PBKDF2
+}
+
+// RegisterDecoder the decoder with the algorithm.DecoderMatchRegister. The decoder is selected for encoded digests
+// which begin with a known id such as {bcrypt} or {pbkdf2}.
+func (e *Encoder) RegisterDecoder(r algorithm.DecoderMatchRegister) (err error) {
+	if err = r.RegisterDecodeFunc(AlgIdentifier, e.Decode); err != nil {
+		return err
+	}
+
+	if err = r.RegisterDecodeMatchFunc(AlgIdentifier, e.MatchEncoded); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MatchEncoded returns true if the encoded digest begins with a known id.
+func (e *Encoder) MatchEncoded(encodedDigest string) (match bool) {
+	id, _, err := decoderParts(encodedDigest)
+
+	return err == nil && e.known(id)
+}
+
+// Decode the encoded digest into a algorithm.Digest. The bcrypt and argon2 formats are decoded into the
+// algorithm.Digest of the relevant package with the id retained as the storage prefix, the pbkdf2, scrypt, and noop
+// formats are decoded into the pbkdf2.Digest, scrypt.Digest, and plaintext.Digest respectively, and all other formats
+// are decoded into a spring.Digest.
+func (e *Encoder) Decode(encodedDigest string) (digest algorithm.Digest, err error) {
+	var id, data string
+
+	if id, data, err = decoderParts(encodedDigest); err != nil {
+		return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+	}
+
+	switch id {
+	case IDBcrypt:
+		return decodeStoragePrefix(bcrypt.DecodeVariant(bcrypt.VariantStandard), id, data)
+	case IDArgon2, IDArgon2V5_8:
+		return decodeStoragePrefix(argon2.Decode, id, data)
+	case IDNoop:
+		return plaintext.DecodeVariant(plaintext.VariantPlainText)(fmt.Sprintf(plaintext.EncodingFmt, plaintext.AlgIdentifierPlainText, data))
+	case IDSCrypt, IDSCryptV5_8:
+		if encodedDigest, err = decodeSCrypt(data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return scrypt.DecodeVariant(scrypt.VariantScrypt)(encodedDigest)
+	}
+
+	if variant := NewVariant(id); variant != VariantNone {
+		if digest, err = e.decodeDigest(variant, data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return digest, nil
+	}
+
+	for _, c := range e.pbkdf2 {
+		if c.id != id {
+			continue
+		}
+
+		if encodedDigest, err = decodePBKDF2(c.config, data); err != nil {
+			return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, err)
+		}
+
+		return pbkdf2.DecodeVariant(c.config.Variant)(encodedDigest)
+	}
+
+	return nil, fmt.Errorf(algorithm.ErrFmtDigestDecode, AlgName, fmt.Errorf("%w: id '%s' is unknown", algorithm.ErrEncodedHashInvalidIdentifier, id))
+}
+
+// Encode the algorithm.Digest in the format Spring expects including the id. The supported digests are the
+// spring.Digest, the standard variant of the bcrypt.Digest, the argon2.Digest without a secret or associated data, the
+// scrypt variant of the scrypt.Digest, the plaintext.Digest, and the pbkdf2.Digest if its parameters match one of the
+// configured Spring Pbkdf2PasswordEncoder ids. The argon2 and scrypt digests are always encoded with the argon2 and
+// scrypt ids respectively as the Spring Security 5.8 ids share the same layout.
+func (e *Encoder) Encode(digest algorithm.Digest) (value string, err error) {
+	if digest == nil {
+		return "", fmt.Errorf("can't encode a nil digest")
+	}
+
+	switch d := digest.(type) {
+	case *Digest:
+		return d.Encode(), nil
+	case *plaintext.Digest:
+		return "{" + IDNoop + "}" + string(d.Key()), nil
+	case *pbkdf2.Digest:
+		return e.encodePBKDF2(d)
+	case *scrypt.Digest:
+		return encodeSCrypt(d)
+	case *bcrypt.Digest:
+		encodedDigest := encodeWithoutStoragePrefix(d)
+
+		if parts := phc.Split(encodedDigest, 3); len(parts) != 3 || bcrypt.NewVariant(parts[1]) != bcrypt.VariantStandard {
+			return "", fmt.Errorf("%s encode error: bcrypt digests must use the standard variant", AlgName)
+		}
+
+		return "{" + IDBcrypt + "}" + encodedDigest, nil
+	case *argon2.Digest:
+		if len(d.KeyID()) != 0 || len(d.AssociatedData()) != 0 {
+			return "", fmt.Errorf("%s encode error: argon2 digests must not use a secret or associated data", AlgName)
+		}
+
+		return "{" + IDArgon2 + "}" + encodeWithoutStoragePrefix(d), nil
+	default:
+		return "", fmt.Errorf("%s encode error: digests of type '%T' are not supported", AlgName, digest)
+	}
+}
+
+// Name returns the name of the crypt.Format implemented by this spring.Encoder which is used with the EncodeAs method of
+// a crypt.Decoder.
+func (e *Encoder) Name() (name string) {
+	return FormatName
+}
+
+// Normalize converts a value which begins with a known id into an encoded digest. The bcrypt and argon2 formats have
+// the id removed, and the pbkdf2, scrypt, and noop formats are converted into the encoded form used by the
+// pbkdf2.Digest, scrypt.Digest, and plaintext.Digest respectively. The legacy formats are returned as is and are only
+// decoded if the spring decoder is also registered.
+func (e *Encoder) Normalize(value string) (encodedDigest string, ok bool) {
+	id, data, err := decoderParts(value)
+
+	if err != nil || !e.known(id) {
+		return value, false
+	}
+
+	switch id {
+	case IDBcrypt, IDArgon2, IDArgon2V5_8:
+		return data, true
+	case IDNoop:
+		return fmt.Sprintf(plaintext.EncodingFmt, plaintext.AlgIdentifierPlainText, data), true
+	case IDSCrypt, IDSCryptV5_8:
+		if encodedDigest, err = decodeSCrypt(data); err != nil {
+			return value, false
+		}
+
+		return encodedDigest, true
+	}
+
+	for _, c := range e.pbkdf2 {
+		if c.id != id {
+			continue
+		}
+
+		if encodedDigest, err = decodePBKDF2(c.config, data); err != nil {
+			return value, false
+		}
+
+		return encodedDigest, true
+	}
+
+	return value, true
+}
+
+func (e *Encoder) known(id string) bool {
+	switch id {
+	case IDBcrypt, IDArgon2, IDArgon2V5_8, IDNoop, IDSCrypt, IDSCryptV5_8:
+		return true
+	}
+
+	if NewVariant(id) != VariantNone {
+		return true
+	}
+
+	for _, c := range e.pbkdf2 {
+		if c.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Encoder) decodeDigest(variant Variant, data string) (digest algorithm.Digest, err error) {
+	decoded := &Digest{
+		variant: variant,
+	}
+
+	if variant != VariantStandard && strings.HasPrefix(data, "{") {
+		end := strings.IndexRune(data, '}')
+
+		if end == -1 {
+			return nil, fmt.Errorf("%w: the salt doesn't have a closing brace", algorithm.ErrEncodedHashSaltEncoding)
+		}
+
+		decoded.salt, data = []byte(data[:end+1]), data[end+1:]
+	}
+
+	var raw []byte
+
+	if raw, err = hex.DecodeString(data); err != nil {
+		return nil, fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	size := variant.HashFunc()().Size()
+
+	if variant == VariantStandard {
+		if len(raw) != StandardSaltLength+size {
+			return nil, fmt.Errorf("%w: salt and key have %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), StandardSaltLength+size)
+		}
+
+		decoded.secret, decoded.salt, raw = e.secret, raw[:StandardSaltLength], raw[StandardSaltLength:]
+	}
+
+	if len(raw) != size {
+		return nil, fmt.Errorf("%w: key has %d bytes but must have %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), size)
+	}
+
+	decoded.key = raw
+
+	return decoded, nil
+}
+
+func (e *Encoder) encodePBKDF2(digest *pbkdf2.Digest) (value string, err error) {
+	parts := phc.Split(digest.Canonical().Encode(), -1)
+
+	if len(parts) != 5 {
+		return "", fmt.Errorf("%s encode error: pbkdf2 digest is not valid", AlgName)
+	}
+
+	var iterations int
+
+	if iterations, err = strconv.Atoi(parts[2]); err != nil {
+		return "", fmt.Errorf("%s encode error: %w", AlgName, err)
+	}
+
+	variant, salt, key := pbkdf2.NewVariant(parts[1]), digest.Salt(), digest.Key()
+
+	for _, c := range e.pbkdf2 {
+		if c.config.Variant != variant || c.config.Iterations != iterations || c.config.KeyLength != len(key) ||
+			len(salt) != c.config.SaltLength+len(c.config.Secret) || !bytes.HasSuffix(salt, c.config.Secret) {
+			continue
+		}
+
+		raw := append(append([]byte{}, salt[:c.config.SaltLength]...), key...)
+
+		if c.config.Base64 {
+			return "{" + c.id + "}" + base64.StdEncoding.EncodeToString(raw), nil
+		}
+
+		return "{" + c.id + "}" + hex.EncodeToString(raw), nil
+	}
+
+	return "", fmt.Errorf("%s encode error: pbkdf2 digest parameters do not match any of the configured ids", AlgName)
+}
+
+func decoderParts(encodedDigest string) (id, data string, err error) {
+	end := strings.IndexRune(encodedDigest, '}')
+
+	if !strings.HasPrefix(encodedDigest, "{") || end == -1 {
+		return "", "", fmt.Errorf("%w: the digest doesn't begin with an id", algorithm.ErrEncodedHashInvalidFormat)
+	}
+
+	return encodedDigest[1:end], encodedDigest[end+1:], nil
+}
+
+func decodeStoragePrefix(decode algorithm.DecodeFunc, id, data string) (digest algorithm.Digest, err error) {
+	if digest, err = decode(data); err != nil {
+		return nil, err
+	}
+
+	if p, ok := digest.(algorithm.StoragePrefixDigest); ok {
+		p.WithStoragePrefix("{" + id + "}")
+	}
+
+	return digest, nil
+}
+
+// decodePBKDF2 converts the Spring Pbkdf2PasswordEncoder layout which is the encoded salt followed by the key into the
+// encoded form used by the pbkdf2.Digest. The secret is appended to the salt.
+func decodePBKDF2(config PBKDF2, data string) (encodedDigest string, err error) {
+	var raw []byte
+
+	if config.Base64 {
+		raw, err = base64.StdEncoding.DecodeString(data)
+	} else {
+		raw, err = hex.DecodeString(data)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	if len(raw) <= config.SaltLength {
+		return "", fmt.Errorf("%w: salt and key have %d bytes but must have more than %d bytes", algorithm.ErrEncodedHashKeyEncoding, len(raw), config.SaltLength)
+	}
+
+	salt := append(append([]byte{}, raw[:config.SaltLength]...), config.Secret...)
+
+	return fmt.Sprintf(pbkdf2.EncodingFmt, config.Variant.Prefix(), config.Iterations,
+		encoding.Base64RawAdaptedEncoding.EncodeToString(salt),
+		encoding.Base64RawAdaptedEncoding.EncodeToString(raw[config.SaltLength:]),
+	), nil
+}
+
+// decodeSCrypt converts the Spring SCryptPasswordEncoder layout which is $<hex params>$<salt>$<key> into the encoded
+// form used by the scrypt.Digest. The params are the log2 of N, r, and p packed into 16, 8, and 8 bits respectively.
+func decodeSCrypt(data string) (encodedDigest string, err error) {
+	parts := phc.Split(data, -1)
+
+	if len(parts) != 4 || parts[0] != "" {
+		return "", algorithm.ErrEncodedHashInvalidFormat
+	}
+
+	var (
+		params    uint64
+		salt, key []byte
+	)
+
+	if params, err = strconv.ParseUint(parts[1], 16, 64); err != nil {
+		return "", fmt.Errorf("%w: params could not be parsed: %v", algorithm.ErrEncodedHashInvalidOptionValue, err)
+	}
+
+	if salt, err = base64.StdEncoding.DecodeString(parts[2]); err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashSaltEncoding, err)
+	}
+
+	if key, err = base64.StdEncoding.DecodeString(parts[3]); err != nil {
+		return "", fmt.Errorf("%w: %v", algorithm.ErrEncodedHashKeyEncoding, err)
+	}
+
+	return fmt.Sprintf(scrypt.EncodingFmt, scrypt.AlgIdentifier, params>>16&0xffff, params>>8&0xff, params&0xff,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func encodeSCrypt(digest *scrypt.Digest) (value string, err error) {
+	parts := phc.Split(digest.Canonical().Encode(), -1)
+
+	if len(parts) != 5 || parts[1] != scrypt.AlgIdentifier {
+		return "", fmt.Errorf("%s encode error: scrypt digests must use the scrypt variant", AlgName)
+	}
+
+	if len(digest.Key()) != SCryptKeyLength {
+		return "", fmt.Errorf("%s encode error: scrypt digests must have a key length of %d bytes but has %d bytes", AlgName, SCryptKeyLength, len(digest.Key()))
+	}
+
+	var (
+		params []phc.Parameter
+		ln     int
+		r, p   int
+	)
+
+	if params, err = phc.DecodeParameters(parts[2]); err != nil {
+		return "", fmt.Errorf("%s encode error: %w", AlgName, err)
+	}
+
+	for _, param := range params {
+		var v int
+
+		if v, err = param.Int(); err != nil {
+			return "", fmt.Errorf("%s encode error: %w", AlgName, err)
+		}
+
+		switch param.Key {
+		case "ln":
+			ln = v
+		case "r":
+			r = v
+		case "p":
+			p = v
+		}
+	}
+
+	if r > 0xff || p > 0xff {
+		return "", fmt.Errorf("%s encode error: scrypt digests must have a block size and parallelism of at most 255", AlgName)
+	}
+
+	return "{" + IDSCrypt + "}" + fmt.Sprintf(EncodingFmtSCrypt, ln<<16|r<<8|p,
+		base64.StdEncoding.EncodeToString(digest.Salt()),
+		base64.StdEncoding.EncodeToString(digest.Key()),
+	), nil
+}
+
+func encodeWithoutStoragePrefix(digest algorithm.StoragePrefixDigest) (encodedDigest string) {
+	return strings.TrimPrefix(digest.Encode(), digest.StoragePrefix())
+}
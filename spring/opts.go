@@ -0,0 +1,75 @@
+package spring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+)
+
+// Opt describes the functional option pattern for the spring.Encoder.
+type Opt func(e *Encoder) (err error)
+
+// PBKDF2 describes the configuration of a Spring Pbkdf2PasswordEncoder. These parameters are not included in the
+// encoded digest so they must match the configuration of the Spring application.
+type PBKDF2 struct {
+	// Variant is the pbkdf2.Variant matching the SecretKeyFactoryAlgorithm.
+	Variant pbkdf2.Variant
+
+	// Iterations is the number of iterations.
+	Iterations int
+
+	// SaltLength is the salt size in bytes.
+	SaltLength int
+
+	// KeyLength is the key size in bytes which is the hash width divided by 8.
+	KeyLength int
+
+	// Secret is the secret which is appended to the salt.
+	Secret []byte
+
+	// Base64 is true if the digest is encoded using base64 instead of hex.
+	Base64 bool
+}
+
+// WithPBKDF2 configures the Spring Pbkdf2PasswordEncoder with the provided id replacing the configuration of the
+// built-in ids if they're used. The pbkdf2 id uses the Spring Security 5.5 defaults and the
+// pbkdf2@SpringSecurity_v5_8 id uses the Spring Security 5.8 defaults unless configured otherwise.
+func WithPBKDF2(id string, config PBKDF2) Opt {
+	return func(e *Encoder) (err error) {
+		switch {
+		case id == "" || strings.ContainsAny(id, "{}"):
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: id '%s' is invalid", algorithm.ErrParameterInvalid, id))
+		case config.Variant.HashFunc() == nil:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: pbkdf2 variant '%d' is invalid", algorithm.ErrParameterInvalid, config.Variant))
+		case config.Iterations < 1:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: pbkdf2 iterations must be greater than 0 but is set to '%d'", algorithm.ErrParameterInvalid, config.Iterations))
+		case config.SaltLength < 1:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: pbkdf2 salt length must be greater than 0 but is set to '%d'", algorithm.ErrParameterInvalid, config.SaltLength))
+		case config.KeyLength < 1:
+			return fmt.Errorf(algorithm.ErrFmtHasherValidation, AlgName, fmt.Errorf("%w: pbkdf2 key length must be greater than 0 but is set to '%d'", algorithm.ErrParameterInvalid, config.KeyLength))
+		}
+
+		for i, c := range e.pbkdf2 {
+			if c.id == id {
+				e.pbkdf2[i].config = config
+
+				return nil
+			}
+		}
+
+		e.pbkdf2 = append(e.pbkdf2, encoderPBKDF2{id: id, config: config})
+
+		return nil
+	}
+}
+
+// WithStandardSecret sets the secret of the Spring StandardPasswordEncoder used by the sha256 id.
+func WithStandardSecret(secret []byte) Opt {
+	return func(e *Encoder) (err error) {
+		e.secret = secret
+
+		return nil
+	}
+}
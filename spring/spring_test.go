@@ -0,0 +1,364 @@
+package spring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/go-crypt/crypt/algorithm/bcrypt"
+	"github.com/go-crypt/crypt/algorithm/md5crypt"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/go-crypt/crypt/algorithm/plaintext"
+	"github.com/go-crypt/crypt/algorithm/scrypt"
+)
+
+const (
+	password = "password"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []Opt
+		have     string
+		expected algorithm.Digest
+		encoded  string
+	}{
+		{
+			"ShouldDecodeBcrypt",
+			nil,
+			"{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG",
+			&bcrypt.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeArgon2",
+			nil,
+			"{argon2}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			&argon2.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeArgon2V5_8",
+			nil,
+			"{argon2@SpringSecurity_v5_8}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+			&argon2.Digest{},
+			"{argon2}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU",
+		},
+		{
+			"ShouldDecodePBKDF2",
+			nil,
+			"{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc",
+			&pbkdf2.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodePBKDF2V5_8",
+			nil,
+			"{pbkdf2@SpringSecurity_v5_8}000102030405060708090a0b0c0d0e0fe0f65a4bf6716253d2d10a7a4b18f35cd4baf31ff031a187cd0091674905482d",
+			&pbkdf2.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodePBKDF2WithSecretAndBase64",
+			[]Opt{WithPBKDF2(IDPBKDF2V5_8, PBKDF2{Variant: pbkdf2.VariantSHA256, Iterations: 310000, SaltLength: 16, KeyLength: 32, Secret: []byte("secret"), Base64: true})},
+			"{pbkdf2@SpringSecurity_v5_8}AAECAwQFBgcICQoLDA0OD0a+ytUbgPjaRbAX0BgFyBPUKk8+zpkLv6Re4PzjJECl",
+			&pbkdf2.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeSCrypt",
+			nil,
+			"{scrypt}$e0801$8bWJaSu2IKSn9Z9kM+TPXfOc/9bdYSrN1oD9qfVThWEwdRTnO7re7Ei+fUZRJ68k9lTyuTeUp4of4g24hHnazw==$OAOec05+bXxvuu/1qZ6NUR+xQYvYv7BeL1QxwRpY5Pc=",
+			&scrypt.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeSCryptV5_8",
+			nil,
+			"{scrypt@SpringSecurity_v5_8}$100801$AAECAwQFBgcICQoLDA0ODw==$jWPkcxERY25E9gwism7ggXZkARLbUPyOZiOM5ZQx95s=",
+			&scrypt.Digest{},
+			"{scrypt}$100801$AAECAwQFBgcICQoLDA0ODw==$jWPkcxERY25E9gwism7ggXZkARLbUPyOZiOM5ZQx95s=",
+		},
+		{
+			"ShouldDecodeNoop",
+			nil,
+			"{noop}password",
+			&plaintext.Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeStandard",
+			nil,
+			"{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0",
+			&Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeStandardWithSecret",
+			[]Opt{WithStandardSecret([]byte("secret"))},
+			"{sha256}0001020304050607f8d0b9d8610c368371f82ab16c259a7341634ae63a9a9f2d56a445bdb0025ba3",
+			&Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeMD5",
+			nil,
+			"{MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+			&Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeMD5WithSalt",
+			nil,
+			"{MD5}{c2FsdA==}abd04ad96eadacb48f73ef84c7de82c9",
+			&Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeSHA1WithSalt",
+			nil,
+			"{SHA-1}{c2FsdA==}6977fb252c9546a8465b8f02f3c2814277e3c7c5",
+			&Digest{},
+			"",
+		},
+		{
+			"ShouldDecodeSHA256",
+			nil,
+			"{SHA-256}5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+			&Digest{},
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := New(tc.opts...)
+			require.NoError(t, err)
+
+			digest, err := e.Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.IsType(t, tc.expected, digest)
+			assert.True(t, digest.Match(password))
+			assert.False(t, digest.Match("notpassword"))
+
+			value, err := e.Encode(digest)
+
+			require.NoError(t, err)
+
+			if tc.encoded == "" {
+				assert.Equal(t, tc.have, value)
+			} else {
+				assert.Equal(t, tc.encoded, value)
+			}
+		})
+	}
+}
+
+func TestDecodeStoragePrefix(t *testing.T) {
+	digest, err := Decode("{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG")
+
+	require.NoError(t, err)
+	assert.Equal(t, "{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG", digest.Encode())
+
+	assert.Equal(t, "$2b$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG", crypt.EncodeCanonical(digest))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldErrNoID", "$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG", "spring decode error: provided encoded hash has an invalid format: the digest doesn't begin with an id"},
+		{"ShouldErrUnknownID", "{foo}abc", "spring decode error: provided encoded hash has an invalid identifier: id 'foo' is unknown"},
+		{"ShouldErrCaseSensitiveID", "{md5}5f4dcc3b5aa765d61d8327deb882cf99", "spring decode error: provided encoded hash has an invalid identifier: id 'md5' is unknown"},
+		{"ShouldErrPBKDF2Hex", "{pbkdf2}zz", "spring decode error: provided encoded hash has a key value that can't be decoded: encoding/hex: invalid byte: U+007A 'z'"},
+		{"ShouldErrPBKDF2Short", "{pbkdf2}0001020304050607", "spring decode error: provided encoded hash has a key value that can't be decoded: salt and key have 8 bytes but must have more than 8 bytes"},
+		{"ShouldErrSCryptFormat", "{scrypt}$e0801$abc", "spring decode error: provided encoded hash has an invalid format"},
+		{"ShouldErrSCryptParams", "{scrypt}$zz$AAAA$AAAA", "spring decode error: provided encoded hash has an invalid option value: params could not be parsed: strconv.ParseUint: parsing \"zz\": invalid syntax"},
+		{"ShouldErrStandardLength", "{sha256}00", "spring decode error: provided encoded hash has a key value that can't be decoded: salt and key have 1 bytes but must have 40 bytes"},
+		{"ShouldErrMD5Length", "{MD5}00", "spring decode error: provided encoded hash has a key value that can't be decoded: key has 1 bytes but must have 16 bytes"},
+		{"ShouldErrMD5Salt", "{MD5}{abc00", "spring decode error: provided encoded hash has a salt value that can't be decoded: the salt doesn't have a closing brace"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := Decode(tc.have)
+
+			assert.Nil(t, digest)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	bcryptHasher, err := bcrypt.New(bcrypt.WithVariant(bcrypt.VariantSHA256), bcrypt.WithCost(bcrypt.IterationsMin))
+	require.NoError(t, err)
+
+	bcryptSHA256, err := bcryptHasher.Hash(password)
+	require.NoError(t, err)
+
+	pbkdf2Hasher, err := pbkdf2.NewSHA512()
+	require.NoError(t, err)
+
+	pbkdf2SHA512, err := pbkdf2Hasher.Hash(password)
+	require.NoError(t, err)
+
+	argon2Secret, err := argon2.Decode("$argon2id$v=19$m=65536,t=3,p=4,keyid=AAAA$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU")
+	require.NoError(t, err)
+
+	yescrypt, err := scrypt.Decode("$y$j9T$AAt9R641xPvCI9nXw1HHW/$cuQRBMN3N/f8IcmVN.4YrZ1bHMOiLOoz9/XQMKV/v0A")
+	require.NoError(t, err)
+
+	md5, err := md5crypt.Decode("$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     algorithm.Digest
+		expected string
+	}{
+		{"ShouldErrNil", nil, "can't encode a nil digest"},
+		{"ShouldErrBcryptSHA256", bcryptSHA256, "spring encode error: bcrypt digests must use the standard variant"},
+		{"ShouldErrPBKDF2Unmatched", pbkdf2SHA512, "spring encode error: pbkdf2 digest parameters do not match any of the configured ids"},
+		{"ShouldErrArgon2Secret", argon2Secret, "spring encode error: argon2 digests must not use a secret or associated data"},
+		{"ShouldErrYeScrypt", yescrypt, "spring encode error: scrypt digests must use the scrypt variant"},
+		{"ShouldErrUnsupported", md5, "spring encode error: digests of type '*md5crypt.Digest' are not supported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := Encode(tc.have)
+
+			assert.Equal(t, "", value)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	e, err := New()
+	require.NoError(t, err)
+
+	var _ crypt.Format = e
+
+	decoder := crypt.NewDecoderStrict()
+
+	require.NoError(t, decoder.RegisterFormat(e))
+	require.NoError(t, bcrypt.RegisterDecoder(decoder))
+	require.NoError(t, argon2.RegisterDecoder(decoder))
+	require.NoError(t, pbkdf2.RegisterDecoder(decoder))
+	require.NoError(t, scrypt.RegisterDecoderScrypt(decoder))
+	require.NoError(t, plaintext.RegisterDecoderPlainText(decoder))
+	require.NoError(t, e.RegisterDecoder(decoder))
+
+	testCases := []struct {
+		name     string
+		have     string
+		digest   algorithm.Digest
+		expected string
+	}{
+		{"ShouldNormalizeBcrypt", "{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG", &bcrypt.Digest{}, ""},
+		{"ShouldNormalizeArgon2", "{argon2}$argon2id$v=19$m=65536,t=3,p=4$QmkpoTw3W72fzd7RrWofuw$r0xig+VVj7ynnE2S1jrE5us7dPKv2S2ff6Z6ts4mVuU", &argon2.Digest{}, ""},
+		{"ShouldNormalizePBKDF2", "{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc", &pbkdf2.Digest{}, ""},
+		{"ShouldNormalizeSCryptV5_8", "{scrypt@SpringSecurity_v5_8}$100801$AAECAwQFBgcICQoLDA0ODw==$jWPkcxERY25E9gwism7ggXZkARLbUPyOZiOM5ZQx95s=", &scrypt.Digest{}, "{scrypt}$100801$AAECAwQFBgcICQoLDA0ODw==$jWPkcxERY25E9gwism7ggXZkARLbUPyOZiOM5ZQx95s="},
+		{"ShouldNormalizeNoop", "{noop}password", &plaintext.Digest{}, ""},
+		{"ShouldNotNormalizeMD5", "{MD5}5f4dcc3b5aa765d61d8327deb882cf99", &Digest{}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := decoder.Decode(tc.have)
+			require.NoError(t, err)
+
+			assert.IsType(t, tc.digest, digest)
+			assert.True(t, digest.Match(password))
+
+			expected := tc.expected
+
+			if expected == "" {
+				expected = tc.have
+			}
+
+			value, err := decoder.EncodeAs(digest, FormatName)
+			require.NoError(t, err)
+			assert.Equal(t, expected, value)
+		})
+	}
+
+	encodedDigest, ok := e.Normalize("{foo}abc")
+	assert.False(t, ok)
+	assert.Equal(t, "{foo}abc", encodedDigest)
+
+	encodedDigest, ok = e.Normalize("{scrypt}$e0801$abc")
+	assert.False(t, ok)
+	assert.Equal(t, "{scrypt}$e0801$abc", encodedDigest)
+
+	encodedDigest, ok = e.Normalize("{pbkdf2}zz")
+	assert.False(t, ok)
+	assert.Equal(t, "{pbkdf2}zz", encodedDigest)
+}
+
+func TestNewOptionErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     Opt
+		expected string
+	}{
+		{"ShouldErrID", WithPBKDF2("{pbkdf2}", PBKDF2{Variant: pbkdf2.VariantSHA1, Iterations: 1, SaltLength: 1, KeyLength: 1}), "spring validation error: parameter is invalid: id '{pbkdf2}' is invalid"},
+		{"ShouldErrVariant", WithPBKDF2(IDPBKDF2, PBKDF2{Iterations: 1, SaltLength: 1, KeyLength: 1}), "spring validation error: parameter is invalid: pbkdf2 variant '0' is invalid"},
+		{"ShouldErrIterations", WithPBKDF2(IDPBKDF2, PBKDF2{Variant: pbkdf2.VariantSHA1, SaltLength: 1, KeyLength: 1}), "spring validation error: parameter is invalid: pbkdf2 iterations must be greater than 0 but is set to '0'"},
+		{"ShouldErrSaltLength", WithPBKDF2(IDPBKDF2, PBKDF2{Variant: pbkdf2.VariantSHA1, Iterations: 1, KeyLength: 1}), "spring validation error: parameter is invalid: pbkdf2 salt length must be greater than 0 but is set to '0'"},
+		{"ShouldErrKeyLength", WithPBKDF2(IDPBKDF2, PBKDF2{Variant: pbkdf2.VariantSHA1, Iterations: 1, SaltLength: 1}), "spring validation error: parameter is invalid: pbkdf2 key length must be greater than 0 but is set to '0'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := New(tc.have)
+
+			assert.Nil(t, e)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	d := crypt.NewDecoder()
+
+	e, err := New(WithPBKDF2("pbkdf2-custom", PBKDF2{Variant: pbkdf2.VariantSHA256, Iterations: 310000, SaltLength: 16, KeyLength: 32}))
+	require.NoError(t, err)
+
+	require.NoError(t, e.RegisterDecoder(d))
+
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldDecodeBcrypt", "{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG"},
+		{"ShouldDecodePBKDF2", "{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc"},
+		{"ShouldDecodePBKDF2Custom", "{pbkdf2-custom}000102030405060708090a0b0c0d0e0fe0f65a4bf6716253d2d10a7a4b18f35cd4baf31ff031a187cd0091674905482d"},
+		{"ShouldDecodeStandard", "{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := d.Decode(tc.have)
+
+			require.NoError(t, err)
+			assert.True(t, digest.Match(password))
+		})
+	}
+
+	assert.True(t, e.MatchEncoded("{pbkdf2-custom}abc"))
+	assert.False(t, e.MatchEncoded("{foo}abc"))
+	assert.False(t, e.MatchEncoded("$2a$10$abc"))
+
+	_, err = d.Decode("{foo}abc")
+	assert.Error(t, err)
+}
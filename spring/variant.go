@@ -0,0 +1,83 @@
+package spring
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+
+	"github.com/go-crypt/crypt/algorithm"
+)
+
+// NewVariant converts a Spring id to a spring.Variant.
+func NewVariant(id string) (variant Variant) {
+	switch id {
+	case IDStandard:
+		return VariantStandard
+	case IDMD5:
+		return VariantMD5
+	case IDSHA1:
+		return VariantSHA1
+	case IDSHA256:
+		return VariantSHA256
+	default:
+		return VariantNone
+	}
+}
+
+// Variant is a variant of the spring.Digest.
+type Variant int
+
+const (
+	// VariantNone is a variant of the spring.Digest which is unknown.
+	VariantNone Variant = iota
+
+	// VariantStandard is the variant of the spring.Digest which is produced by the Spring StandardPasswordEncoder.
+	VariantStandard
+
+	// VariantMD5 is the variant of the spring.Digest which is produced by the Spring MessageDigestPasswordEncoder using
+	// MD5.
+	VariantMD5
+
+	// VariantSHA1 is the variant of the spring.Digest which is produced by the Spring MessageDigestPasswordEncoder
+	// using SHA-1.
+	VariantSHA1
+
+	// VariantSHA256 is the variant of the spring.Digest which is produced by the Spring MessageDigestPasswordEncoder
+	// using SHA-256.
+	VariantSHA256
+)
+
+// String implements the fmt.Stringer returning the Spring id of the spring.Variant.
+func (v Variant) String() (id string) {
+	switch v {
+	case VariantStandard:
+		return IDStandard
+	case VariantMD5:
+		return IDMD5
+	case VariantSHA1:
+		return IDSHA1
+	case VariantSHA256:
+		return IDSHA256
+	default:
+		return
+	}
+}
+
+// Prefix returns the spring.Variant prefix such as {sha256}.
+func (v Variant) Prefix() (prefix string) {
+	return "{" + v.String() + "}"
+}
+
+// HashFunc returns the algorithm.HashFunc of the spring.Variant.
+func (v Variant) HashFunc() algorithm.HashFunc {
+	switch v {
+	case VariantMD5:
+		return md5.New
+	case VariantSHA1:
+		return sha1.New
+	case VariantStandard, VariantSHA256:
+		return sha256.New
+	default:
+		return nil
+	}
+}
@@ -1,10 +1,14 @@
 package crypt
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-crypt/crypt/algorithm/argon2"
 )
 
 func TestArgon2Outputs(t *testing.T) {
@@ -24,6 +28,22 @@ func TestArgon2Outputs(t *testing.T) {
 			"ShouldValidatePasswordArgon2d",
 			"$argon2d$v=19$m=65536,t=3,p=4$HV/RIiFSYEMoRYqBcFnqfg$eGNckPZjkL2xOIHZv8Q4ROg5xbcdD8ijIJOgPwVAPmA",
 		},
+		{
+			"ShouldValidatePasswordArgon2idVersion10",
+			"$argon2id$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$mA69JKTmZ/FjRvnUp4sXVyh4NhPgzG+xfC7IhLFkNd8",
+		},
+		{
+			"ShouldValidatePasswordArgon2iVersion10",
+			"$argon2i$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ",
+		},
+		{
+			"ShouldValidatePasswordArgon2iVersion10WithoutVersion",
+			"$argon2i$m=65536,t=2,p=1$c29tZXNhbHQ$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ",
+		},
+		{
+			"ShouldValidatePasswordArgon2dVersion10",
+			"$argon2d$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$LsDZJTWPWDDK8MHMij7lizRQV1lCi4WcebckFfUfkiE",
+		},
 	}
 
 	for _, tc := range testcCases {
@@ -45,3 +65,38 @@ func TestArgon2Outputs(t *testing.T) {
 
 	}
 }
+
+func TestArgon2SecretLookup(t *testing.T) {
+	secrets := map[string][]byte{
+		"key1": bytes.Repeat([]byte{0x03}, 8),
+	}
+
+	lookup := func(keyid []byte) (secret []byte, err error) {
+		var ok bool
+
+		if secret, ok = secrets[string(keyid)]; !ok {
+			return nil, fmt.Errorf("unknown key id '%s'", keyid)
+		}
+
+		return secret, nil
+	}
+
+	d := NewDecoder()
+
+	require.NoError(t, argon2.RegisterDecoderWithSecretLookup(d, lookup))
+
+	password := string(bytes.Repeat([]byte{0x01}, 32))
+
+	digest, err := d.Decode("$argon2id$v=19$m=32,t=3,p=4,keyid=a2V5MQ,data=BAQEBAQEBAQEBAQE$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+	require.NoError(t, err)
+
+	assert.True(t, digest.Match(password))
+	assert.False(t, digest.Match(wrongPassword))
+
+	digest, err = d.Decode("$argon2id$v=19$m=32,t=3,p=4,keyid=a2V5Mg$AgICAgICAgICAgICAgICAg$DWQN9Y14dmwIwDejSotTydAe8EUtdbZetSUg6WsB5lk")
+	require.NoError(t, err)
+
+	match, err := digest.MatchAdvanced(password)
+	assert.EqualError(t, err, "argon2 match error: could not resolve the secret: unknown key id 'key2'")
+	assert.False(t, match)
+}
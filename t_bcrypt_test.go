@@ -44,6 +44,22 @@ func TestBcryptOutputs(t *testing.T) {
 			"ShouldValidatePasswordSHA256VariantY",
 			"$bcrypt-sha256$v=2,t=2y,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC",
 		},
+		{
+			"ShouldValidatePasswordSHA256Version1VariantB",
+			"$bcrypt-sha256$2b,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+		},
+		{
+			"ShouldValidatePasswordSHA256Version1VariantA",
+			"$bcrypt-sha256$2a,10$E/e/2AOEqqqqqqqqqqqqqe$ezuk8qOS1HsmW622joN8tgodaYyp.sq",
+		},
+		{
+			"ShouldValidatePasswordSHA384",
+			"$bcrypt-sha384$v=2,t=2b,r=10$E/e/2AOEqqqqqqqqqqqqqe$JRqYp0g7CT.Zv8.Q17FnsmXYq6qiEPe",
+		},
+		{
+			"ShouldValidatePasswordSHA512",
+			"$bcrypt-sha512$v=2,t=2b,r=10$E/e/2AOEqqqqqqqqqqqqqe$96EdadHIY99dTuFohZJ3kLf0vq/XlZK",
+		},
 	}
 
 	for _, tc := range testcCases {
@@ -32,6 +32,22 @@ func TestPBKDF2Outputs(t *testing.T) {
 			"ShouldValidatePasswordSHA512",
 			"$pbkdf2-sha512$100000$bHfSOIyj0UDoCo1Q4Bz49w$v/olF/T/R6On84NuHlNCiI/sUwsdyOC7J4cO8Cz7feNtLHHEKNjayeEZj0b/Js/cgkMK6zLFw2vynLo2el028Q",
 		},
+		{
+			"ShouldValidatePasswordSHA512_256",
+			"$pbkdf2-sha512-256$100000$c2FsdHNhbHRzYWx0c2FsdA$9hiJghtBBDKd1PScX1FcwiOR4lsiaKv3ZXf8l1KQn60",
+		},
+		{
+			"ShouldValidatePasswordSHA3_256",
+			"$pbkdf2-sha3-256$100000$c2FsdHNhbHRzYWx0c2FsdA$6rD.bQJ1sk.mC0QWGlnPXebYzIDYio9AeXPOaMObAZ0",
+		},
+		{
+			"ShouldValidatePasswordSHA3_512",
+			"$pbkdf2-sha3-512$100000$c2FsdHNhbHRzYWx0c2FsdA$F4lfhZZjOvd./9wcOP8k7gNn0fgWJIwgCqJJYPynTcauZbQlT0YHRe8axZkqVAXaat1aqqBzdYBCxT/ChLhZ2w",
+		},
+		{
+			"ShouldValidatePasswordBLAKE2b_512",
+			"$pbkdf2-blake2b-512$100000$c2FsdHNhbHRzYWx0c2FsdA$IhrWV5YRbRkKlBR85IGwdNkcA.NpuWcOlXODqak2WWZL6Sq4DgdD1swa/UO2Zt4abtl2QjkZRL8zaVJva2IS9g",
+		},
 	}
 
 	for _, tc := range testcCases {
@@ -62,6 +62,46 @@ func TestScryptOutputsNative(t *testing.T) {
 			"$y$jD5$K3wjJ.n1W9g1TfLeI0ESC0$SAt46wIbyewhlHlKVQcelosVETYUGOaV6mC1qjurql9",
 			"password",
 		},
+		{
+			"ShouldValidateYesCryptOutputLibxcryptParallelismAndTime",
+			"$y$j750//$saltsaltsaltsalt$E.bpTc857WEVvUt4el8pJg8f.Swhxr4xLSauXF3jnX6",
+			"password",
+		},
+		{
+			"ShouldValidateYesCryptOutputLibxcryptWORM",
+			"$y$/75$saltsaltsaltsalt$ajDS8nt1YQa5qYzZoBxnzBW5fej7mTblixS1htlz7L4",
+			"password",
+		},
+		{
+			"ShouldValidateScryptCryptOutputLibxcrypt",
+			"$7$A/..../....saltsalt$/RU7hqIxpoLYVsvdsnV7cZJ3ylkmYjXt5MKWnTIORh4",
+			"password",
+		},
+		{
+			"ShouldValidateScryptCryptOutputLibxcryptParallelism",
+			"$7$A/....0....s$lMxXBgK70ZVye/KvAJXMEU7UT8X77peuKwLC5Y2BTO3",
+			"password",
+		},
+		{
+			"ShouldValidateScryptCryptOutputLibxcryptEmptySalt",
+			"$7$AU..../....$lSdxqDDAe/D/QhQTxdruBliS4129.1IbXLqGaPuTDZD",
+			"password",
+		},
+		{
+			"ShouldValidateGostYescryptOutputLibxcrypt",
+			"$gy$j75$saltsaltsaltsalt$ay0RnPzeHnpJWVwfMGx3wS3yP/c5bVfWY9qMfl56DD8",
+			"password",
+		},
+		{
+			"ShouldValidateGostYescryptOutputLibxcryptLargeBlockSize",
+			"$gy$j9T$saltsaltsaltsalt$rJwUc/Ira7bf5YaD7oIyqgudtsbNclaiv18Jg66v.j.",
+			"password",
+		},
+		{
+			"ShouldValidateGostYescryptOutputLibxcryptWORM",
+			"$gy$/75$saltsaltsaltsalt$zTygx2vG5SiydZPpuMeD5SVZXo8AHAiJO6DbbQP4Xs6",
+			"password",
+		},
 	}
 
 	for _, tc := range testcCases {
@@ -70,6 +70,21 @@ func (d *Digest) String() string {
 	return d.digest.String()
 }
 
+// Canonical returns a crypt.Digest which wraps the canonical form of the algorithm.Digest.
+func (d *Digest) Canonical() *Digest {
+	return &Digest{digest: Canonical(d.digest)}
+}
+
+// EncodeCanonical returns the canonical encoded form of the algorithm.Digest.
+func (d *Digest) EncodeCanonical() string {
+	return EncodeCanonical(d.digest)
+}
+
+// IsCanonical returns true if the algorithm.Digest is encoded in the canonical form.
+func (d *Digest) IsCanonical() bool {
+	return IsCanonical(d.digest)
+}
+
 // MatchBytes decorates the algorithm.Digest MatchBytes function.
 func (d *Digest) MatchBytes(passwordBytes []byte) (match bool) {
 	return d.digest.MatchBytes(passwordBytes)
@@ -90,6 +105,20 @@ func (d *Digest) Match(password string) (match bool) {
 	return d.digest.Match(password)
 }
 
+// MatchWithContext decorates the algorithm.ContextualMatcher MatchWithContext function if the algorithm.Digest
+// implements it, otherwise it decorates the algorithm.Digest Match function.
+func (d *Digest) MatchWithContext(password string, ctx algorithm.MatchContext) (match bool) {
+	match, _ = MatchWithContext(d.digest, password, ctx)
+
+	return match
+}
+
+// MatchAdvancedWithContext decorates the algorithm.ContextualMatcher MatchAdvancedWithContext function if the
+// algorithm.Digest implements it, otherwise it decorates the algorithm.Digest MatchAdvanced function.
+func (d *Digest) MatchAdvancedWithContext(password string, ctx algorithm.MatchContext) (match bool, err error) {
+	return MatchWithContext(d.digest, password, ctx)
+}
+
 // Key returns the key which is the final result of this digest.
 func (d *Digest) Key() (key []byte) {
 	return d.digest.Key()
@@ -190,6 +219,33 @@ func (d *NullDigest) String() string {
 	return d.digest.String()
 }
 
+// Canonical returns a crypt.NullDigest which wraps the canonical form of the algorithm.Digest.
+func (d *NullDigest) Canonical() *NullDigest {
+	if d.digest == nil {
+		return &NullDigest{}
+	}
+
+	return &NullDigest{digest: Canonical(d.digest)}
+}
+
+// EncodeCanonical returns the canonical encoded form of the algorithm.Digest.
+func (d *NullDigest) EncodeCanonical() string {
+	if d.digest == nil {
+		return ""
+	}
+
+	return EncodeCanonical(d.digest)
+}
+
+// IsCanonical returns true if the algorithm.Digest is encoded in the canonical form.
+func (d *NullDigest) IsCanonical() bool {
+	if d.digest == nil {
+		return true
+	}
+
+	return IsCanonical(d.digest)
+}
+
 // Match decorates the algorithm.Digest Match function.
 func (d *NullDigest) Match(password string) (match bool) {
 	if d.digest == nil {
@@ -226,6 +282,28 @@ func (d *NullDigest) MatchBytesAdvanced(passwordBytes []byte) (match bool, err e
 	return d.digest.MatchBytesAdvanced(passwordBytes)
 }
 
+// MatchWithContext decorates the algorithm.ContextualMatcher MatchWithContext function if the algorithm.Digest
+// implements it, otherwise it decorates the algorithm.Digest Match function.
+func (d *NullDigest) MatchWithContext(password string, ctx algorithm.MatchContext) (match bool) {
+	if d.digest == nil {
+		return false
+	}
+
+	match, _ = MatchWithContext(d.digest, password, ctx)
+
+	return match
+}
+
+// MatchAdvancedWithContext decorates the algorithm.ContextualMatcher MatchAdvancedWithContext function if the
+// algorithm.Digest implements it, otherwise it decorates the algorithm.Digest MatchAdvanced function.
+func (d *NullDigest) MatchAdvancedWithContext(password string, ctx algorithm.MatchContext) (match bool, err error) {
+	if d.digest == nil {
+		return false, nil
+	}
+
+	return MatchWithContext(d.digest, password, ctx)
+}
+
 // Key returns the key which is the final result of this digest.
 func (d *NullDigest) Key() (key []byte) {
 	if d.digest == nil {